@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -12,7 +15,9 @@ import (
 	"designhub/internal/repository"
 	"designhub/internal/server"
 	"designhub/internal/service"
+	"designhub/pkg/mailer"
 	"designhub/pkg/migration"
+	"designhub/pkg/pubsub"
 	"designhub/pkg/storage"
 
 	"github.com/jmoiron/sqlx"
@@ -38,6 +43,9 @@ func main() {
 
 	// Загрузка конфигурации
 	cfg := config.NewConfig()
+	if err := cfg.Validate(); err != nil {
+		logrus.Fatalf("Invalid configuration: %s", err.Error())
+	}
 
 	// Инициализация БД
 	db, err := initDB(cfg.DB)
@@ -57,17 +65,63 @@ func main() {
 		logrus.Fatalf("Failed to initialize file storage: %s", err.Error())
 	}
 
+	// Инициализация шины событий реального времени. С несколькими экземплярами
+	// приложения события передаются между ними через LISTEN/NOTIFY
+	var hub pubsub.Hub
+	var postgresHub *pubsub.PostgresHub
+	switch cfg.Realtime.Backend {
+	case "postgres":
+		postgresHub = pubsub.NewPostgresHub(db, cfg.DB.GetDSN(), cfg.Realtime.Channel)
+		hub = postgresHub
+	default:
+		hub = pubsub.NewMemoryHub()
+	}
+
+	// Инициализация отправки писем. Без SMTP письма только пишутся в журнал
+	var mail mailer.Mailer
+	switch cfg.Mail.Backend {
+	case "smtp":
+		mail = mailer.NewSMTPMailer(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+	default:
+		mail = mailer.NewLogMailer()
+	}
+
 	// Инициализация слоев приложения
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos, db, fileStorage)
+	services := service.NewService(repos, db, fileStorage, hub, mail, cfg)
 	handlers := handler.NewHandler(services, fileStorage, cfg)
 
+	// Запуск фоновых задач
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	var jobs sync.WaitGroup
+	runJob := func(job func(ctx context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(jobsCtx)
+		}()
+	}
+
+	runJob(services.Trending.Run)
+	runJob(services.Analytics.Run)
+	runJob(services.Related.Run)
+	runJob(services.Publishing.Run)
+	runJob(services.Trash.Run)
+	runJob(services.Digest.Run)
+
+	if postgresHub != nil {
+		runJob(postgresHub.Run)
+	}
+
 	// Инициализация HTTP сервера
 	srv := server.NewServer(cfg.Server, handlers.InitRoutes())
+	srv.RegisterOnShutdown(handlers.CloseStreams)
 
 	// Запуск сервера в горутине
 	go func() {
-		if err := srv.Run(); err != nil {
+		if err := srv.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logrus.Fatalf("Error occurred while running server: %s", err.Error())
 		}
 	}()
@@ -89,6 +143,10 @@ func main() {
 		logrus.Fatalf("Server forced to shutdown: %s", err.Error())
 	}
 
+	// Останавливаем фоновые задачи и ждем их завершения (например, записи накопленных просмотров)
+	stopJobs()
+	jobs.Wait()
+
 	logrus.Print("DesignHub server exited properly")
 }
 
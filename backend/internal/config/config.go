@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -20,14 +23,61 @@ const (
 	defaultDBMaxOpenConns    = 20
 	defaultDBMaxIdleConns    = 20
 	defaultDBConnMaxLifetime = time.Hour
+
+	defaultTrendingInterval = 10 * time.Minute
+	defaultTrendingGravity  = 1.8
+
+	defaultRelatedCacheTTL = 15 * time.Minute
+	defaultRelatedLimit    = 8
+
+	defaultViewDedupWindow   = 30 * time.Minute
+	defaultViewFlushInterval = time.Minute
+
+	defaultPublishingInterval = time.Minute
+
+	defaultRemoderationFields = "title,description,category_id"
+
+	defaultTrashRetentionDays = 30
+	defaultTrashPurgeInterval = time.Hour
+
+	defaultCommentsMaxDepth = 3
+
+	defaultReactionTypes = "inspiring,great-typography,clean-ui,bold-colors,smooth-motion"
+
+	defaultRealtimeBackend   = "memory"
+	defaultRealtimeChannel   = "designhub_events"
+	defaultRealtimeKeepAlive = 25 * time.Second
+	defaultRealtimeMaxPosts  = 50
+
+	defaultMailBackend = "log"
+	defaultSMTPPort    = 587
+
+	defaultDigestInterval      = 15 * time.Minute
+	defaultDigestBatchSize     = 100
+	defaultDigestPostsLimit    = 6
+	defaultDigestActivityLimit = 10
+
+	defaultReportsAutoHideThreshold = 5
 )
 
 type (
 	Config struct {
-		Server ServerConfig
-		DB     DBConfig
-		JWT    JWTConfig
-		Storage StorageConfig
+		Server     ServerConfig
+		DB         DBConfig
+		JWT        JWTConfig
+		Storage    StorageConfig
+		Trending   TrendingConfig
+		Related    RelatedConfig
+		Analytics  AnalyticsConfig
+		Publishing PublishingConfig
+		Moderation ModerationConfig
+		Trash      TrashConfig
+		Comments   CommentsConfig
+		Reactions  ReactionsConfig
+		Realtime   RealtimeConfig
+		Mail       MailConfig
+		Digest     DigestConfig
+		Reports    ReportsConfig
 	}
 
 	ServerConfig struct {
@@ -36,6 +86,9 @@ type (
 		WriteTimeout time.Duration
 		MaxBodyBytes int64
 		Secure       bool
+		// Адреса или подсети прокси, которым доверяется X-Forwarded-For.
+		// Пустой список — клиентом считается адрес соединения
+		TrustedProxies []string
 	}
 
 	DBConfig struct {
@@ -61,6 +114,73 @@ type (
 		MaxSize    int64
 		AllowTypes []string
 	}
+
+	TrendingConfig struct {
+		Interval time.Duration // Период пересчета оценок трендовых постов
+		Gravity  float64       // Степень затухания оценки со временем
+	}
+
+	RelatedConfig struct {
+		CacheTTL time.Duration // Время жизни кэша похожих постов
+		Limit    int           // Количество похожих постов в ответе
+	}
+
+	AnalyticsConfig struct {
+		ViewDedupWindow   time.Duration // Окно, в котором повторные просмотры одного зрителя не считаются
+		ViewFlushInterval time.Duration // Период записи накопленных просмотров в базу
+		IPHashSalt        string        // Соль для хэширования IP-адресов гостей
+	}
+
+	PublishingConfig struct {
+		Interval time.Duration // Период проверки черновиков и отложенных постов, время публикации которых наступило
+	}
+
+	ModerationConfig struct {
+		RemoderationFields []string // Поля поста, правка которых автором возвращает опубликованный пост на модерацию
+	}
+
+	TrashConfig struct {
+		RetentionDays int           // Сколько дней удаленные записи можно восстановить
+		PurgeInterval time.Duration // Период окончательного удаления записей с истекшим сроком хранения
+	}
+
+	CommentsConfig struct {
+		MaxDepth int // Максимальная вложенность ответов; ответ глубже становится ответом на родителя
+	}
+
+	ReactionsConfig struct {
+		Types []string // Доступные типы реакций на посты и комментарии
+	}
+
+	RealtimeConfig struct {
+		Backend   string        // Шина событий: memory (один экземпляр) или postgres (LISTEN/NOTIFY)
+		Channel   string        // Канал PostgreSQL для бэкенда postgres
+		KeepAlive time.Duration // Период служебных сообщений, чтобы прокси не закрывали поток
+		MaxPosts  int           // Сколько постов можно отслеживать в одном потоке
+	}
+
+	MailConfig struct {
+		Backend  string // Отправка писем: log (только в журнал) или smtp
+		Host     string
+		Port     int
+		Username string
+		Password string
+		From     string // Адрес отправителя
+	}
+
+	DigestConfig struct {
+		Interval          time.Duration // Период проверки пользователей, которым пора отправить дайджест
+		BatchSize         int           // Сколько дайджестов отправляется за один проход
+		PostsLimit        int           // Сколько новых работ попадает в дайджест
+		ActivityLimit     int           // Сколько событий пользователя попадает в дайджест
+		SiteURL           string        // Адрес сайта для ссылок в письме
+		UnsubscribeURL    string        // Адрес эндпоинта отписки в один клик
+		UnsubscribeSecret string        // Ключ подписи ссылок отписки
+	}
+
+	ReportsConfig struct {
+		AutoHideThreshold int // После скольких открытых жалоб от разных пользователей объект скрывается до решения модератора; 0 — не скрывать
+	}
 )
 
 // NewConfig создает новый экземпляр конфигурации
@@ -71,11 +191,12 @@ func NewConfig() *Config {
 
 	return &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", defaultServerPort),
-			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", defaultServerReadTimeout),
-			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", defaultServerWriteTimeout),
-			MaxBodyBytes: getEnvAsInt64("SERVER_MAX_BODY_BYTES", defaultServerMaxBodyBytes),
-			Secure:       getEnvAsBool("SERVER_SECURE", false),
+			Port:           getEnv("SERVER_PORT", defaultServerPort),
+			ReadTimeout:    getEnvAsDuration("SERVER_READ_TIMEOUT", defaultServerReadTimeout),
+			WriteTimeout:   getEnvAsDuration("SERVER_WRITE_TIMEOUT", defaultServerWriteTimeout),
+			MaxBodyBytes:   getEnvAsInt64("SERVER_MAX_BODY_BYTES", defaultServerMaxBodyBytes),
+			Secure:         getEnvAsBool("SERVER_SECURE", false),
+			TrustedProxies: getEnvAsSlice("SERVER_TRUSTED_PROXIES", ""),
 		},
 		DB: DBConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -104,7 +225,98 @@ func NewConfig() *Config {
 				"video/webm",
 			},
 		},
+		Trending: TrendingConfig{
+			Interval: getEnvAsDuration("TRENDING_INTERVAL", defaultTrendingInterval),
+			Gravity:  getEnvAsFloat("TRENDING_GRAVITY", defaultTrendingGravity),
+		},
+		Related: RelatedConfig{
+			CacheTTL: getEnvAsDuration("RELATED_CACHE_TTL", defaultRelatedCacheTTL),
+			Limit:    getEnvAsInt("RELATED_LIMIT", defaultRelatedLimit),
+		},
+		Analytics: AnalyticsConfig{
+			ViewDedupWindow:   getEnvAsDuration("VIEW_DEDUP_WINDOW", defaultViewDedupWindow),
+			ViewFlushInterval: getEnvAsDuration("VIEW_FLUSH_INTERVAL", defaultViewFlushInterval),
+			IPHashSalt:        getEnv("VIEW_IP_HASH_SALT", "designhub-views"),
+		},
+		Publishing: PublishingConfig{
+			Interval: getEnvAsDuration("PUBLISHING_INTERVAL", defaultPublishingInterval),
+		},
+		Moderation: ModerationConfig{
+			RemoderationFields: getEnvAsSlice("POST_REMODERATION_FIELDS", defaultRemoderationFields),
+		},
+		Trash: TrashConfig{
+			RetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", defaultTrashRetentionDays),
+			PurgeInterval: getEnvAsDuration("TRASH_PURGE_INTERVAL", defaultTrashPurgeInterval),
+		},
+		Comments: CommentsConfig{
+			MaxDepth: getEnvAsInt("COMMENTS_MAX_DEPTH", defaultCommentsMaxDepth),
+		},
+		Reactions: ReactionsConfig{
+			Types: getEnvAsSlice("REACTION_TYPES", defaultReactionTypes),
+		},
+		Realtime: RealtimeConfig{
+			Backend:   getEnv("REALTIME_BACKEND", defaultRealtimeBackend),
+			Channel:   getEnv("REALTIME_CHANNEL", defaultRealtimeChannel),
+			KeepAlive: getEnvAsDuration("REALTIME_KEEPALIVE", defaultRealtimeKeepAlive),
+			MaxPosts:  getEnvAsInt("REALTIME_MAX_POSTS", defaultRealtimeMaxPosts),
+		},
+		Mail: MailConfig{
+			Backend:  getEnv("MAIL_BACKEND", defaultMailBackend),
+			Host:     getEnv("SMTP_HOST", "localhost"),
+			Port:     getEnvAsInt("SMTP_PORT", defaultSMTPPort),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "DesignHub <noreply@designhub.local>"),
+		},
+		Digest: DigestConfig{
+			Interval:          getEnvAsDuration("DIGEST_INTERVAL", defaultDigestInterval),
+			BatchSize:         getEnvAsInt("DIGEST_BATCH_SIZE", defaultDigestBatchSize),
+			PostsLimit:        getEnvAsInt("DIGEST_POSTS_LIMIT", defaultDigestPostsLimit),
+			ActivityLimit:     getEnvAsInt("DIGEST_ACTIVITY_LIMIT", defaultDigestActivityLimit),
+			SiteURL:           getEnv("SITE_URL", "http://localhost:3000"),
+			UnsubscribeURL:    getEnv("DIGEST_UNSUBSCRIBE_URL", "http://localhost:8080/api/v1/unsubscribe"),
+			UnsubscribeSecret: getEnv("DIGEST_UNSUBSCRIBE_SECRET", ""),
+		},
+		Reports: ReportsConfig{
+			AutoHideThreshold: getEnvAsInt("REPORTS_AUTO_HIDE_THRESHOLD", defaultReportsAutoHideThreshold),
+		},
+	}
+}
+
+// Validate проверяет настройки, без которых приложение нельзя запускать
+func (cfg *Config) Validate() error {
+	// Ключом подписываются ссылки отписки: зная его, можно отписать любого пользователя
+	if cfg.Digest.UnsubscribeSecret == "" {
+		return errors.New("DIGEST_UNSUBSCRIBE_SECRET is required")
 	}
+
+	for _, proxy := range cfg.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return fmt.Errorf("SERVER_TRUSTED_PROXIES: invalid address %q", proxy)
+		}
+	}
+
+	// Периоды фоновых задач передаются в time.NewTicker, который паникует на значениях <= 0
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"TRENDING_INTERVAL", cfg.Trending.Interval},
+		{"RELATED_CACHE_TTL", cfg.Related.CacheTTL},
+		{"VIEW_FLUSH_INTERVAL", cfg.Analytics.ViewFlushInterval},
+		{"PUBLISHING_INTERVAL", cfg.Publishing.Interval},
+		{"TRASH_PURGE_INTERVAL", cfg.Trash.PurgeInterval},
+		{"REALTIME_KEEPALIVE", cfg.Realtime.KeepAlive},
+		{"DIGEST_INTERVAL", cfg.Digest.Interval},
+	}
+
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", interval.name, interval.value)
+		}
+	}
+
+	return nil
 }
 
 // GetDSN возвращает строку подключения к базе данных
@@ -139,6 +351,14 @@ func getEnvAsInt64(key string, defaultVal int64) int64 {
 	return defaultVal
 }
 
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	return defaultVal
+}
+
 func getEnvAsBool(key string, defaultVal bool) bool {
 	valueStr := getEnv(key, "")
 	if value, err := strconv.ParseBool(valueStr); err == nil {
@@ -147,10 +367,21 @@ func getEnvAsBool(key string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// getEnvAsSlice читает список значений через запятую, пустая строка означает пустой список
+func getEnvAsSlice(key, defaultVal string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultVal), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
 	valueStr := getEnv(key, "")
 	if value, err := time.ParseDuration(valueStr); err == nil {
 		return value
 	}
 	return defaultVal
-} 
\ No newline at end of file
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Журнал действий модераторов
+// @Tags moderation
+// @Description Решения модераторов по постам, комментариям, категориям и жалобам, новые сверху. Для каждого действия сохраняются причина и состояние объекта до и после. Автоматическое скрытие по жалобам записывается без модератора (только для администраторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param moderator_id query int false "ID модератора"
+// @Param target_type query string false "post, comment или category"
+// @Param target_id query int false "ID объекта"
+// @Param action query string false "Тип действия, например post_status или category_update"
+// @Param from query string false "Начальная дата (YYYY-MM-DD)"
+// @Param to query string false "Конечная дата включительно (YYYY-MM-DD)"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице (по умолчанию 20)"
+// @Success 200 {object} models.ModerationActionsResponse "Журнал действий"
+// @Failure 400,422 {object} models.StandardError "Некорректные параметры"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/audit [get]
+func (h *Handler) getAuditLog(c *gin.Context) {
+	var filter models.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	actions, err := h.services.Audit.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, actions)
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Блокировка пользователя
+// @Tags blocks
+// @Description Блокировка пользователя. Заблокированный не может комментировать посты, упоминать, писать сообщения и подписываться, а посты заблокировавшего для него скрыты. Подписки между пользователями удаляются в обе стороны
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешной блокировке"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя или блокировка себя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 409 {object} models.StandardError "Пользователь уже заблокирован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/block [post]
+func (h *Handler) blockUser(c *gin.Context) {
+	userId, id, ok := restrictionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Block.Block(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Пользователь заблокирован"})
+}
+
+// @Summary Разблокировка пользователя
+// @Tags blocks
+// @Description Снятие блокировки пользователя. Удаленные при блокировке подписки не восстанавливаются
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешной разблокировке"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Блокировка не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/block [delete]
+func (h *Handler) unblockUser(c *gin.Context) {
+	userId, id, ok := restrictionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Block.Unblock(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Пользователь разблокирован"})
+}
+
+// @Summary Заглушение пользователя
+// @Tags blocks
+// @Description Заглушение пользователя: его посты скрываются из общей ленты и ленты подписок, комментарии — из обсуждений, уведомления о его действиях не приходят. Сам пользователь об этом не узнает
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешном заглушении"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя или заглушение себя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 409 {object} models.StandardError "Пользователь уже заглушен"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/mute [post]
+func (h *Handler) muteUser(c *gin.Context) {
+	userId, id, ok := restrictionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Block.Mute(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Пользователь заглушен"})
+}
+
+// @Summary Снятие заглушения пользователя
+// @Tags blocks
+// @Description Снятие заглушения: посты и комментарии пользователя снова видны
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном снятии заглушения"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Заглушение не найдено"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/mute [delete]
+func (h *Handler) unmuteUser(c *gin.Context) {
+	userId, id, ok := restrictionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Block.Unmute(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Заглушение снято"})
+}
+
+// @Summary Заблокированные пользователи
+// @Tags blocks
+// @Description Получение списка пользователей, заблокированных текущим пользователем, новые сверху
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.RestrictedUsersResponse "Список заблокированных"
+// @Failure 400 {object} models.StandardError "Некорректные параметры"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/blocked [get]
+func (h *Handler) getBlockedUsers(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.RestrictionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	users, err := h.services.Block.GetBlocked(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// @Summary Заглушенные пользователи
+// @Tags blocks
+// @Description Получение списка пользователей, заглушенных текущим пользователем, новые сверху
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.RestrictedUsersResponse "Список заглушенных"
+// @Failure 400 {object} models.StandardError "Некорректные параметры"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/muted [get]
+func (h *Handler) getMutedUsers(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.RestrictionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	users, err := h.services.Block.GetMuted(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// restrictionRequest получает текущего пользователя и ID пользователя из пути
+func restrictionRequest(c *gin.Context) (int, int, bool) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return 0, 0, false
+	}
+
+	return userId, id, true
+}
@@ -17,7 +17,10 @@ import (
 // @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
 // @Router /api/v1/categories [get]
 func (h *Handler) getAllCategories(c *gin.Context) {
-	categories, err := h.services.Category.GetAll(c.Request.Context())
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	categories, err := h.services.Category.GetAll(c.Request.Context(), currentUserId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -44,7 +47,10 @@ func (h *Handler) getCategoryById(c *gin.Context) {
 		return
 	}
 
-	category, err := h.services.Category.GetByID(c.Request.Context(), id)
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	category, err := h.services.Category.GetByID(c.Request.Context(), id, currentUserId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -74,6 +80,12 @@ func (h *Handler) createCategory(c *gin.Context) {
 		return
 	}
 
+	moderatorId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
 	var input models.CategoryCreate
 	if err := c.ShouldBindJSON(&input); err != nil {
 		handleValidationError(c, err)
@@ -81,14 +93,14 @@ func (h *Handler) createCategory(c *gin.Context) {
 	}
 
 	// Создаем категорию
-	categoryId, err := h.services.Category.Create(c.Request.Context(), input)
+	categoryId, err := h.services.Category.Create(c.Request.Context(), moderatorId, input)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 
 	// Получаем созданную категорию
-	category, err := h.services.Category.GetByID(c.Request.Context(), categoryId)
+	category, err := h.services.Category.GetByID(c.Request.Context(), categoryId, 0)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -120,6 +132,12 @@ func (h *Handler) updateCategory(c *gin.Context) {
 		return
 	}
 
+	moderatorId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID категории"})
@@ -133,13 +151,13 @@ func (h *Handler) updateCategory(c *gin.Context) {
 	}
 
 	// Обновляем категорию
-	if err := h.services.Category.Update(c.Request.Context(), id, input); err != nil {
+	if err := h.services.Category.Update(c.Request.Context(), id, moderatorId, input); err != nil {
 		handleError(c, err)
 		return
 	}
 
 	// Получаем обновленную категорию
-	category, err := h.services.Category.GetByID(c.Request.Context(), id)
+	category, err := h.services.Category.GetByID(c.Request.Context(), id, 0)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -170,6 +188,12 @@ func (h *Handler) deleteCategory(c *gin.Context) {
 		return
 	}
 
+	moderatorId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID категории"})
@@ -177,7 +201,7 @@ func (h *Handler) deleteCategory(c *gin.Context) {
 	}
 
 	// Удаляем категорию
-	if err := h.services.Category.Delete(c.Request.Context(), id); err != nil {
+	if err := h.services.Category.Delete(c.Request.Context(), id, moderatorId); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Соавторы поста
+// @Tags coauthors
+// @Description Получение всех приглашенных соавторов поста со статусами приглашений (для автора, соавторов и модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {array} models.CoauthorResponse "Список соавторов"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors [get]
+func (h *Handler) getPostCoauthors(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	coauthors, err := h.services.Coauthor.GetByPostID(c.Request.Context(), postId, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, coauthors)
+}
+
+// @Summary Приглашение соавтора
+// @Tags coauthors
+// @Description Приглашение пользователя в соавторы поста с ролью: подписью и правом редактирования can_edit. Подпись — произвольный текст, права из нее не выводятся (только автор поста)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param input body models.CoauthorInvite true "Приглашаемый пользователь и его роль"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешном приглашении"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост или пользователь не найдены"
+// @Failure 409 {object} models.StandardError "Пользователь уже приглашен"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors [post]
+func (h *Handler) inviteCoauthor(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	var input models.CoauthorInvite
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Coauthor.Invite(c.Request.Context(), postId, userId, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Приглашение отправлено"})
+}
+
+// @Summary Изменение роли соавтора
+// @Tags coauthors
+// @Description Изменение подписи роли и права редактирования соавтора (только автор поста)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param userId path int true "ID соавтора"
+// @Param input body models.CoauthorUpdate true "Роль и права соавтора"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном изменении"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост или соавтор не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors/{userId} [put]
+func (h *Handler) updateCoauthor(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	coauthorId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	var input models.CoauthorUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Coauthor.Update(c.Request.Context(), postId, userId, coauthorId, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Роль соавтора обновлена"})
+}
+
+// @Summary Удаление соавтора
+// @Tags coauthors
+// @Description Удаление соавтора или отзыв приглашения. Автор может убрать любого соавтора, соавтор — только себя
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param userId path int true "ID соавтора"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении"
+// @Failure 400 {object} models.StandardError "Некорректный ID"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост или соавтор не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors/{userId} [delete]
+func (h *Handler) removeCoauthor(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	coauthorId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	if err := h.services.Coauthor.Remove(c.Request.Context(), postId, userId, coauthorId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Соавтор удален"})
+}
+
+// @Summary Принятие приглашения в соавторы
+// @Tags coauthors
+// @Description Принятие приглашения текущего пользователя в соавторы поста
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном принятии"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Приглашение не найдено"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors/accept [post]
+func (h *Handler) acceptCoauthorInvitation(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Coauthor.Accept(c.Request.Context(), postId, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Приглашение принято"})
+}
+
+// @Summary Отклонение приглашения в соавторы
+// @Tags coauthors
+// @Description Отклонение приглашения текущего пользователя в соавторы поста
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном отклонении"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Приглашение не найдено"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/coauthors/decline [post]
+func (h *Handler) declineCoauthorInvitation(c *gin.Context) {
+	userId, postId, ok := postRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Coauthor.Decline(c.Request.Context(), postId, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Приглашение отклонено"})
+}
+
+// @Summary Приглашения в соавторы
+// @Tags coauthors
+// @Description Получение ожидающих ответа приглашений текущего пользователя в соавторы
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.CoauthorInvitationResponse "Список приглашений"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/coauthor-invitations [get]
+func (h *Handler) getCoauthorInvitations(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	invitations, err := h.services.Coauthor.GetInvitations(c.Request.Context(), userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// postRequest получает текущего пользователя и ID поста из запроса
+func postRequest(c *gin.Context) (int, int, bool) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return 0, 0, false
+	}
+
+	return userId, id, true
+}
@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Получение коллекции
+// @Tags collections
+// @Description Получение коллекции и страницы ее постов в порядке, заданном владельцем. Приватная коллекция доступна только владельцу
+// @Accept json
+// @Produce json
+// @Param id path int true "ID коллекции"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице"
+// @Success 200 {object} models.CollectionPageResponse "Коллекция с постами"
+// @Failure 400 {object} models.StandardError "Некорректный ID коллекции"
+// @Failure 404 {object} models.StandardError "Коллекция не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/collections/{id} [get]
+func (h *Handler) getCollectionById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID коллекции"})
+		return
+	}
+
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	// Устанавливаем значения по умолчанию, если не указаны
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = 12
+	}
+
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	collection, err := h.services.Collection.GetByID(c.Request.Context(), id, currentUserId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// @Summary Коллекции с постом
+// @Tags collections
+// @Description Получение публичных коллекций, в которые добавлен пост, и приватных коллекций текущего пользователя
+// @Accept json
+// @Produce json
+// @Param id path int true "ID поста"
+// @Success 200 {array} models.CollectionResponse "Список коллекций"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/posts/{id}/collections [get]
+func (h *Handler) getPostCollections(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	currentUserId, _ := getUserId(c)
+
+	collections, err := h.services.Collection.GetByPostID(c.Request.Context(), id, currentUserId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Коллекции пользователя
+// @Tags collections
+// @Description Получение публичных коллекций пользователя (владельцу видны и приватные)
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Success 200 {array} models.CollectionResponse "Список коллекций"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/users/{id}/collections [get]
+func (h *Handler) getUserCollections(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	currentUserId, _ := getUserId(c)
+
+	collections, err := h.services.Collection.GetByUserID(c.Request.Context(), id, currentUserId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Мои коллекции
+// @Tags collections
+// @Description Получение всех коллекций текущего пользователя, включая приватные
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.CollectionResponse "Список коллекций"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/collections [get]
+func (h *Handler) getMyCollections(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	collections, err := h.services.Collection.GetByUserID(c.Request.Context(), userId, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// @Summary Создание коллекции
+// @Tags collections
+// @Description Создание новой коллекции текущего пользователя
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body models.CollectionCreate true "Данные коллекции"
+// @Success 201 {object} models.CollectionPageResponse "Созданная коллекция"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections [post]
+func (h *Handler) createCollection(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var input models.CollectionCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	id, err := h.services.Collection.Create(c.Request.Context(), userId, input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	h.respondWithCollection(c, http.StatusCreated, id, userId)
+}
+
+// @Summary Обновление коллекции
+// @Tags collections
+// @Description Обновление названия, описания и видимости коллекции
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Param input body models.CollectionUpdate true "Данные для обновления коллекции"
+// @Success 200 {object} models.CollectionPageResponse "Обновленная коллекция"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id} [put]
+func (h *Handler) updateCollection(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	var input models.CollectionUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Collection.Update(c.Request.Context(), id, userId, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	h.respondWithCollection(c, http.StatusOK, id, userId)
+}
+
+// @Summary Обновление обложки коллекции
+// @Tags collections
+// @Description Загрузка обложки коллекции. Без обложки используется первая работа коллекции
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Param cover formData file true "Файл обложки"
+// @Success 200 {object} models.CollectionPageResponse "Обновленная коллекция"
+// @Failure 400 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id}/cover [put]
+func (h *Handler) updateCollectionCover(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("cover")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Ошибка загрузки файла"})
+		return
+	}
+	defer file.Close()
+
+	// Проверка типа файла
+	contentType := header.Header.Get("Content-Type")
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Недопустимый формат файла. Допустимые форматы: JPEG, PNG, GIF"})
+		return
+	}
+
+	// Проверка размера файла (максимум 5 МБ)
+	if header.Size > 5*1024*1024 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Размер файла превышает лимит в 5 МБ"})
+		return
+	}
+
+	if err := h.services.Collection.UpdateCover(c.Request.Context(), id, userId, header); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	h.respondWithCollection(c, http.StatusOK, id, userId)
+}
+
+// @Summary Удаление коллекции
+// @Tags collections
+// @Description Удаление коллекции. Сами посты не удаляются
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении"
+// @Failure 400 {object} models.StandardError "Некорректный ID коллекции"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id} [delete]
+func (h *Handler) deleteCollection(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Collection.Delete(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Коллекция успешно удалена"})
+}
+
+// @Summary Добавление поста в коллекцию
+// @Tags collections
+// @Description Добавление опубликованного поста в конец коллекции
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Param input body models.CollectionItemAdd true "ID поста"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешном добавлении"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция или пост не найдены"
+// @Failure 409 {object} models.StandardError "Пост уже есть в коллекции"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id}/posts [post]
+func (h *Handler) addCollectionPost(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	var input models.CollectionItemAdd
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Collection.AddPost(c.Request.Context(), id, userId, input.PostID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Пост добавлен в коллекцию"})
+}
+
+// @Summary Удаление поста из коллекции
+// @Tags collections
+// @Description Удаление поста из коллекции
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Param postId path int true "ID поста"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении"
+// @Failure 400 {object} models.StandardError "Некорректный ID"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция или пост не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id}/posts/{postId} [delete]
+func (h *Handler) removeCollectionPost(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	postId, err := strconv.Atoi(c.Param("postId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	if err := h.services.Collection.RemovePost(c.Request.Context(), id, userId, postId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Пост удален из коллекции"})
+}
+
+// @Summary Изменение порядка постов в коллекции
+// @Tags collections
+// @Description Задает новый порядок постов. Список должен содержать каждый пост коллекции ровно один раз
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID коллекции"
+// @Param input body models.CollectionReorder true "ID постов в новом порядке"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном изменении порядка"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Коллекция не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/collections/{id}/order [put]
+func (h *Handler) reorderCollection(c *gin.Context) {
+	userId, id, ok := collectionRequest(c)
+	if !ok {
+		return
+	}
+
+	var input models.CollectionReorder
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Collection.Reorder(c.Request.Context(), id, userId, input.PostIDs); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Порядок постов обновлен"})
+}
+
+// collectionRequest получает текущего пользователя и ID коллекции из запроса
+func collectionRequest(c *gin.Context) (int, int, bool) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID коллекции"})
+		return 0, 0, false
+	}
+
+	return userId, id, true
+}
+
+// respondWithCollection отвечает коллекцией с первой страницей ее постов
+func (h *Handler) respondWithCollection(c *gin.Context, status int, id int, userId int) {
+	collection, err := h.services.Collection.GetByID(c.Request.Context(), id, userId, models.PostFilter{Page: 1, PerPage: 12})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(status, collection)
+}
@@ -10,11 +10,15 @@ import (
 
 // @Summary Получение комментариев к посту
 // @Tags comments
-// @Description Получение списка комментариев к указанному посту
+// @Description Получение страницы комментариев верхнего уровня к указанному посту, включая аннотации к областям медиа. Ответы загружаются отдельно
 // @Accept json
 // @Produce json
 // @Param id path int true "ID поста"
-// @Success 200 {array} models.CommentResponse "Список комментариев"
+// @Param resolved query bool false "Только учтенные (true) или только открытые (false) замечания"
+// @Param annotated query bool false "Только привязанные к области медиа (true) или только обычные (false)"
+// @Param per_page query int false "Количество комментариев на странице (по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Success 200 {object} models.CommentPage "Страница комментариев"
 // @Failure 400 {object} models.StandardError "Некорректный ID поста"
 // @Failure 404 {object} models.StandardError "Пост не найден"
 // @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
@@ -26,7 +30,12 @@ func (h *Handler) getPostComments(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.services.Comment.GetByPostID(c.Request.Context(), id)
+	filter, ok := bindCommentFilter(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.services.Comment.GetByPostID(c.Request.Context(), id, filter)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -35,9 +44,45 @@ func (h *Handler) getPostComments(c *gin.Context) {
 	c.JSON(http.StatusOK, comments)
 }
 
+// @Summary Получение ответов на комментарий
+// @Tags comments
+// @Description Получение страницы прямых ответов на комментарий
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Param resolved query bool false "Только учтенные (true) или только открытые (false) замечания"
+// @Param annotated query bool false "Только привязанные к области медиа (true) или только обычные (false)"
+// @Param per_page query int false "Количество ответов на странице (по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Success 200 {object} models.CommentPage "Страница ответов"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/comments/{id}/replies [get]
+func (h *Handler) getCommentReplies(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID комментария"})
+		return
+	}
+
+	filter, ok := bindCommentFilter(c)
+	if !ok {
+		return
+	}
+
+	replies, err := h.services.Comment.GetReplies(c.Request.Context(), id, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, replies)
+}
+
 // @Summary Создание комментария
 // @Tags comments
-// @Description Добавление нового комментария к посту
+// @Description Добавление нового комментария к посту или ответа на комментарий через parent_id. Комментарий можно привязать к точке или области медиа через annotation
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -140,7 +185,7 @@ func (h *Handler) updateComment(c *gin.Context) {
 
 // @Summary Удаление комментария
 // @Tags comments
-// @Description Удаление комментария
+// @Description Перемещение комментария в корзину, откуда его можно восстановить в течение срока хранения
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -173,3 +218,85 @@ func (h *Handler) deleteComment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Комментарий успешно удален"})
 }
+
+// @Summary Отметка замечания учтенным
+// @Tags comments
+// @Description Отметка комментария учтенным (для автора и соавторов поста, автора комментария и модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Success 200 {object} models.CommentResponse "Обновленный комментарий"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/resolve [post]
+func (h *Handler) resolveComment(c *gin.Context) {
+	h.setCommentResolved(c, true)
+}
+
+// @Summary Повторное открытие замечания
+// @Tags comments
+// @Description Снятие отметки об учтенном замечании (для автора и соавторов поста, автора комментария и модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Success 200 {object} models.CommentResponse "Обновленный комментарий"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/resolve [delete]
+func (h *Handler) unresolveComment(c *gin.Context) {
+	h.setCommentResolved(c, false)
+}
+
+// setCommentResolved меняет отметку об учтенном замечании и возвращает обновленный комментарий
+func (h *Handler) setCommentResolved(c *gin.Context, resolved bool) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID комментария"})
+		return
+	}
+
+	if err := h.services.Comment.SetResolved(c.Request.Context(), commentId, userId, resolved); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	comment, err := h.services.Comment.GetByID(c.Request.Context(), commentId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// bindCommentFilter разбирает параметры фильтрации и пагинации комментариев
+func bindCommentFilter(c *gin.Context) (models.CommentFilter, bool) {
+	var filter models.CommentFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return models.CommentFilter{}, false
+	}
+
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	filter.ViewerID, _ = getUserId(c)
+
+	return filter, true
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Подписка на пользователя
+// @Tags follows
+// @Description Подписка текущего пользователя на автора. Посты автора попадают в персональную ленту
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешной подписке"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя или подписка на себя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 409 {object} models.StandardError "Подписка уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/follow [post]
+func (h *Handler) followUser(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	if err := h.services.Follow.FollowUser(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Подписка оформлена"})
+}
+
+// @Summary Отписка от пользователя
+// @Tags follows
+// @Description Отмена подписки текущего пользователя на автора
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешной отписке"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Подписка не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/follow [delete]
+func (h *Handler) unfollowUser(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	if err := h.services.Follow.UnfollowUser(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Подписка отменена"})
+}
+
+// @Summary Подписка на категорию
+// @Tags follows
+// @Description Подписка текущего пользователя на категорию. Посты категории попадают в персональную ленту
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID категории"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешной подписке"
+// @Failure 400 {object} models.StandardError "Некорректный ID категории"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Категория не найдена"
+// @Failure 409 {object} models.StandardError "Подписка уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/categories/{id}/follow [post]
+func (h *Handler) followCategory(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID категории"})
+		return
+	}
+
+	if err := h.services.Follow.FollowCategory(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Подписка оформлена"})
+}
+
+// @Summary Отписка от категории
+// @Tags follows
+// @Description Отмена подписки текущего пользователя на категорию
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID категории"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешной отписке"
+// @Failure 400 {object} models.StandardError "Некорректный ID категории"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Подписка не найдена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/categories/{id}/follow [delete]
+func (h *Handler) unfollowCategory(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID категории"})
+		return
+	}
+
+	if err := h.services.Follow.UnfollowCategory(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Подписка отменена"})
+}
+
+// @Summary Подписчики пользователя
+// @Tags follows
+// @Description Получение списка подписчиков пользователя, новые сверху. is_following показывает, подписан ли на них текущий пользователь
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.FollowUsersResponse "Список подписчиков"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/users/{id}/followers [get]
+func (h *Handler) getUserFollowers(c *gin.Context) {
+	id, filter, ok := followListRequest(c)
+	if !ok {
+		return
+	}
+
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	followers, err := h.services.Follow.GetFollowers(c.Request.Context(), id, currentUserId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, followers)
+}
+
+// @Summary Подписки пользователя
+// @Tags follows
+// @Description Получение списка авторов, на которых подписан пользователь, новые сверху
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.FollowUsersResponse "Список подписок"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/users/{id}/following [get]
+func (h *Handler) getUserFollowing(c *gin.Context) {
+	id, filter, ok := followListRequest(c)
+	if !ok {
+		return
+	}
+
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	following, err := h.services.Follow.GetFollowing(c.Request.Context(), id, currentUserId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, following)
+}
+
+// @Summary Категории, на которые подписан пользователь
+// @Tags follows
+// @Description Получение списка категорий, на которые подписан пользователь, новые сверху
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество категорий на странице (по умолчанию 20)"
+// @Success 200 {object} models.FollowedCategoriesResponse "Список категорий"
+// @Failure 400 {object} models.StandardError "Некорректный ID пользователя"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/users/{id}/following/categories [get]
+func (h *Handler) getUserFollowedCategories(c *gin.Context) {
+	id, filter, ok := followListRequest(c)
+	if !ok {
+		return
+	}
+
+	categories, err := h.services.Follow.GetFollowedCategories(c.Request.Context(), id, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// followListRequest разбирает ID пользователя и параметры пагинации списка подписок
+func followListRequest(c *gin.Context) (int, models.FollowFilter, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return 0, models.FollowFilter{}, false
+	}
+
+	var filter models.FollowFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return 0, models.FollowFilter{}, false
+	}
+
+	return id, filter, true
+}
@@ -3,9 +3,11 @@ package handler
 import (
 	"designhub/internal/config"
 	"designhub/internal/service"
+	"sync"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -15,6 +17,8 @@ type Handler struct {
 	services    *service.Service
 	fileStorage service.FileStorage
 	config      *config.Config
+	streamsDone chan struct{} // Закрывается при остановке сервера, чтобы завершить потоки событий
+	closeOnce   sync.Once
 }
 
 // NewHandler конструктор обработчика HTTP запросов
@@ -23,9 +27,18 @@ func NewHandler(services *service.Service, fileStorage service.FileStorage, conf
 		services:    services,
 		fileStorage: fileStorage,
 		config:      config,
+		streamsDone: make(chan struct{}),
 	}
 }
 
+// CloseStreams завершает открытые потоки событий. Вызывается при остановке сервера,
+// иначе Shutdown ждал бы их до истечения тайм-аута
+func (h *Handler) CloseStreams() {
+	h.closeOnce.Do(func() {
+		close(h.streamsDone)
+	})
+}
+
 // InitRoutes инициализирует маршруты HTTP запросов
 func (h *Handler) InitRoutes() *gin.Engine {
 	// Настройка Gin
@@ -35,6 +48,12 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	router.RedirectTrailingSlash = false
 	router.RedirectFixedPath = false
 
+	// X-Forwarded-For учитывается только от настроенных прокси, иначе клиент мог бы
+	// подставить любой адрес, например чтобы обойти дедупликацию просмотров
+	if err := router.SetTrustedProxies(h.config.Server.TrustedProxies); err != nil {
+		logrus.Fatalf("Invalid trusted proxies: %s", err.Error())
+	}
+
 	// Настройка CORS
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
@@ -68,26 +87,56 @@ func (h *Handler) InitRoutes() *gin.Engine {
 
 			// Публичные эндпоинты
 			// Категории (доступны всем)
-			categories := v1.Group("/categories")
+			categories := v1.Group("/categories", h.optionalUserIdentity)
 			{
 				categories.GET("", h.getAllCategories)
 				categories.GET("/", h.getAllCategories)
 				categories.GET("/:id", h.getCategoryById)
 			}
 
-			// Посты (публичный доступ)
-			public := v1.Group("/public")
+			// Каталог инструментов и список лицензий (доступны всем)
+			tools := v1.Group("/tools")
+			{
+				tools.GET("", h.getAllTools)
+				tools.GET("/", h.getAllTools)
+				tools.GET("/:id", h.getToolById)
+			}
+			v1.GET("/licenses", h.getLicenses)
+			v1.GET("/reactions", h.getReactionTypes)
+
+			// Отписка от email-дайджеста по ссылке из письма
+			v1.GET("/unsubscribe", h.confirmUnsubscribeDigest)
+			v1.POST("/unsubscribe", h.unsubscribeDigest)
+
+			// Поток событий реального времени (SSE)
+			v1.GET("/stream", h.streamIdentity, h.stream)
+
+			// Посты (публичный доступ, авторизация необязательна)
+			public := v1.Group("/public", h.optionalUserIdentity)
 			{
 				public.GET("/posts", h.getAllPosts)
 				public.GET("/posts/:id", h.getPostById)
 				public.GET("/posts/:id/comments", h.getPostComments)
+				public.GET("/comments/:id/replies", h.getCommentReplies)
+				public.GET("/posts/:id/reactions/:type", h.getPostReactionUsers)
+				public.GET("/comments/:id/reactions/:type", h.getCommentReactionUsers)
+				public.GET("/posts/:id/related", h.getRelatedPosts)
+				public.GET("/posts/:id/collections", h.getPostCollections)
 				public.GET("/users/:id", h.getUserById)
 				public.GET("/users/:id/posts", h.getUserPosts)
+				public.GET("/users/:id/followers", h.getUserFollowers)
+				public.GET("/users/:id/following", h.getUserFollowing)
+				public.GET("/users/:id/following/categories", h.getUserFollowedCategories)
+				public.GET("/users/:id/collections", h.getUserCollections)
+				public.GET("/collections/:id", h.getCollectionById)
 			}
 
 			// Защищенные эндпоинты (требуют авторизации)
 			protected := v1.Group("/", h.userIdentity)
 			{
+				// Персональная лента подписок
+				protected.GET("/feed", h.getFollowingFeed)
+
 				// Профиль пользователя
 				users := protected.Group("/users")
 				{
@@ -95,6 +144,66 @@ func (h *Handler) InitRoutes() *gin.Engine {
 					users.PUT("/me", h.updateUserProfile)
 					users.PUT("/me/avatar", h.updateUserAvatar)
 					users.GET("/me/likes", h.getUserLikedPosts)
+					users.GET("/me/dashboard", h.getUserDashboard)
+					users.GET("/me/drafts", h.getUserDrafts)
+					users.GET("/me/scheduled", h.getUserScheduledPosts)
+					users.GET("/me/trash", h.getUserTrash)
+					users.GET("/me/collections", h.getMyCollections)
+					users.GET("/me/coauthor-invitations", h.getCoauthorInvitations)
+					users.GET("/me/message-settings", h.getMessageSettings)
+					users.PUT("/me/message-settings", h.updateMessageSettings)
+					users.GET("/me/blocked", h.getBlockedUsers)
+					users.GET("/me/muted", h.getMutedUsers)
+					users.POST("/:id/follow", h.followUser)
+					users.DELETE("/:id/follow", h.unfollowUser)
+					users.POST("/:id/block", h.blockUser)
+					users.DELETE("/:id/block", h.unblockUser)
+					users.POST("/:id/mute", h.muteUser)
+					users.DELETE("/:id/mute", h.unmuteUser)
+					users.POST("/:id/messages", h.sendMessageToUser)
+				}
+
+				// Личные сообщения
+				conversations := protected.Group("/conversations")
+				{
+					conversations.GET("", h.getConversations)
+					conversations.GET("/", h.getConversations)
+					conversations.GET("/requests", h.getMessageRequests)
+					conversations.GET("/:id", h.getConversationById)
+					conversations.GET("/:id/messages", h.getConversationMessages)
+					conversations.POST("/:id/messages", h.sendConversationMessage)
+					conversations.POST("/:id/read", h.readConversation)
+					conversations.POST("/:id/accept", h.acceptMessageRequest)
+					conversations.POST("/:id/decline", h.declineMessageRequest)
+				}
+
+				// Центр уведомлений
+				notifications := protected.Group("/notifications")
+				{
+					notifications.GET("", h.getNotifications)
+					notifications.GET("/", h.getNotifications)
+					notifications.GET("/unread-count", h.getUnreadNotificationsCount)
+					notifications.POST("/read", h.readNotifications)
+					notifications.POST("/:id/read", h.readNotification)
+					notifications.GET("/preferences", h.getNotificationPreferences)
+					notifications.PUT("/preferences", h.updateNotificationPreferences)
+				}
+
+				// Подписки на категории
+				protected.POST("/categories/:id/follow", h.followCategory)
+				protected.DELETE("/categories/:id/follow", h.unfollowCategory)
+
+				// Коллекции
+				collections := protected.Group("/collections")
+				{
+					collections.POST("", h.createCollection)
+					collections.POST("/", h.createCollection)
+					collections.PUT("/:id", h.updateCollection)
+					collections.DELETE("/:id", h.deleteCollection)
+					collections.PUT("/:id/cover", h.updateCollectionCover)
+					collections.POST("/:id/posts", h.addCollectionPost)
+					collections.DELETE("/:id/posts/:postId", h.removeCollectionPost)
+					collections.PUT("/:id/order", h.reorderCollection)
 				}
 
 				// Посты
@@ -104,11 +213,30 @@ func (h *Handler) InitRoutes() *gin.Engine {
 					posts.POST("/", h.createPost)
 					posts.PUT("/:id", h.updatePost)
 					posts.DELETE("/:id", h.deletePost)
+					posts.POST("/:id/restore", h.restorePost)
+					posts.POST("/:id/submit", h.submitPost)
+					posts.GET("/:id/stats", h.getPostStats)
+					posts.GET("/:id/revisions", h.getPostRevisions)
+					posts.GET("/:id/coauthors", h.getPostCoauthors)
+					posts.POST("/:id/coauthors", h.inviteCoauthor)
+					posts.PUT("/:id/coauthors/:userId", h.updateCoauthor)
+					posts.DELETE("/:id/coauthors/:userId", h.removeCoauthor)
+					posts.POST("/:id/coauthors/accept", h.acceptCoauthorInvitation)
+					posts.POST("/:id/coauthors/decline", h.declineCoauthorInvitation)
 					posts.POST("/:id/like", h.likePost)
 					posts.DELETE("/:id/like", h.unlikePost)
+					posts.POST("/:id/reactions/:type", h.addPostReaction)
+					posts.DELETE("/:id/reactions/:type", h.removePostReaction)
+					posts.POST("/:id/report", h.reportPost)
 					posts.POST("/:id/comments", h.createComment)
 					posts.PUT("/comments/:id", h.updateComment)
 					posts.DELETE("/comments/:id", h.deleteComment)
+					posts.POST("/comments/:id/restore", h.restoreComment)
+					posts.POST("/comments/:id/resolve", h.resolveComment)
+					posts.DELETE("/comments/:id/resolve", h.unresolveComment)
+					posts.POST("/comments/:id/reactions/:type", h.addCommentReaction)
+					posts.DELETE("/comments/:id/reactions/:type", h.removeCommentReaction)
+					posts.POST("/comments/:id/report", h.reportComment)
 				}
 			}
 
@@ -117,11 +245,28 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			{
 				moderator.GET("/moderation", h.getPostsPendingModeration)
 				moderator.PUT("/moderation/:id", h.moderatePost)
+				moderator.GET("/trash", h.getTrash)
+
+				// Жалобы пользователей
+				moderator.GET("/reports", h.getReports)
+				moderator.GET("/reports/:target/:id", h.getTargetReports)
+				moderator.POST("/reports/:target/:id/resolve", h.resolveReports)
 
 				// Управление категориями
 				moderator.POST("/categories", h.createCategory)
 				moderator.PUT("/categories/:id", h.updateCategory)
 				moderator.DELETE("/categories/:id", h.deleteCategory)
+
+				// Управление каталогом инструментов
+				moderator.POST("/tools", h.createTool)
+				moderator.PUT("/tools/:id", h.updateTool)
+				moderator.DELETE("/tools/:id", h.deleteTool)
+			}
+
+			// Эндпоинты для администраторов
+			admin := v1.Group("/admin", h.userIdentity, h.adminRequired)
+			{
+				admin.GET("/audit", h.getAuditLog)
 			}
 		}
 	}
@@ -29,6 +29,75 @@ func handleError(c *gin.Context, err error) {
 	case strings.Contains(err.Error(), "forbidden") || strings.Contains(err.Error(), "доступ запрещен"):
 		statusCode = http.StatusForbidden
 		message = "Доступ запрещен"
+	case strings.Contains(err.Error(), "недопустимый статус поста"):
+		statusCode = http.StatusConflict
+		message = "Действие недоступно для поста в текущем статусе"
+	case strings.Contains(err.Error(), "срок восстановления истек"):
+		statusCode = http.StatusGone
+		message = "Срок восстановления истек"
+	case strings.Contains(err.Error(), "некорректный порядок"):
+		statusCode = http.StatusBadRequest
+		message = "Список должен содержать каждый пост коллекции ровно один раз"
+	case strings.Contains(err.Error(), "некорректный соавтор"):
+		statusCode = http.StatusBadRequest
+		message = "Автор поста не может быть своим соавтором"
+	case strings.Contains(err.Error(), "неизвестная лицензия"):
+		statusCode = http.StatusBadRequest
+		message = "Неизвестная лицензия"
+	case strings.Contains(err.Error(), "некорректная ссылка"):
+		statusCode = http.StatusBadRequest
+		message = "Ссылка должна быть абсолютным http или https адресом"
+	case strings.Contains(err.Error(), "неизвестный инструмент"):
+		statusCode = http.StatusBadRequest
+		message = "Инструмент не найден в каталоге"
+	case strings.Contains(err.Error(), "используется в постах"):
+		statusCode = http.StatusConflict
+		message = err.Error()
+	case strings.Contains(err.Error(), "некорректный родительский комментарий"):
+		statusCode = http.StatusBadRequest
+		message = "Можно ответить только на комментарий к этому же посту"
+	case strings.Contains(err.Error(), "некорректная аннотация"):
+		statusCode = http.StatusBadRequest
+		message = "Область аннотации должна лежать внутри медиа поста"
+	case strings.Contains(err.Error(), "некорректный курсор"):
+		statusCode = http.StatusBadRequest
+		message = "Некорректный курсор пагинации"
+	case strings.Contains(err.Error(), "неизвестная реакция"):
+		statusCode = http.StatusBadRequest
+		message = "Неизвестный тип реакции"
+	case strings.Contains(err.Error(), "слишком много постов в потоке"):
+		statusCode = http.StatusBadRequest
+		message = "Слишком много постов для отслеживания в одном потоке"
+	case strings.Contains(err.Error(), "нельзя подписаться на себя"):
+		statusCode = http.StatusBadRequest
+		message = "Нельзя подписаться на себя"
+	case strings.Contains(err.Error(), "неизвестный тип уведомлений"):
+		statusCode = http.StatusBadRequest
+		message = "Неизвестный тип уведомлений"
+	case strings.Contains(err.Error(), "недействительная ссылка отписки"):
+		statusCode = http.StatusBadRequest
+		message = "Ссылка отписки недействительна"
+	case strings.Contains(err.Error(), "нельзя написать себе"):
+		statusCode = http.StatusBadRequest
+		message = "Нельзя написать самому себе"
+	case strings.Contains(err.Error(), "пустое сообщение"):
+		statusCode = http.StatusBadRequest
+		message = "Сообщение не может быть пустым"
+	case strings.Contains(err.Error(), "пользователь ограничил личные сообщения"):
+		statusCode = http.StatusForbidden
+		message = "Пользователь не принимает от вас сообщения"
+	case strings.Contains(err.Error(), "диалог не является запросом"):
+		statusCode = http.StatusConflict
+		message = "Диалог не является запросом на переписку"
+	case strings.Contains(err.Error(), "нельзя заблокировать или заглушить себя"):
+		statusCode = http.StatusBadRequest
+		message = "Нельзя заблокировать или заглушить самого себя"
+	case strings.Contains(err.Error(), "пользователь заблокирован"):
+		statusCode = http.StatusForbidden
+		message = "Действие недоступно из-за блокировки"
+	case strings.Contains(err.Error(), "нельзя пожаловаться на свой контент"):
+		statusCode = http.StatusBadRequest
+		message = "Нельзя пожаловаться на собственный пост или комментарий"
 	case strings.Contains(err.Error(), "неверный пароль"):
 		statusCode = http.StatusUnauthorized
 		message = "Неверный email или пароль"
@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Сообщение пользователю
+// @Tags messages
+// @Description Отправка личного сообщения пользователю. Если диалога еще нет, он создается: у получателя диалог попадает во входящие, если получатель подписан на отправителя, иначе — в запросы на переписку
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID получателя"
+// @Param input body models.MessageCreate true "Текст сообщения"
+// @Success 201 {object} models.MessageResponse "Отправленное сообщение"
+// @Failure 400,422 {object} models.StandardError "Некорректные данные или сообщение себе"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Пользователь не принимает сообщения от отправителя"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/{id}/messages [post]
+func (h *Handler) sendMessageToUser(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID пользователя"})
+		return
+	}
+
+	var input models.MessageCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	message, err := h.services.Message.SendToUser(c.Request.Context(), userId, id, input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// @Summary Входящие диалоги
+// @Tags messages
+// @Description Получение диалогов текущего пользователя, диалоги с новыми сообщениями сверху
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество диалогов на странице (по умолчанию 20)"
+// @Success 200 {object} models.ConversationsResponse "Список диалогов"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации параметров"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations [get]
+func (h *Handler) getConversations(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.ConversationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	conversations, err := h.services.Message.GetConversations(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversations)
+}
+
+// @Summary Запросы на переписку
+// @Tags messages
+// @Description Получение диалогов, начатых пользователями, на которых текущий пользователь не подписан. Запрос можно принять, отклонить или просто ответить на него
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество диалогов на странице (по умолчанию 20)"
+// @Success 200 {object} models.ConversationsResponse "Список запросов"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации параметров"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/requests [get]
+func (h *Handler) getMessageRequests(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.ConversationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	conversations, err := h.services.Message.GetRequests(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversations)
+}
+
+// @Summary Диалог
+// @Tags messages
+// @Description Получение диалога, в котором участвует текущий пользователь
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Success 200 {object} models.ConversationResponse "Диалог"
+// @Failure 400 {object} models.StandardError "Некорректный ID диалога"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id} [get]
+func (h *Handler) getConversationById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	conversation, err := h.services.Message.GetConversation(c.Request.Context(), id, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// @Summary Сообщения диалога
+// @Tags messages
+// @Description Получение сообщений диалога, новые сверху, с курсорной пагинацией. Параметр q ищет сообщения по тексту
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Param q query string false "Поиск по тексту сообщений"
+// @Param per_page query int false "Количество сообщений на странице (по умолчанию 50)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Success 200 {object} models.MessagePage "Страница сообщений"
+// @Failure 400,422 {object} models.StandardError "Некорректный ID диалога, курсор или параметры"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id}/messages [get]
+func (h *Handler) getConversationMessages(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	var filter models.MessageFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	messages, err := h.services.Message.GetMessages(c.Request.Context(), id, userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// @Summary Сообщение в диалог
+// @Tags messages
+// @Description Отправка сообщения в существующий диалог. Ответ на запрос на переписку принимает его
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Param input body models.MessageCreate true "Текст сообщения"
+// @Success 201 {object} models.MessageResponse "Отправленное сообщение"
+// @Failure 400,422 {object} models.StandardError "Некорректные данные"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Собеседник не принимает сообщения"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id}/messages [post]
+func (h *Handler) sendConversationMessage(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	var input models.MessageCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	message, err := h.services.Message.SendToConversation(c.Request.Context(), userId, id, input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// @Summary Отметка диалога прочитанным
+// @Tags messages
+// @Description Отметка всех сообщений диалога прочитанными. Собеседник получает событие messages_read в потоке реального времени
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешной отметке"
+// @Failure 400 {object} models.StandardError "Некорректный ID диалога"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id}/read [post]
+func (h *Handler) readConversation(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	if err := h.services.Message.MarkRead(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Диалог отмечен прочитанным"})
+}
+
+// @Summary Принятие запроса на переписку
+// @Tags messages
+// @Description Перенос запроса на переписку во входящие
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном принятии"
+// @Failure 400 {object} models.StandardError "Некорректный ID диалога"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 409 {object} models.StandardError "Диалог не является запросом"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id}/accept [post]
+func (h *Handler) acceptMessageRequest(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	if err := h.services.Message.Accept(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Запрос принят"})
+}
+
+// @Summary Отклонение запроса на переписку
+// @Tags messages
+// @Description Отклонение запроса на переписку: диалог скрывается, и собеседник больше не может в него писать
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID диалога"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном отклонении"
+// @Failure 400 {object} models.StandardError "Некорректный ID диалога"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Диалог не найден"
+// @Failure 409 {object} models.StandardError "Диалог не является запросом"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/conversations/{id}/decline [post]
+func (h *Handler) declineMessageRequest(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID диалога"})
+		return
+	}
+
+	if err := h.services.Message.Decline(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Запрос отклонен"})
+}
+
+// @Summary Настройки личных сообщений
+// @Tags messages
+// @Description Получение настройки, кто может писать текущему пользователю: everyone (все, сообщения незнакомых попадают в запросы), following (только те, на кого пользователь подписан) или nobody
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.MessageSettings "Настройки личных сообщений"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/message-settings [get]
+func (h *Handler) getMessageSettings(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	settings, err := h.services.Message.GetSettings(c.Request.Context(), userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// @Summary Изменение настроек личных сообщений
+// @Tags messages
+// @Description Изменение настройки, кто может писать текущему пользователю. Уже принятые диалоги продолжаются
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body models.MessageSettings true "Настройки личных сообщений"
+// @Success 200 {object} models.MessageSettings "Настройки после изменения"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/message-settings [put]
+func (h *Handler) updateMessageSettings(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var input models.MessageSettings
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Message.UpdateSettings(c.Request.Context(), userId, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
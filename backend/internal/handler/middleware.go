@@ -42,6 +42,34 @@ func (h *Handler) userIdentity(c *gin.Context) {
 	c.Next()
 }
 
+// streamIdentity middleware для потока событий: браузерный EventSource не передает
+// заголовки, поэтому токен также принимается из параметра token
+func (h *Handler) streamIdentity(c *gin.Context) {
+	if token := c.Query("token"); token != "" && c.GetHeader(authorizationHeader) == "" {
+		c.Request.Header.Set(authorizationHeader, "Bearer "+token)
+	}
+
+	h.userIdentity(c)
+}
+
+// optionalUserIdentity middleware для публичных маршрутов: если передан валидный JWT токен,
+// идентифицирует пользователя, иначе пропускает запрос как гостевой
+func (h *Handler) optionalUserIdentity(c *gin.Context) {
+	headerParts := strings.Split(c.GetHeader(authorizationHeader), " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		c.Next()
+		return
+	}
+
+	userId, userRole, err := h.services.Authorization.ParseToken(headerParts[1])
+	if err == nil {
+		c.Set(userCtx, userId)
+		c.Set(userRoleCtx, userRole)
+	}
+
+	c.Next()
+}
+
 // moderatorRequired middleware для проверки роли модератора
 func (h *Handler) moderatorRequired(c *gin.Context) {
 	userRole, exists := c.Get(userRoleCtx)
@@ -60,6 +88,24 @@ func (h *Handler) moderatorRequired(c *gin.Context) {
 	c.Next()
 }
 
+// adminRequired middleware для проверки роли администратора
+func (h *Handler) adminRequired(c *gin.Context) {
+	userRole, exists := c.Get(userRoleCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		c.Abort()
+		return
+	}
+
+	if userRole != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Доступ запрещен. Требуются права администратора."})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
 // getUserId получает ID пользователя из контекста
 func getUserId(c *gin.Context) (int, error) {
 	idFromContext, exists := c.Get(userCtx)
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Уведомления
+// @Tags notifications
+// @Description Получение уведомлений текущего пользователя, новые сверху: решения модерации, лайки, комментарии, ответы, упоминания и новые подписчики. Однотипные события объединяются в одно уведомление с actors_count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param unread query bool false "Только непрочитанные"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество уведомлений на странице (по умолчанию 20)"
+// @Success 200 {object} models.NotificationsResponse "Список уведомлений"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации параметров"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications [get]
+func (h *Handler) getNotifications(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.NotificationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	notifications, err := h.services.Notification.GetByUserID(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Количество непрочитанных уведомлений
+// @Tags notifications
+// @Description Получение счетчика непрочитанных уведомлений текущего пользователя
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.UnreadCountResponse "Количество непрочитанных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications/unread-count [get]
+func (h *Handler) getUnreadNotificationsCount(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	count, err := h.services.Notification.CountUnread(c.Request.Context(), userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UnreadCountResponse{UnreadCount: count})
+}
+
+// @Summary Отметка уведомления прочитанным
+// @Tags notifications
+// @Description Отметка одного уведомления текущего пользователя прочитанным
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID уведомления"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешной отметке"
+// @Failure 400 {object} models.StandardError "Некорректный ID уведомления"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Уведомление не найдено"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications/{id}/read [post]
+func (h *Handler) readNotification(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID уведомления"})
+		return
+	}
+
+	if err := h.services.Notification.MarkRead(c.Request.Context(), userId, id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Уведомление отмечено прочитанным"})
+}
+
+// @Summary Отметка уведомлений прочитанными
+// @Tags notifications
+// @Description Отметка прочитанными уведомлений из списка ids. Без тела запроса или с пустым списком отмечаются все уведомления
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body models.NotificationRead false "ID уведомлений"
+// @Success 200 {object} map[string]interface{} "Количество отмеченных уведомлений"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications/read [post]
+func (h *Handler) readNotifications(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var input models.NotificationRead
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			handleValidationError(c, err)
+			return
+		}
+	}
+
+	marked, err := h.services.Notification.MarkAllRead(c.Request.Context(), userId, input.IDs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
+
+// @Summary Настройки уведомлений
+// @Tags notifications
+// @Description Получение настроек уведомлений текущего пользователя: канал для каждого типа (in_app — только в центре уведомлений, email — также в email-дайджесте, off — не уведомлять) и периодичность дайджеста (daily, weekly, off)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.NotificationPreferences "Настройки уведомлений"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications/preferences [get]
+func (h *Handler) getNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	preferences, err := h.services.Notification.GetPreferences(c.Request.Context(), userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// @Summary Изменение настроек уведомлений
+// @Tags notifications
+// @Description Изменение каналов доставки для отдельных типов уведомлений и периодичности email-дайджеста. Не указанные в запросе настройки не меняются
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body models.NotificationPreferencesUpdate true "Новые настройки, например {\"types\": {\"like\": \"off\"}, \"digest\": \"daily\"}"
+// @Success 200 {object} models.NotificationPreferences "Настройки уведомлений после изменения"
+// @Failure 400,422 {object} models.StandardError "Неизвестный тип уведомлений или ошибка валидации"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/notifications/preferences [put]
+func (h *Handler) updateNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var input models.NotificationPreferencesUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	preferences, err := h.services.Notification.UpdatePreferences(c.Request.Context(), userId, input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// unsubscribePage страница подтверждения отписки. GET только показывает ее: ссылки из писем
+// открывают антивирусные сканеры и предзагрузка почтовых клиентов, и отписка по GET
+// срабатывала бы без ведома пользователя
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>Отписка от дайджеста</title></head>
+<body>
+{{if .Done}}<p>Вы отписались от email-дайджеста DesignHub.</p>
+{{else}}<p>Отписаться от email-дайджеста DesignHub?</p>
+<form method="post" action="{{.Action}}"><button type="submit">Отписаться</button></form>
+{{end}}</body>
+</html>
+`))
+
+type unsubscribePageData struct {
+	Done   bool
+	Action string
+}
+
+// @Summary Подтверждение отписки от email-дайджеста
+// @Tags notifications
+// @Description Страница подтверждения для ссылки отписки из письма. Ничего не меняет: отписка выполняется кнопкой на странице
+// @Produce html
+// @Param token query string true "Токен отписки из письма"
+// @Success 200 {string} string "Страница подтверждения"
+// @Failure 400 {object} models.StandardError "Недействительная ссылка отписки"
+// @Router /api/v1/unsubscribe [get]
+func (h *Handler) confirmUnsubscribeDigest(c *gin.Context) {
+	token := c.Query("token")
+	if err := h.services.Notification.CheckUnsubscribeToken(token); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	renderUnsubscribePage(c, unsubscribePageData{Action: "?token=" + url.QueryEscape(token)})
+}
+
+// @Summary Отписка от email-дайджеста
+// @Tags notifications
+// @Description Отписка от email-дайджеста по подписанной ссылке из письма, без авторизации. Поддерживает отписку в один клик из почтового клиента (RFC 8058). Браузеру, отправившему форму со страницы подтверждения, возвращается страница, остальным клиентам — JSON
+// @Accept json
+// @Produce json,html
+// @Param token query string true "Токен отписки из письма"
+// @Success 200 {object} models.UnsubscribeResponse "Сообщение об успешной отписке"
+// @Failure 400 {object} models.StandardError "Недействительная ссылка отписки"
+// @Failure 404 {object} models.StandardError "Пользователь не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/unsubscribe [post]
+func (h *Handler) unsubscribeDigest(c *gin.Context) {
+	if err := h.services.Notification.Unsubscribe(c.Request.Context(), c.Query("token")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderUnsubscribePage(c, unsubscribePageData{Done: true})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UnsubscribeResponse{Message: "Вы отписались от email-дайджеста"})
+}
+
+// renderUnsubscribePage отдает страницу отписки
+func renderUnsubscribePage(c *gin.Context, data unsubscribePageData) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := unsubscribePage.Execute(c.Writer, data); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"designhub/internal/models"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,10 +17,14 @@ import (
 // @Produce json
 // @Param category_id query int false "ID категории"
 // @Param q query string false "Поисковый запрос"
-// @Param sort_by query string false "Поле сортировки (date, popularity)"
+// @Param tool_id query int false "ID инструмента"
+// @Param license query string false "Код лицензии"
+// @Param sort_by query string false "Поле сортировки (date, popularity, trending)"
+// @Param period query string false "Период для сортировки trending (day, week, month)"
 // @Param sort_order query string false "Порядок сортировки (asc, desc)"
 // @Param page query int false "Номер страницы"
 // @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
 // @Success 200 {object} models.FeedResponse "Список постов"
 // @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
 // @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
@@ -83,9 +89,44 @@ func (h *Handler) getPostById(c *gin.Context) {
 		return
 	}
 
+	// Учитываем просмотр опубликованного поста, не считая просмотры автора
+	if post.Status == "approved" && post.Author.ID != currentUserId {
+		h.services.Analytics.RecordView(id, currentUserId, c.ClientIP(), c.Request.Referer())
+	}
+
 	c.JSON(http.StatusOK, post)
 }
 
+// @Summary Получение похожих постов
+// @Tags posts
+// @Description Получение постов, похожих на указанный: из той же категории, того же автора и лайкнутых теми же пользователями
+// @Accept json
+// @Produce json
+// @Param id path int true "ID поста"
+// @Success 200 {array} models.PostResponse "Список похожих постов"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/posts/{id}/related [get]
+func (h *Handler) getRelatedPosts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	posts, err := h.services.Related.GetByPostID(c.Request.Context(), id, currentUserId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
 // @Summary Создание нового поста
 // @Tags posts
 // @Description Создание нового поста с загрузкой медиафайла
@@ -96,6 +137,12 @@ func (h *Handler) getPostById(c *gin.Context) {
 // @Param description formData string true "Описание поста"
 // @Param category_id formData int true "ID категории"
 // @Param media formData file true "Медиафайл (изображение или видео)"
+// @Param draft formData bool false "Сохранить как черновик без отправки на модерацию"
+// @Param publish_at formData string false "Время публикации в формате RFC3339"
+// @Param license formData string false "Код лицензии (по умолчанию all-rights-reserved)"
+// @Param source_url formData string false "Ссылка на оригинал работы"
+// @Param credit_url formData string false "Ссылка на автора или правообладателя"
+// @Param tool_ids formData []int false "ID использованных инструментов" collectionFormat(multi)
 // @Success 201 {object} models.PostResponse "Созданный пост"
 // @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
 // @Failure 401 {object} models.StandardError "Не авторизован"
@@ -130,6 +177,43 @@ func (h *Handler) createPost(c *gin.Context) {
 		return
 	}
 
+	// Черновик и время публикации необязательны
+	var draft bool
+	if draftStr := c.PostForm("draft"); draftStr != "" {
+		draft, err = strconv.ParseBool(draftStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректное значение draft"})
+			return
+		}
+	}
+
+	var publishAt *time.Time
+	if publishAtStr := c.PostForm("publish_at"); publishAtStr != "" {
+		parsed, err := time.Parse(time.RFC3339, publishAtStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректное время публикации, ожидается формат RFC3339"})
+			return
+		}
+		publishAt = &parsed
+	}
+
+	// Инструменты можно передать несколькими полями tool_ids или одним значением через запятую
+	var toolIds []int
+	for _, value := range c.PostFormArray("tool_ids") {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			toolId, err := strconv.Atoi(part)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID инструмента"})
+				return
+			}
+			toolIds = append(toolIds, toolId)
+		}
+	}
+
 	// Получаем медиафайл
 	file, header, err := c.Request.FormFile("media")
 	if err != nil {
@@ -155,6 +239,12 @@ func (h *Handler) createPost(c *gin.Context) {
 		Title:       title,
 		Description: description,
 		CategoryID:  categoryId,
+		Draft:       draft,
+		PublishAt:   publishAt,
+		License:     c.PostForm("license"),
+		SourceURL:   c.PostForm("source_url"),
+		CreditURL:   c.PostForm("credit_url"),
+		ToolIDs:     toolIds,
 	}
 
 	// Сохраняем пост и медиафайл
@@ -226,7 +316,7 @@ func (h *Handler) updatePost(c *gin.Context) {
 
 // @Summary Удаление поста
 // @Tags posts
-// @Description Удаление поста
+// @Description Перемещение поста в корзину, откуда его можно восстановить в течение срока хранения
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -260,6 +350,131 @@ func (h *Handler) deletePost(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Пост успешно удален"})
 }
 
+// @Summary Отправка черновика на модерацию
+// @Tags posts
+// @Description Отправка черновика автора на модерацию
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} models.PostResponse "Отправленный пост"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 409 {object} models.StandardError "Пост не является черновиком"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/submit [post]
+func (h *Handler) submitPost(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	if err := h.services.Post.Submit(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	post, err := h.services.Post.GetByID(c.Request.Context(), id, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// @Summary Статистика поста
+// @Tags posts
+// @Description Получение просмотров, лайков и комментариев поста по дням и основных источников переходов (для автора или модератора)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param days query int false "Количество дней (по умолчанию 30, максимум 365)"
+// @Success 200 {object} models.PostStatsResponse "Статистика поста"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/stats [get]
+func (h *Handler) getPostStats(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	var query models.StatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if query.Days == 0 {
+		query.Days = 30
+	}
+
+	stats, err := h.services.Analytics.GetPostStats(c.Request.Context(), id, userId, query.Days)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary История правок поста
+// @Tags posts
+// @Description Получение истории правок поста с изменениями по полям (для автора или модератора)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {array} models.PostRevisionResponse "История правок"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/revisions [get]
+func (h *Handler) getPostRevisions(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	revisions, err := h.services.Post.GetRevisions(c.Request.Context(), id, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
 // @Summary Лайк поста
 // @Tags posts
 // @Description Добавление лайка к посту
@@ -336,6 +551,50 @@ func (h *Handler) unlikePost(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Лайк успешно удален"})
 }
 
+// @Summary Лента подписок
+// @Tags posts
+// @Description Получение опубликованных постов авторов и категорий, на которые подписан текущий пользователь, в хронологическом порядке
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param category_id query int false "ID категории"
+// @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Success 200 {object} models.FeedResponse "Лента подписок"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/feed [get]
+func (h *Handler) getFollowingFeed(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	// Устанавливаем значения по умолчанию, если не указаны
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = 12
+	}
+
+	posts, err := h.services.Post.GetFollowingFeed(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
 // @Summary Получение постов, ожидающих модерации
 // @Tags moderation
 // @Description Получение списка постов со статусом "pending"
@@ -344,6 +603,7 @@ func (h *Handler) unlikePost(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param page query int false "Номер страницы"
 // @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
 // @Success 200 {object} models.FeedResponse "Список постов на модерации"
 // @Failure 401 {object} models.StandardError "Не авторизован"
 // @Failure 403 {object} models.StandardError "Доступ запрещен"
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Набор реакций
+// @Tags reactions
+// @Description Получение списка типов реакций, которые можно поставить посту или комментарию
+// @Accept json
+// @Produce json
+// @Success 200 {array} string "Типы реакций"
+// @Router /api/v1/reactions [get]
+func (h *Handler) getReactionTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, h.services.Reaction.GetTypes())
+}
+
+// @Summary Реакция на пост
+// @Tags reactions
+// @Description Добавление реакции указанного типа к опубликованному посту. На один пост можно поставить несколько разных реакций
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param type path string true "Тип реакции"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешном добавлении реакции"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста или неизвестный тип реакции"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 409 {object} models.StandardError "Реакция уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/reactions/{type} [post]
+func (h *Handler) addPostReaction(c *gin.Context) {
+	h.addReaction(c, models.ReactionTargetPost, "Некорректный ID поста")
+}
+
+// @Summary Удаление реакции на пост
+// @Tags reactions
+// @Description Снятие реакции указанного типа с поста
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param type path string true "Тип реакции"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении реакции"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста или неизвестный тип реакции"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пост или реакция не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/reactions/{type} [delete]
+func (h *Handler) removePostReaction(c *gin.Context) {
+	h.removeReaction(c, models.ReactionTargetPost, "Некорректный ID поста")
+}
+
+// @Summary Реакция на комментарий
+// @Tags reactions
+// @Description Добавление реакции указанного типа к комментарию
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Param type path string true "Тип реакции"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешном добавлении реакции"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария или неизвестный тип реакции"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 409 {object} models.StandardError "Реакция уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/reactions/{type} [post]
+func (h *Handler) addCommentReaction(c *gin.Context) {
+	h.addReaction(c, models.ReactionTargetComment, "Некорректный ID комментария")
+}
+
+// @Summary Удаление реакции на комментарий
+// @Tags reactions
+// @Description Снятие реакции указанного типа с комментария
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Param type path string true "Тип реакции"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении реакции"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария или неизвестный тип реакции"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Комментарий или реакция не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/reactions/{type} [delete]
+func (h *Handler) removeCommentReaction(c *gin.Context) {
+	h.removeReaction(c, models.ReactionTargetComment, "Некорректный ID комментария")
+}
+
+// @Summary Кто поставил реакцию на пост
+// @Tags reactions
+// @Description Получение списка пользователей, поставивших посту реакцию указанного типа, новые сверху
+// @Accept json
+// @Produce json
+// @Param id path int true "ID поста"
+// @Param type path string true "Тип реакции"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.ReactionUsersResponse "Список пользователей"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста или неизвестный тип реакции"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/posts/{id}/reactions/{type} [get]
+func (h *Handler) getPostReactionUsers(c *gin.Context) {
+	h.getReactionUsers(c, models.ReactionTargetPost, "Некорректный ID поста")
+}
+
+// @Summary Кто поставил реакцию на комментарий
+// @Tags reactions
+// @Description Получение списка пользователей, поставивших комментарию реакцию указанного типа, новые сверху
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Param type path string true "Тип реакции"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество пользователей на странице (по умолчанию 20)"
+// @Success 200 {object} models.ReactionUsersResponse "Список пользователей"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария или неизвестный тип реакции"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/public/comments/{id}/reactions/{type} [get]
+func (h *Handler) getCommentReactionUsers(c *gin.Context) {
+	h.getReactionUsers(c, models.ReactionTargetComment, "Некорректный ID комментария")
+}
+
+// addReaction ставит реакцию на пост или комментарий из параметров пути
+func (h *Handler) addReaction(c *gin.Context, target string, invalidIdMessage string) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": invalidIdMessage})
+		return
+	}
+
+	if err := h.services.Reaction.Add(c.Request.Context(), userId, target, id, c.Param("type")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Реакция успешно добавлена"})
+}
+
+// removeReaction снимает реакцию с поста или комментария из параметров пути
+func (h *Handler) removeReaction(c *gin.Context, target string, invalidIdMessage string) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": invalidIdMessage})
+		return
+	}
+
+	if err := h.services.Reaction.Remove(c.Request.Context(), userId, target, id, c.Param("type")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Реакция успешно удалена"})
+}
+
+// getReactionUsers отдает страницу отреагировавших пользователей
+func (h *Handler) getReactionUsers(c *gin.Context, target string, invalidIdMessage string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": invalidIdMessage})
+		return
+	}
+
+	var filter models.ReactionUsersFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+	filter.ViewerID, _ = getUserId(c)
+
+	users, err := h.services.Reaction.GetUsers(c.Request.Context(), target, id, c.Param("type"), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Жалоба на пост
+// @Tags reports
+// @Description Жалоба на опубликованный пост. Один пользователь может пожаловаться на пост один раз. Набрав REPORTS_AUTO_HIDE_THRESHOLD жалоб от разных пользователей, пост скрывается до решения модератора
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Param input body models.ReportCreate true "Причина жалобы"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешной отправке жалобы"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных или жалоба на свой пост"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 409 {object} models.StandardError "Жалоба уже отправлена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/report [post]
+func (h *Handler) reportPost(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	var input models.ReportCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Report.ReportPost(c.Request.Context(), userId, id, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Жалоба отправлена"})
+}
+
+// @Summary Жалоба на комментарий
+// @Tags reports
+// @Description Жалоба на комментарий к опубликованному посту. Один пользователь может пожаловаться на комментарий один раз. Набрав REPORTS_AUTO_HIDE_THRESHOLD жалоб от разных пользователей, комментарий скрывается до решения модератора
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Param input body models.ReportCreate true "Причина жалобы"
+// @Success 201 {object} map[string]interface{} "Сообщение об успешной отправке жалобы"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных или жалоба на свой комментарий"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 404 {object} models.StandardError "Комментарий не найден"
+// @Failure 409 {object} models.StandardError "Жалоба уже отправлена"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/report [post]
+func (h *Handler) reportComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID комментария"})
+		return
+	}
+
+	var input models.ReportCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Report.ReportComment(c.Request.Context(), userId, id, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Жалоба отправлена"})
+}
+
+// @Summary Очередь жалоб
+// @Tags moderation
+// @Description Жалобы, сгруппированные по объекту: сверху объекты с наибольшим числом жалоб. hidden показывает, скрыт ли объект сейчас
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "open (по умолчанию) или resolved"
+// @Param target_type query string false "post или comment"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество объектов на странице (по умолчанию 20)"
+// @Success 200 {object} models.ReportGroupsResponse "Очередь жалоб"
+// @Failure 400 {object} models.StandardError "Некорректные параметры"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/reports [get]
+func (h *Handler) getReports(c *gin.Context) {
+	var filter models.ReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	reports, err := h.services.Report.GetQueue(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Жалобы на объект
+// @Tags moderation
+// @Description Все жалобы на пост или комментарий, включая закрытые, новые сверху
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param target path string true "post или comment"
+// @Param id path int true "ID объекта"
+// @Success 200 {array} models.ReportResponse "Жалобы"
+// @Failure 400 {object} models.StandardError "Некорректный объект"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Жалобы не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/reports/{target}/{id} [get]
+func (h *Handler) getTargetReports(c *gin.Context) {
+	target, id, ok := reportTargetParams(c)
+	if !ok {
+		return
+	}
+
+	reports, err := h.services.Report.GetByTarget(c.Request.Context(), target, id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Решение по жалобам
+// @Tags moderation
+// @Description Решение модератора по всем открытым жалобам на объект: dismiss — отклонить жалобы и вернуть скрытый объект, hide — скрыть объект, delete — переместить в корзину, warn — отправить автору предупреждение с текстом message
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param target path string true "post или comment"
+// @Param id path int true "ID объекта"
+// @Param input body models.ReportResolve true "Решение"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном решении"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Открытые жалобы или объект не найдены"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/reports/{target}/{id}/resolve [post]
+func (h *Handler) resolveReports(c *gin.Context) {
+	moderatorId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	target, id, ok := reportTargetParams(c)
+	if !ok {
+		return
+	}
+
+	var input models.ReportResolve
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Report.Resolve(c.Request.Context(), target, id, moderatorId, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Жалобы рассмотрены"})
+}
+
+// reportTargetParams разбирает тип и ID объекта жалоб из пути
+func reportTargetParams(c *gin.Context) (string, int, bool) {
+	target := c.Param("target")
+	if target != models.ReportTargetPost && target != models.ReportTargetComment {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный тип объекта"})
+		return "", 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID объекта"})
+		return "", 0, false
+	}
+
+	return target, id, true
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/pkg/pubsub"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Поток событий реального времени
+// @Tags stream
+// @Description Server-Sent Events: новые уведомления (notification), счетчик непрочитанных (unread_count), счетчики лайков и комментариев просматриваемых постов (post_counts), личные сообщения (message) и отметки о прочтении (messages_read), а для модераторов — изменения очереди модерации (moderation) и очереди жалоб (report). EventSource не умеет передавать заголовки, поэтому токен можно передать параметром token
+// @Produce text/event-stream
+// @Param token query string false "JWT токен, если не передан заголовок Authorization"
+// @Param posts query string false "ID просматриваемых постов через запятую"
+// @Success 200 {string} string "Поток событий"
+// @Failure 400 {object} models.StandardError "Некорректный список постов"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Нет доступа к неопубликованному посту"
+// @Failure 404 {object} models.StandardError "Пост не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/stream [get]
+func (h *Handler) stream(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var postIds []int
+	for _, part := range strings.Split(c.Query("posts"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		postId, err := strconv.Atoi(part)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+			return
+		}
+		postIds = append(postIds, postId)
+	}
+
+	sub, err := h.services.Stream.Subscribe(c.Request.Context(), userId, postIds)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer sub.Close()
+
+	// Поток живет дольше WriteTimeout сервера, поэтому снимаем ограничение для этого соединения
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Поток событий не поддерживается"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Отключаем буферизацию в nginx
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(h.config.Realtime.KeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.streamsDone:
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			data, err := h.streamData(c.Request.Context(), userId, msg)
+			if err != nil {
+				logrus.Warnf("Failed to prepare %s event for user %d: %s", msg.Event, userId, err.Error())
+				continue
+			}
+			c.SSEvent(msg.Event, data)
+			c.Writer.Flush()
+		case <-keepAlive.C:
+			// Комментарий SSE не вызывает событий у клиента, но держит соединение открытым
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// streamData готовит данные события для клиента. О новом сообщении шина передает только
+// ID, поэтому само сообщение загружается здесь
+func (h *Handler) streamData(ctx context.Context, userId int, msg pubsub.Message) (interface{}, error) {
+	if msg.Event != models.StreamEventMessage {
+		return msg.Data, nil
+	}
+
+	var ref models.MessageRef
+	if err := json.Unmarshal(msg.Data, &ref); err != nil {
+		return nil, err
+	}
+
+	return h.services.Message.GetEvent(ctx, userId, ref)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Каталог инструментов
+// @Tags tools
+// @Description Получение списка инструментов, которые можно указать в посте
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Tool "Список инструментов"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/tools [get]
+func (h *Handler) getAllTools(c *gin.Context) {
+	tools, err := h.services.Tool.GetAll(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tools)
+}
+
+// @Summary Получение инструмента по ID
+// @Tags tools
+// @Description Получение информации об инструменте из каталога
+// @Accept json
+// @Produce json
+// @Param id path int true "ID инструмента"
+// @Success 200 {object} models.Tool "Информация об инструменте"
+// @Failure 400 {object} models.StandardError "Некорректный ID инструмента"
+// @Failure 404 {object} models.StandardError "Инструмент не найден"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/tools/{id} [get]
+func (h *Handler) getToolById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID инструмента"})
+		return
+	}
+
+	tool, err := h.services.Tool.GetByID(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tool)
+}
+
+// @Summary Список лицензий
+// @Tags tools
+// @Description Получение списка лицензий, под которыми можно опубликовать работу
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.License "Список лицензий"
+// @Router /api/v1/licenses [get]
+func (h *Handler) getLicenses(c *gin.Context) {
+	c.JSON(http.StatusOK, models.Licenses)
+}
+
+// @Summary Добавление инструмента
+// @Tags tools
+// @Description Добавление инструмента в каталог (только для модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body models.ToolCreate true "Данные инструмента"
+// @Success 201 {object} models.Tool "Созданный инструмент"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 409 {object} models.StandardError "Инструмент уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/tools [post]
+func (h *Handler) createTool(c *gin.Context) {
+	var input models.ToolCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	toolId, err := h.services.Tool.Create(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	tool, err := h.services.Tool.GetByID(c.Request.Context(), toolId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tool)
+}
+
+// @Summary Обновление инструмента
+// @Tags tools
+// @Description Переименование инструмента в каталоге (только для модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID инструмента"
+// @Param input body models.ToolUpdate true "Данные для обновления инструмента"
+// @Success 200 {object} models.Tool "Обновленный инструмент"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Инструмент не найден"
+// @Failure 409 {object} models.StandardError "Инструмент уже существует"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/tools/{id} [put]
+func (h *Handler) updateTool(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID инструмента"})
+		return
+	}
+
+	var input models.ToolUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if err := h.services.Tool.Update(c.Request.Context(), id, input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	tool, err := h.services.Tool.GetByID(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tool)
+}
+
+// @Summary Удаление инструмента
+// @Tags tools
+// @Description Удаление инструмента из каталога, если он не указан ни в одном посте (только для модераторов)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID инструмента"
+// @Success 200 {object} map[string]interface{} "Сообщение об успешном удалении"
+// @Failure 400 {object} models.StandardError "Некорректный ID инструмента"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Инструмент не найден"
+// @Failure 409 {object} models.StandardError "Инструмент используется в постах"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/tools/{id} [delete]
+func (h *Handler) deleteTool(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID инструмента"})
+		return
+	}
+
+	if err := h.services.Tool.Delete(c.Request.Context(), id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Инструмент успешно удален"})
+}
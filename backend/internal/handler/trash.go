@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"designhub/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Корзина пользователя
+// @Tags trash
+// @Description Получение удаленных постов и комментариев текущего пользователя
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type query string false "Тип записей (post, comment)"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице"
+// @Success 200 {object} models.TrashResponse "Содержимое корзины"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/trash [get]
+func (h *Handler) getUserTrash(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	filter, ok := bindTrashFilter(c)
+	if !ok {
+		return
+	}
+
+	trash, err := h.services.Trash.GetUserTrash(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Корзина модератора
+// @Tags moderation
+// @Description Получение всех удаленных постов и комментариев
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type query string false "Тип записей (post, comment)"
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице"
+// @Success 200 {object} models.TrashResponse "Содержимое корзины"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/admin/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	filter, ok := bindTrashFilter(c)
+	if !ok {
+		return
+	}
+
+	trash, err := h.services.Trash.GetAllTrash(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Восстановление поста
+// @Tags trash
+// @Description Восстановление поста из корзины (автором, если он удалил пост сам, или модератором)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} models.PostResponse "Восстановленный пост"
+// @Failure 400 {object} models.StandardError "Некорректный ID поста"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Пост не найден в корзине"
+// @Failure 410 {object} models.StandardError "Срок восстановления истек"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/{id}/restore [post]
+func (h *Handler) restorePost(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID поста"})
+		return
+	}
+
+	if err := h.services.Trash.RestorePost(c.Request.Context(), id, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	post, err := h.services.Post.GetByID(c.Request.Context(), id, userId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// @Summary Восстановление комментария
+// @Tags trash
+// @Description Восстановление комментария из корзины (автором, если он удалил комментарий сам, или модератором)
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID комментария"
+// @Success 200 {object} models.CommentResponse "Восстановленный комментарий"
+// @Failure 400 {object} models.StandardError "Некорректный ID комментария"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 403 {object} models.StandardError "Доступ запрещен"
+// @Failure 404 {object} models.StandardError "Комментарий не найден в корзине"
+// @Failure 410 {object} models.StandardError "Срок восстановления истек"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/posts/comments/{id}/restore [post]
+func (h *Handler) restoreComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Некорректный ID комментария"})
+		return
+	}
+
+	if err := h.services.Trash.RestoreComment(c.Request.Context(), commentId, userId); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	comment, err := h.services.Comment.GetByID(c.Request.Context(), commentId)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// bindTrashFilter разбирает параметры корзины и подставляет значения пагинации по умолчанию
+func bindTrashFilter(c *gin.Context) (models.TrashFilter, bool) {
+	var filter models.TrashFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return filter, false
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = 12
+	}
+
+	return filter, true
+}
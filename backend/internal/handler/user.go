@@ -25,7 +25,7 @@ func (h *Handler) getUserProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.services.User.GetByID(c.Request.Context(), userId)
+	user, err := h.services.User.GetByID(c.Request.Context(), userId, userId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -51,7 +51,10 @@ func (h *Handler) getUserById(c *gin.Context) {
 		return
 	}
 
-	user, err := h.services.User.GetByID(c.Request.Context(), id)
+	// Получаем текущего пользователя из контекста (если он авторизован)
+	currentUserId, _ := getUserId(c)
+
+	user, err := h.services.User.GetByID(c.Request.Context(), id, currentUserId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -90,7 +93,7 @@ func (h *Handler) updateUserProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.services.User.GetByID(c.Request.Context(), userId)
+	user, err := h.services.User.GetByID(c.Request.Context(), userId, userId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -145,7 +148,7 @@ func (h *Handler) updateUserAvatar(c *gin.Context) {
 	}
 
 	// Получение обновленных данных пользователя
-	user, err := h.services.User.GetByID(c.Request.Context(), userId)
+	user, err := h.services.User.GetByID(c.Request.Context(), userId, userId)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -154,6 +157,44 @@ func (h *Handler) updateUserAvatar(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// @Summary Панель автора
+// @Tags users
+// @Description Получение сводной статистики портфолио текущего пользователя: итоги, активность по дням, доля одобренных постов, медианное время модерации и лучшие работы
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Количество дней (по умолчанию 30, максимум 365)"
+// @Success 200 {object} models.DashboardResponse "Статистика портфолио"
+// @Failure 400,422 {object} models.StandardError "Ошибка валидации данных"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/dashboard [get]
+func (h *Handler) getUserDashboard(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var query models.StatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	if query.Days == 0 {
+		query.Days = 30
+	}
+
+	dashboard, err := h.services.Analytics.GetCreatorDashboard(c.Request.Context(), userId, query.Days)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
 // @Summary Получение постов, понравившихся пользователю
 // @Tags users
 // @Description Получение списка постов, которые понравились текущему пользователю
@@ -162,6 +203,7 @@ func (h *Handler) updateUserAvatar(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param page query int false "Номер страницы"
 // @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
 // @Success 200 {object} models.FeedResponse "Список понравившихся постов"
 // @Failure 401 {object} models.StandardError "Не авторизован"
 // @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
@@ -196,6 +238,92 @@ func (h *Handler) getUserLikedPosts(c *gin.Context) {
 	c.JSON(http.StatusOK, posts)
 }
 
+// @Summary Получение черновиков пользователя
+// @Tags users
+// @Description Получение списка черновиков текущего пользователя
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Success 200 {object} models.FeedResponse "Список черновиков"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/drafts [get]
+func (h *Handler) getUserDrafts(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	// Устанавливаем значения по умолчанию, если не указаны
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = 12
+	}
+
+	posts, err := h.services.Post.GetDrafts(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
+// @Summary Получение отложенных постов пользователя
+// @Tags users
+// @Description Получение еще не опубликованных постов текущего пользователя с назначенным временем публикации
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Номер страницы"
+// @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Success 200 {object} models.FeedResponse "Список отложенных постов"
+// @Failure 401 {object} models.StandardError "Не авторизован"
+// @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
+// @Router /api/v1/users/me/scheduled [get]
+func (h *Handler) getUserScheduledPosts(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Требуется авторизация"})
+		return
+	}
+
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		handleValidationError(c, err)
+		return
+	}
+
+	// Устанавливаем значения по умолчанию, если не указаны
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = 12
+	}
+
+	posts, err := h.services.Post.GetScheduled(c.Request.Context(), userId, filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
 // @Summary Получение постов пользователя
 // @Tags users
 // @Description Получение списка постов указанного пользователя
@@ -204,6 +332,7 @@ func (h *Handler) getUserLikedPosts(c *gin.Context) {
 // @Param id path int true "ID пользователя"
 // @Param page query int false "Номер страницы"
 // @Param per_page query int false "Количество записей на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
 // @Success 200 {object} models.FeedResponse "Список постов пользователя"
 // @Failure 404 {object} models.StandardError "Пользователь не найден"
 // @Failure 500 {object} models.StandardError "Внутренняя ошибка сервера"
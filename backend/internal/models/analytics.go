@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// PostViewCount накопленные просмотры поста за день из одного источника
+type PostViewCount struct {
+	PostID   int
+	Day      time.Time
+	Referrer string
+	Views    int
+}
+
+// PostDailyStat статистика поста за один день
+type PostDailyStat struct {
+	Day      time.Time `json:"day" db:"day"`
+	Views    int       `json:"views" db:"views"`
+	Likes    int       `json:"likes" db:"likes"`
+	Comments int       `json:"comments" db:"comments"`
+}
+
+// ReferrerStat количество просмотров из одного источника
+type ReferrerStat struct {
+	Referrer string `json:"referrer" db:"referrer"`
+	Views    int    `json:"views" db:"views"`
+}
+
+// StatsQuery параметры запроса статистики
+type StatsQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+// PostStatsResponse статистика поста для автора
+type PostStatsResponse struct {
+	PostID        int             `json:"post_id"`
+	ViewsCount    int             `json:"views_count"`
+	LikesCount    int             `json:"likes_count"`
+	CommentsCount int             `json:"comments_count"`
+	Daily         []PostDailyStat `json:"daily"`
+	TopReferrers  []ReferrerStat  `json:"top_referrers"`
+}
+
+// CreatorSummary агрегированные показатели всех постов автора
+type CreatorSummary struct {
+	Posts                   int      `json:"posts" db:"posts"`
+	ApprovedPosts           int      `json:"approved_posts" db:"approved_posts"`
+	PendingPosts            int      `json:"pending_posts" db:"pending_posts"`
+	RejectedPosts           int      `json:"rejected_posts" db:"rejected_posts"`
+	Views                   int      `json:"views" db:"views"`
+	Likes                   int      `json:"likes" db:"likes"`
+	Comments                int      `json:"comments" db:"comments"`
+	Followers               int      `json:"followers" db:"followers"`
+	MedianModerationSeconds *float64 `json:"median_moderation_seconds" db:"median_moderation_seconds"` // От последней отправки на модерацию до решения
+}
+
+// CreatorDailyStat активность вокруг работ автора за один день
+type CreatorDailyStat struct {
+	Day          time.Time `json:"day" db:"day"`
+	Views        int       `json:"views" db:"views"`
+	Likes        int       `json:"likes" db:"likes"`
+	Comments     int       `json:"comments" db:"comments"`
+	NewFollowers int       `json:"new_followers" db:"new_followers"`
+}
+
+// TopPostStat показатели поста за период для списка лучших работ
+type TopPostStat struct {
+	PostID   int    `json:"post_id" db:"post_id"`
+	Title    string `json:"title" db:"title"`
+	Views    int    `json:"views" db:"views"`
+	Likes    int    `json:"likes" db:"likes"`
+	Comments int    `json:"comments" db:"comments"`
+}
+
+// DashboardResponse сводная статистика портфолио автора
+type DashboardResponse struct {
+	Totals       CreatorSummary     `json:"totals"`
+	ApprovalRate *float64           `json:"approval_rate"` // Доля одобренных среди промодерированных, null если решений еще не было
+	Daily        []CreatorDailyStat `json:"daily"`
+	TopPosts     []TopPostStat      `json:"top_posts"`
+}
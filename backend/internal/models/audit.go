@@ -0,0 +1,111 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Действия модераторов в журнале
+const (
+	AuditActionPostStatus     = "post_status"    // Смена статуса поста при модерации
+	AuditActionPostDelete     = "post_delete"    // Удаление чужого поста
+	AuditActionCommentDelete  = "comment_delete" // Удаление чужого комментария
+	AuditActionCategoryCreate = "category_create"
+	AuditActionCategoryUpdate = "category_update"
+	AuditActionCategoryDelete = "category_delete"
+	AuditActionReportDismiss  = "report_dismiss" // Решения по жалобам
+	AuditActionReportHide     = "report_hide"
+	AuditActionReportDelete   = "report_delete"
+	AuditActionReportWarn     = "report_warn"
+	AuditActionAutoHide       = "report_auto_hide" // Автоматическое скрытие по числу жалоб, без модератора
+)
+
+// Объекты действий модераторов
+const (
+	AuditTargetPost     = "post"
+	AuditTargetComment  = "comment"
+	AuditTargetCategory = "category"
+)
+
+// AuditState состояние объекта до или после действия модератора, хранится в JSONB
+type AuditState map[string]interface{}
+
+// Value сериализует состояние для записи в базу. Пустое состояние сохраняется как NULL
+func (s AuditState) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan восстанавливает состояние из JSON
+func (s *AuditState) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*s = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for audit state: %T", src)
+	}
+
+	return json.Unmarshal(data, s)
+}
+
+// ModerationAction запись журнала действий модераторов
+type ModerationAction struct {
+	ID            int        `db:"id"`
+	ModeratorID   *int       `db:"moderator_id"`
+	Action        string     `db:"action"`
+	TargetType    string     `db:"target_type"`
+	TargetID      int        `db:"target_id"`
+	Reason        *string    `db:"reason"`
+	PreviousState AuditState `db:"previous_state"`
+	NewState      AuditState `db:"new_state"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// ModerationActionDetails запись журнала вместе с данными модератора
+type ModerationActionDetails struct {
+	ModerationAction
+	ModeratorUsername *string `db:"moderator_username"`
+	ModeratorNickname *string `db:"moderator_nickname"`
+	ModeratorAvatar   *string `db:"moderator_avatar"`
+}
+
+// AuditFilter параметры выборки журнала действий модераторов
+type AuditFilter struct {
+	ModeratorID int       `form:"moderator_id" binding:"omitempty,min=1"`
+	TargetType  string    `form:"target_type" binding:"omitempty,oneof=post comment category"`
+	TargetID    int       `form:"target_id" binding:"omitempty,min=1"`
+	Action      string    `form:"action"`
+	From        time.Time `form:"from" time_format:"2006-01-02"` // Начиная с даты включительно
+	To          time.Time `form:"to" time_format:"2006-01-02"`   // По дату включительно
+	Page        int       `form:"page" binding:"omitempty,min=1"`
+	PerPage     int       `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// ModerationActionResponse модель ответа с записью журнала
+type ModerationActionResponse struct {
+	ID            int        `json:"id"`
+	Moderator     *UserBrief `json:"moderator,omitempty"` // Не указан для автоматических действий
+	Action        string     `json:"action"`
+	TargetType    string     `json:"target_type"`
+	TargetID      int        `json:"target_id"`
+	Reason        *string    `json:"reason,omitempty"`
+	PreviousState AuditState `json:"previous_state"`
+	NewState      AuditState `json:"new_state"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ModerationActionsResponse модель ответа с журналом действий модераторов
+type ModerationActionsResponse struct {
+	Actions    []ModerationActionResponse `json:"actions"`
+	Pagination Pagination                 `json:"pagination"`
+}
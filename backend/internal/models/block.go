@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSelfRestrict возвращается при попытке заблокировать или заглушить самого себя
+	ErrSelfRestrict = errors.New("нельзя заблокировать или заглушить себя")
+	// ErrUserBlocked возвращается, если действие запрещено блокировкой между пользователями
+	ErrUserBlocked = errors.New("пользователь заблокирован")
+)
+
+// RestrictedUser пользователь из списка заблокированных или заглушенных
+type RestrictedUser struct {
+	UserID    int       `db:"user_id"`
+	Username  string    `db:"username"`
+	Nickname  string    `db:"nickname"`
+	Avatar    *string   `db:"avatar"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// RestrictionFilter параметры пагинации списков заблокированных и заглушенных
+type RestrictionFilter struct {
+	Page    int `form:"page" binding:"omitempty,min=1"`
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// RestrictedUserResponse модель ответа с заблокированным или заглушенным пользователем
+type RestrictedUserResponse struct {
+	User      UserBrief `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RestrictedUsersResponse модель ответа со списком заблокированных или заглушенных
+type RestrictedUsersResponse struct {
+	Users      []RestrictedUserResponse `json:"users"`
+	Pagination Pagination               `json:"pagination"`
+}
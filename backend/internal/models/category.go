@@ -4,11 +4,13 @@ import "time"
 
 // Category представляет модель категории
 type Category struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Slug      string    `json:"slug" db:"slug"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID             int       `json:"id" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	Slug           string    `json:"slug" db:"slug"`
+	FollowersCount int       `json:"followers_count" db:"followers_count"`
+	IsFollowing    bool      `json:"is_following" db:"-"` // Подписан ли текущий пользователь
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // CategoryCreate модель для создания категории
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCoauthor возвращается при попытке пригласить в соавторы автора поста
+var ErrInvalidCoauthor = errors.New("некорректный соавтор")
+
+// PostCoauthor представляет приглашение пользователя в соавторы поста.
+// Роль соавтора состоит из подписи и права редактирования. Подпись — произвольный текст
+// для отображения ("Иллюстрация", "3D"), и вывести из нее права нельзя, поэтому право
+// редактирования автор задает явно вместе с подписью
+type PostCoauthor struct {
+	PostID      int        `json:"post_id" db:"post_id"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	Role        string     `json:"role" db:"role"`         // Подпись роли
+	CanEdit     bool       `json:"can_edit" db:"can_edit"` // Право редактировать пост, часть роли
+	Status      string     `json:"status" db:"status"`     // "pending", "accepted", "declined"
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+// PostCoauthorDetails соавтор вместе с данными пользователя
+type PostCoauthorDetails struct {
+	PostCoauthor
+	Username string  `db:"username"`
+	Nickname string  `db:"nickname"`
+	Avatar   *string `db:"avatar"`
+}
+
+// CoauthorInvitation приглашение в соавторы вместе с постом и его автором
+type CoauthorInvitation struct {
+	PostCoauthor
+	PostTitle      string  `db:"post_title"`
+	PostMediaPath  string  `db:"post_media_path"`
+	AuthorID       int     `db:"author_id"`
+	AuthorUsername string  `db:"author_username"`
+	AuthorNickname string  `db:"author_nickname"`
+	AuthorAvatar   *string `db:"author_avatar"`
+}
+
+// CoauthorInvite модель для приглашения соавтора
+type CoauthorInvite struct {
+	UserID  int    `json:"user_id" binding:"required"`
+	Role    string `json:"role" binding:"required,min=1,max=50"`
+	CanEdit bool   `json:"can_edit"`
+}
+
+// CoauthorUpdate модель для изменения роли соавтора
+type CoauthorUpdate struct {
+	Role    string `json:"role" binding:"required,min=1,max=50"`
+	CanEdit bool   `json:"can_edit"`
+}
+
+// CoauthorBrief краткая информация о соавторе для включения в ответ о посте
+type CoauthorBrief struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	Role     string `json:"role"`
+}
+
+// CoauthorList список принятых соавторов поста, выбирается из базы одним JSON-массивом
+type CoauthorList []CoauthorBrief
+
+// Value сериализует список для записи в базу
+func (l CoauthorList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan восстанавливает список из JSON
+func (l *CoauthorList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for coauthors: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+// CoauthorResponse модель ответа с информацией о соавторе поста
+type CoauthorResponse struct {
+	User        UserBrief  `json:"user"`
+	Role        string     `json:"role"`
+	CanEdit     bool       `json:"can_edit"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// CoauthorInvitationResponse модель ответа с приглашением в соавторы
+type CoauthorInvitationResponse struct {
+	PostID    int       `json:"post_id"`
+	PostTitle string    `json:"post_title"`
+	MediaURL  string    `json:"media_url"`
+	Author    UserBrief `json:"author"`
+	Role      string    `json:"role"`
+	CanEdit   bool      `json:"can_edit"`
+	CreatedAt time.Time `json:"created_at"`
+}
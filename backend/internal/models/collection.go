@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidCollectionOrder возвращается, если новый порядок не совпадает с набором постов коллекции
+var ErrInvalidCollectionOrder = errors.New("некорректный порядок элементов коллекции")
+
+// Collection представляет модель коллекции
+type Collection struct {
+	ID          int       `json:"id" db:"id"`
+	UserID      int       `json:"user_id" db:"user_id"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	CoverPath   *string   `json:"cover_path,omitempty" db:"cover_path"`
+	IsPublic    bool      `json:"is_public" db:"is_public"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CollectionDetails коллекция вместе с владельцем, количеством постов и запасной обложкой
+type CollectionDetails struct {
+	Collection
+	OwnerUsername string  `db:"owner_username"`
+	OwnerNickname string  `db:"owner_nickname"`
+	OwnerAvatar   *string `db:"owner_avatar"`
+	ItemsCount    int     `db:"items_count"`
+	FallbackCover *string `db:"fallback_cover"` // Медиафайл первого поста коллекции
+}
+
+// CollectionCreate модель для создания коллекции
+type CollectionCreate struct {
+	Title       string `json:"title" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// CollectionUpdate модель для обновления коллекции
+type CollectionUpdate struct {
+	Title       string  `json:"title" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
+// CollectionItemAdd модель для добавления поста в коллекцию
+type CollectionItemAdd struct {
+	PostID int `json:"post_id" binding:"required"`
+}
+
+// CollectionReorder модель для изменения порядка постов в коллекции
+type CollectionReorder struct {
+	PostIDs []int `json:"post_ids" binding:"required,min=1"`
+}
+
+// CollectionResponse модель ответа с информацией о коллекции
+type CollectionResponse struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CoverURL    string    `json:"cover_url"`
+	IsPublic    bool      `json:"is_public"`
+	ItemsCount  int       `json:"items_count"`
+	Owner       UserBrief `json:"user"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CollectionPageResponse модель ответа для страницы коллекции
+type CollectionPageResponse struct {
+	Collection CollectionResponse `json:"collection"`
+	Posts      FeedResponse       `json:"posts"`
+}
@@ -1,21 +1,56 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidParentComment возвращается при ответе на комментарий к другому посту
+var ErrInvalidParentComment = errors.New("некорректный родительский комментарий")
+
+// ErrInvalidAnnotation возвращается, если область аннотации выходит за границы медиа
+var ErrInvalidAnnotation = errors.New("некорректная аннотация")
 
 // Comment представляет модель комментария
 type Comment struct {
-	ID        int       `json:"id" db:"id"`
-	Content   string    `json:"content" db:"content"`
-	UserID    int       `json:"user_id" db:"user_id"`
-	PostID    int       `json:"post_id" db:"post_id"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID           int            `json:"id" db:"id"`
+	Content      string         `json:"content" db:"content"`
+	UserID       int            `json:"user_id" db:"user_id"`
+	PostID       int            `json:"post_id" db:"post_id"`
+	ParentID     *int           `json:"parent_id,omitempty" db:"parent_id"` // Комментарий, на который дан ответ
+	Depth        int            `json:"depth" db:"depth"`                   // Уровень вложенности, 0 для комментариев верхнего уровня
+	RepliesCount int            `json:"replies_count" db:"replies_count"`
+	Reactions    ReactionCounts `json:"reactions" db:"reaction_counts"`         // Счетчики реакций по типам
+	MediaIndex   *int           `json:"media_index,omitempty" db:"media_index"` // Номер медиа поста, к которому привязан комментарий
+	X            *float64       `json:"x,omitempty" db:"pos_x"`                 // Нормализованные координаты (0..1)
+	Y            *float64       `json:"y,omitempty" db:"pos_y"`
+	Width        *float64       `json:"width,omitempty" db:"width"` // Размер области, если отмечена не точка
+	Height       *float64       `json:"height,omitempty" db:"height"`
+	ResolvedAt   *time.Time     `json:"resolved_at,omitempty" db:"resolved_at"`
+	ResolvedBy   *int           `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"` // Время перемещения в корзину
+	DeletedBy    *int           `json:"deleted_by,omitempty" db:"deleted_by"`
+	HiddenAt     *time.Time     `json:"hidden_at,omitempty" db:"hidden_at"` // Время скрытия по жалобам
+}
+
+// CommentAnnotation привязка комментария к точке или прямоугольной области медиа.
+// Координаты нормализованы относительно размеров медиа: (0, 0) — левый верхний угол, (1, 1) — правый нижний
+type CommentAnnotation struct {
+	MediaIndex int      `json:"media_index" binding:"min=0"`
+	X          float64  `json:"x" binding:"min=0,max=1"`
+	Y          float64  `json:"y" binding:"min=0,max=1"`
+	Width      *float64 `json:"width,omitempty" binding:"omitempty,gt=0,max=1"`
+	Height     *float64 `json:"height,omitempty" binding:"omitempty,gt=0,max=1"`
 }
 
 // CommentCreate модель для создания комментария
 type CommentCreate struct {
-	Content string `json:"content" binding:"required,min=1,max=500"`
-	PostID  int    `json:"post_id" binding:"required"`
+	Content    string             `json:"content" binding:"required,min=1,max=500"`
+	PostID     int                `json:"post_id" binding:"required"`
+	ParentID   *int               `json:"parent_id"`  // Ответ на комментарий того же поста
+	Annotation *CommentAnnotation `json:"annotation"` // Необязательная привязка к области медиа
 }
 
 // CommentUpdate модель для обновления комментария
@@ -23,11 +58,37 @@ type CommentUpdate struct {
 	Content string `json:"content" binding:"required,min=1,max=500"`
 }
 
+// CommentFilter модель для фильтрации комментариев поста
+type CommentFilter struct {
+	Resolved  *bool  `form:"resolved"`  // true — только учтенные, false — только открытые
+	Annotated *bool  `form:"annotated"` // true — только привязанные к области медиа
+	PerPage   int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Cursor    string `form:"cursor"` // Курсор следующей страницы (next_cursor из предыдущего ответа)
+	ParentID  int    `form:"-"`      // Комментарий, ответы на который выбираются; 0 — верхний уровень
+	ViewerID  int    `form:"-"`      // Текущий пользователь: для него отмечаются реакции и скрываются заблокированные и заглушенные авторы
+}
+
 // CommentResponse модель ответа с информацией о комментарии
 type CommentResponse struct {
-	ID        int       `json:"id"`
-	Content   string    `json:"content"`
-	User      UserBrief `json:"user"`
-	PostID    int       `json:"post_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           int                `json:"id"`
+	Content      string             `json:"content"`
+	User         UserBrief          `json:"user"`
+	PostID       int                `json:"post_id"`
+	ParentID     *int               `json:"parent_id,omitempty"`
+	Depth        int                `json:"depth"`
+	RepliesCount int                `json:"replies_count"`
+	Annotation   *CommentAnnotation `json:"annotation,omitempty"`
+	Mentions     []MentionBrief     `json:"mentions"`
+	Reactions    ReactionCounts     `json:"reactions"`
+	MyReactions  []string           `json:"my_reactions"`
+	Resolved     bool               `json:"resolved"`
+	ResolvedAt   *time.Time         `json:"resolved_at,omitempty"`
+	ResolvedBy   *int               `json:"resolved_by,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// CommentPage страница комментариев верхнего уровня или ответов
+type CommentPage struct {
+	Items      []CommentResponse `json:"comments"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor возвращается, если курсор ленты не удалось разобрать
+var ErrInvalidCursor = errors.New("некорректный курсор")
+
+// FeedCursor позиция в ленте для keyset-пагинации.
+// Хранит значения сортировки последнего поста на странице, чтобы следующая
+// страница начиналась строго после него независимо от новых публикаций.
+type FeedCursor struct {
+	SortBy     string    `json:"s"`
+	SortOrder  string    `json:"o"`
+	Period     string    `json:"p,omitempty"`
+	CreatedAt  time.Time `json:"c,omitempty"`
+	LikesCount int       `json:"l,omitempty"`
+	Score      float64   `json:"sc,omitempty"`
+	ID         int       `json:"i"`
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c FeedCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeFeedCursor разбирает строку курсора, полученную от клиента
+func DecodeFeedCursor(value string) (FeedCursor, error) {
+	var cursor FeedCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// CommentCursor позиция в списке комментариев, отсортированном по (created_at, id)
+type CommentCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int       `json:"i"`
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c CommentCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCommentCursor разбирает строку курсора комментариев, полученную от клиента
+func DecodeCommentCursor(value string) (CommentCursor, error) {
+	var cursor CommentCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// MessageCursor позиция в списке сообщений, отсортированном по id от новых к старым
+type MessageCursor struct {
+	ID int `json:"i"`
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c MessageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeMessageCursor разбирает строку курсора сообщений, полученную от клиента
+func DecodeMessageCursor(value string) (MessageCursor, error) {
+	var cursor MessageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
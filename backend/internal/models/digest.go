@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidUnsubscribeToken возвращается для поддельной или поврежденной ссылки отписки
+var ErrInvalidUnsubscribeToken = errors.New("недействительная ссылка отписки")
+
+// Периодичность email-дайджеста
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+	DigestOff    = "off"
+)
+
+// DigestRecipient пользователь, которому пора отправить дайджест
+type DigestRecipient struct {
+	ID        int        `db:"id"`
+	Email     string     `db:"email"`
+	Username  string     `db:"username"`
+	Nickname  string     `db:"nickname"`
+	Frequency string     `db:"digest_frequency"`
+	SentAt    *time.Time `db:"digest_sent_at"` // Время предыдущего дайджеста, NULL для первого
+}
+
+// DigestPost новая работа дизайнера, на которого подписан получатель
+type DigestPost struct {
+	ID             int    `db:"id"`
+	Title          string `db:"title"`
+	MediaType      string `db:"media_type"`
+	MediaPath      string `db:"media_path"`
+	LikesCount     int    `db:"likes_count"`
+	AuthorUsername string `db:"author_username"`
+	AuthorNickname string `db:"author_nickname"`
+}
+
+// UnsubscribeResponse модель ответа на отписку от дайджеста
+type UnsubscribeResponse struct {
+	Message string `json:"message"`
+}
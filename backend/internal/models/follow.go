@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrSelfFollow возвращается при попытке подписаться на самого себя
+var ErrSelfFollow = errors.New("нельзя подписаться на себя")
+
+// FollowUser пользователь из списка подписчиков или подписок
+type FollowUser struct {
+	UserID         int       `db:"user_id"`
+	Username       string    `db:"username"`
+	Nickname       string    `db:"nickname"`
+	Avatar         *string   `db:"avatar"`
+	FollowersCount int       `db:"followers_count"`
+	IsFollowing    bool      `db:"is_following"` // Подписан ли на него текущий пользователь
+	FollowedAt     time.Time `db:"followed_at"`
+}
+
+// FollowedCategory категория из списка подписок пользователя
+type FollowedCategory struct {
+	Category
+	FollowedAt time.Time `db:"followed_at"`
+}
+
+// FollowFilter параметры пагинации списков подписок
+type FollowFilter struct {
+	Page    int `form:"page" binding:"omitempty,min=1"`
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// FollowUserResponse модель ответа с пользователем из списка подписок
+type FollowUserResponse struct {
+	User           UserBrief `json:"user"`
+	FollowersCount int       `json:"followers_count"`
+	IsFollowing    bool      `json:"is_following"`
+	FollowedAt     time.Time `json:"followed_at"`
+}
+
+// FollowUsersResponse модель ответа со списком подписчиков или подписок
+type FollowUsersResponse struct {
+	Users      []FollowUserResponse `json:"users"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+// FollowedCategoryResponse модель ответа с категорией из списка подписок
+type FollowedCategoryResponse struct {
+	Category   Category  `json:"category"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowedCategoriesResponse модель ответа со списком категорий, на которые подписан пользователь
+type FollowedCategoriesResponse struct {
+	Categories []FollowedCategoryResponse `json:"categories"`
+	Pagination Pagination                 `json:"pagination"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "errors"
+
+// ErrUnknownLicense возвращается, если код лицензии не входит в список поддерживаемых
+var ErrUnknownLicense = errors.New("неизвестная лицензия")
+
+// ErrInvalidLink возвращается, если ссылка на источник или автора не является http(s) адресом
+var ErrInvalidLink = errors.New("некорректная ссылка")
+
+// DefaultLicense лицензия поста, если автор не выбрал другую
+const DefaultLicense = "all-rights-reserved"
+
+// License описывает условия повторного использования работы
+type License struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Licenses список поддерживаемых лицензий в порядке отображения.
+// Коды совпадают со списком oneof в PostCreate, PostUpdate и PostFilter
+var Licenses = []License{
+	{Code: "all-rights-reserved", Title: "Все права защищены"},
+	{Code: "cc-by", Title: "CC BY 4.0", URL: "https://creativecommons.org/licenses/by/4.0/"},
+	{Code: "cc-by-sa", Title: "CC BY-SA 4.0", URL: "https://creativecommons.org/licenses/by-sa/4.0/"},
+	{Code: "cc-by-nd", Title: "CC BY-ND 4.0", URL: "https://creativecommons.org/licenses/by-nd/4.0/"},
+	{Code: "cc-by-nc", Title: "CC BY-NC 4.0", URL: "https://creativecommons.org/licenses/by-nc/4.0/"},
+	{Code: "cc-by-nc-sa", Title: "CC BY-NC-SA 4.0", URL: "https://creativecommons.org/licenses/by-nc-sa/4.0/"},
+	{Code: "cc-by-nc-nd", Title: "CC BY-NC-ND 4.0", URL: "https://creativecommons.org/licenses/by-nc-nd/4.0/"},
+	{Code: "cc0", Title: "CC0 1.0", URL: "https://creativecommons.org/publicdomain/zero/1.0/"},
+}
+
+// LookupLicense возвращает лицензию по коду
+func LookupLicense(code string) (License, bool) {
+	for _, license := range Licenses {
+		if license.Code == code {
+			return license, true
+		}
+	}
+
+	return License{}, false
+}
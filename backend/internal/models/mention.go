@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Mention упоминание пользователя в описании поста или в комментарии
+type Mention struct {
+	ID              int        `json:"id" db:"id"`
+	PostID          int        `json:"post_id" db:"post_id"`
+	CommentID       *int       `json:"comment_id,omitempty" db:"comment_id"` // nil для упоминания в описании поста
+	AuthorID        int        `json:"author_id" db:"author_id"`
+	MentionedUserID int        `json:"mentioned_user_id" db:"mentioned_user_id"`
+	Offset          int        `json:"offset" db:"text_offset"` // Позиция "@" в символах Unicode
+	Length          int        `json:"length" db:"text_length"` // Длина упоминания вместе с "@"
+	NotifiedAt      *time.Time `json:"notified_at,omitempty" db:"notified_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MentionDetails упоминание вместе с данными упомянутого пользователя
+type MentionDetails struct {
+	Mention
+	Username string `db:"username"`
+	Nickname string `db:"nickname"`
+}
+
+// MentionBrief упоминание для отрисовки ссылки на профиль в тексте
+type MentionBrief struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+// MentionList список упоминаний, выбирается из базы одним JSON-массивом
+type MentionList []MentionBrief
+
+// Value сериализует список для записи в базу
+func (l MentionList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan восстанавливает список из JSON
+func (l *MentionList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for mentions: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
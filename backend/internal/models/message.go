@@ -0,0 +1,146 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSelfMessage возвращается при попытке написать самому себе
+	ErrSelfMessage = errors.New("нельзя написать себе")
+	// ErrMessagesRestricted возвращается, если получатель не принимает сообщения от отправителя
+	ErrMessagesRestricted = errors.New("пользователь ограничил личные сообщения")
+	// ErrEmptyMessage возвращается для сообщения из одних пробелов
+	ErrEmptyMessage = errors.New("пустое сообщение")
+	// ErrNotMessageRequest возвращается при попытке принять или отклонить диалог, который не является запросом
+	ErrNotMessageRequest = errors.New("диалог не является запросом на переписку")
+)
+
+// Кто может писать пользователю
+const (
+	DMPolicyEveryone  = "everyone"  // Все; сообщения незнакомых попадают в запросы
+	DMPolicyFollowing = "following" // Только пользователи, на которых он подписан
+	DMPolicyNobody    = "nobody"    // Никто не может начать новый диалог
+)
+
+// Состояние диалога для участника
+const (
+	ConversationStatusAccepted = "accepted" // Диалог во входящих
+	ConversationStatusRequest  = "request"  // Сообщение от незнакомого пользователя ждет решения
+	ConversationStatusDeclined = "declined" // Запрос отклонен, новые сообщения не принимаются
+)
+
+// ConversationMember состояние диалога для одного участника
+type ConversationMember struct {
+	ConversationID    int        `db:"conversation_id"`
+	UserID            int        `db:"user_id"`
+	Status            string     `db:"status"`
+	LastReadMessageID int        `db:"last_read_message_id"`
+	LastReadAt        *time.Time `db:"last_read_at"`
+}
+
+// ConversationDetails диалог с точки зрения участника: собеседник, последнее сообщение и непрочитанные
+type ConversationDetails struct {
+	ID                    int        `db:"id"`
+	Status                string     `db:"status"`
+	LastReadMessageID     int        `db:"last_read_message_id"`
+	PeerID                int        `db:"peer_id"`
+	PeerUsername          string     `db:"peer_username"`
+	PeerNickname          string     `db:"peer_nickname"`
+	PeerAvatar            string     `db:"peer_avatar"`
+	PeerLastReadMessageID int        `db:"peer_last_read_message_id"`
+	PeerLastReadAt        *time.Time `db:"peer_last_read_at"`
+	LastMessageID         *int       `db:"last_message_id"`
+	LastMessageSenderID   *int       `db:"last_message_sender_id"`
+	LastMessageBody       *string    `db:"last_message_body"`
+	LastMessageCreatedAt  *time.Time `db:"last_message_created_at"`
+	UnreadCount           int        `db:"unread_count"`
+	CreatedAt             time.Time  `db:"created_at"`
+}
+
+// Message личное сообщение
+type Message struct {
+	ID             int       `json:"id" db:"id"`
+	ConversationID int       `json:"conversation_id" db:"conversation_id"`
+	SenderID       int       `json:"sender_id" db:"sender_id"`
+	Body           string    `json:"body" db:"body"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// MessageCreate модель для отправки сообщения
+type MessageCreate struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// MessageFilter параметры выборки сообщений диалога, новые сверху
+type MessageFilter struct {
+	Query   string `form:"q"` // Поиск по тексту сообщений
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Cursor  string `form:"cursor"` // Курсор следующей страницы (next_cursor из предыдущего ответа)
+}
+
+// ConversationFilter параметры пагинации списка диалогов
+type ConversationFilter struct {
+	Page    int `form:"page" binding:"omitempty,min=1"`
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// MessageSettings настройки личных сообщений пользователя
+type MessageSettings struct {
+	DMPolicy string `json:"dm_policy" binding:"required,oneof=everyone following nobody"`
+}
+
+// MessageResponse модель ответа с сообщением
+type MessageResponse struct {
+	ID             int       `json:"id"`
+	ConversationID int       `json:"conversation_id"`
+	SenderID       int       `json:"sender_id"`
+	Body           string    `json:"body"`
+	Read           bool      `json:"read"` // Прочитано ли сообщение получателем
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// MessagePage страница сообщений диалога
+type MessagePage struct {
+	Items      []MessageResponse `json:"messages"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// ConversationResponse модель ответа с диалогом
+type ConversationResponse struct {
+	ID          int              `json:"id"`
+	User        UserBrief        `json:"user"`   // Собеседник
+	Status      string           `json:"status"` // accepted, request или declined
+	LastMessage *MessageResponse `json:"last_message,omitempty"`
+	UnreadCount int              `json:"unread_count"`
+	PeerReadAt  *time.Time       `json:"peer_read_at,omitempty"` // Когда собеседник последний раз читал диалог
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// ConversationsResponse модель ответа со списком диалогов
+type ConversationsResponse struct {
+	Conversations []ConversationResponse `json:"conversations"`
+	Pagination    Pagination             `json:"pagination"`
+}
+
+// MessageEvent новое сообщение в потоке реального времени
+type MessageEvent struct {
+	Message MessageResponse `json:"message"`
+	Status  string          `json:"status"` // Состояние диалога у получателя события
+}
+
+// MessageRef ссылка на новое сообщение, которая передается через шину событий.
+// Подписчик загружает само сообщение из базы, поэтому длина текста не ограничена
+// размером уведомления шины
+type MessageRef struct {
+	MessageID      int `json:"message_id"`
+	ConversationID int `json:"conversation_id"`
+}
+
+// MessagesReadEvent собеседник прочитал сообщения диалога
+type MessagesReadEvent struct {
+	ConversationID    int       `json:"conversation_id"`
+	UserID            int       `json:"user_id"`
+	LastReadMessageID int       `json:"last_read_message_id"`
+	ReadAt            time.Time `json:"read_at"`
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownNotificationType возвращается при настройке несуществующего типа уведомлений
+var ErrUnknownNotificationType = errors.New("неизвестный тип уведомлений")
+
+// Типы уведомлений
+const (
+	NotificationTypePostApproved = "post_approved"
+	NotificationTypePostRejected = "post_rejected"
+	NotificationTypeLike         = "like"
+	NotificationTypeComment      = "comment"
+	NotificationTypeReply        = "reply"
+	NotificationTypeMention      = "mention"
+	NotificationTypeFollow       = "follow"
+	NotificationTypeWarning      = "moderation_warning" // Предупреждение модератора, не отключается в настройках
+)
+
+// NotificationTypes все типы уведомлений в порядке вывода в настройках
+var NotificationTypes = []string{
+	NotificationTypePostApproved,
+	NotificationTypePostRejected,
+	NotificationTypeLike,
+	NotificationTypeComment,
+	NotificationTypeReply,
+	NotificationTypeMention,
+	NotificationTypeFollow,
+}
+
+// Каналы доставки уведомлений. Канал email включает in_app: событие появляется
+// в центре уведомлений и попадает в email-дайджест. При off уведомление не создается
+const (
+	NotificationChannelInApp = "in_app"
+	NotificationChannelEmail = "email"
+	NotificationChannelOff   = "off"
+)
+
+// DefaultNotificationChannel канал для типов, которые пользователь не настраивал
+const DefaultNotificationChannel = NotificationChannelEmail
+
+// Notification уведомление пользователя о событии
+type Notification struct {
+	ID           int        `json:"id" db:"id"`
+	UserID       int        `json:"user_id" db:"user_id"`   // Получатель
+	ActorID      *int       `json:"actor_id" db:"actor_id"` // Последний пользователь, вызвавший событие
+	Type         string     `json:"type" db:"type"`
+	PostID       *int       `json:"post_id,omitempty" db:"post_id"`
+	CommentID    *int       `json:"comment_id,omitempty" db:"comment_id"`
+	RejectReason *string    `json:"reject_reason,omitempty" db:"reject_reason"`
+	GroupKey     *string    `json:"-" db:"group_key"` // События с одинаковым ключом объединяются, пока уведомление не прочитано
+	ReadAt       *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NotificationDetails уведомление вместе с участниками события и заголовком поста
+type NotificationDetails struct {
+	Notification
+	ActorsCount int       `db:"actors_count"`
+	Actors      ActorList `db:"actors"` // Несколько последних участников, новые первыми
+	PostTitle   *string   `db:"post_title"`
+}
+
+// ActorList список участников события, выбирается из базы одним JSON-массивом
+type ActorList []UserBrief
+
+// Value сериализует список для записи в базу
+func (l ActorList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan восстанавливает список из JSON
+func (l *ActorList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for actors: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+// NotificationFilter параметры выборки уведомлений
+type NotificationFilter struct {
+	Unread  bool `form:"unread"` // Только непрочитанные
+	Page    int  `form:"page" binding:"omitempty,min=1"`
+	PerPage int  `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// NotificationRead модель для отметки уведомлений прочитанными. Пустой список отмечает все
+type NotificationRead struct {
+	IDs []int `json:"ids"`
+}
+
+// NotificationPost пост, к которому относится уведомление
+type NotificationPost struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// NotificationResponse модель ответа с уведомлением. Сгруппированные события
+// ("12 человек лайкнули ваш пост") приходят одним уведомлением с actors_count
+type NotificationResponse struct {
+	ID           int               `json:"id"`
+	Type         string            `json:"type"`
+	Actors       []UserBrief       `json:"actors"`
+	ActorsCount  int               `json:"actors_count"`
+	Post         *NotificationPost `json:"post,omitempty"`
+	CommentID    *int              `json:"comment_id,omitempty"`
+	RejectReason *string           `json:"reject_reason,omitempty"`
+	Read         bool              `json:"read"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// NotificationsResponse модель ответа со списком уведомлений
+type NotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+	Pagination    Pagination             `json:"pagination"`
+}
+
+// UnreadCountResponse модель ответа со счетчиком непрочитанных уведомлений
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// NotificationPreference канал доставки для одного типа уведомлений
+type NotificationPreference struct {
+	Type    string `json:"type" db:"type"`
+	Channel string `json:"channel" db:"channel"`
+}
+
+// NotificationPreferences настройки уведомлений пользователя
+type NotificationPreferences struct {
+	Types  []NotificationPreference `json:"types"`
+	Digest string                   `json:"digest"` // Периодичность email-дайджеста
+}
+
+// NotificationPreferencesUpdate модель для изменения настроек уведомлений.
+// Не указанные типы и периодичность дайджеста не меняются
+type NotificationPreferencesUpdate struct {
+	Types  map[string]string `json:"types" binding:"omitempty,dive,oneof=in_app email off"`
+	Digest *string           `json:"digest" binding:"omitempty,oneof=daily weekly off"`
+}
@@ -1,21 +1,74 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPostStatus возвращается, когда действие недоступно для поста в его текущем статусе
+var ErrInvalidPostStatus = errors.New("недопустимый статус поста")
 
 // Post представляет модель поста
 type Post struct {
-	ID           int       `json:"id" db:"id"`
-	Title        string    `json:"title" db:"title"`
-	Description  string    `json:"description" db:"description"`
-	MediaType    string    `json:"media_type" db:"media_type"` // "image" или "video"
-	MediaPath    string    `json:"media_path" db:"media_path"`
-	UserID       int       `json:"user_id" db:"user_id"`
-	CategoryID   int       `json:"category_id" db:"category_id"`
-	Status       string    `json:"status" db:"status"` // "pending", "approved", "rejected"
-	RejectReason *string   `json:"reject_reason,omitempty" db:"reject_reason"`
-	LikesCount   int       `json:"likes_count" db:"likes_count"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           int        `json:"id" db:"id"`
+	Title        string     `json:"title" db:"title"`
+	Description  string     `json:"description" db:"description"`
+	MediaType    string     `json:"media_type" db:"media_type"` // "image" или "video"
+	MediaPath    string     `json:"media_path" db:"media_path"`
+	UserID       int        `json:"user_id" db:"user_id"`
+	CategoryID   int        `json:"category_id" db:"category_id"`
+	Status       string     `json:"status" db:"status"` // "draft", "pending", "scheduled", "approved", "rejected", "hidden"
+	RejectReason *string    `json:"reject_reason,omitempty" db:"reject_reason"`
+	LikesCount   int        `json:"likes_count" db:"likes_count"`
+	ViewsCount   int        `json:"views_count" db:"views_count"`
+	PublishAt    *time.Time `json:"publish_at,omitempty" db:"publish_at"` // Запланированное время публикации
+	License      string     `json:"license" db:"license"`
+	SourceURL    *string    `json:"source_url,omitempty" db:"source_url"` // Ссылка на оригинал работы
+	CreditURL    *string    `json:"credit_url,omitempty" db:"credit_url"` // Ссылка на автора или правообладателя
+	ToolIDs      []int      `json:"-" db:"-"`                             // Инструменты для сохранения, nil — не менять
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // Время перемещения в корзину
+	DeletedBy    *int       `json:"deleted_by,omitempty" db:"deleted_by"`
+	PublishedAt  *time.Time `json:"published_at,omitempty" db:"published_at"` // Время первой публикации
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// FeedTime возвращает время, по которому пост сортируется в лентах:
+// время публикации, а для неопубликованного поста — время создания
+func (p Post) FeedTime() time.Time {
+	if p.PublishedAt != nil {
+		return *p.PublishedAt
+	}
+	return p.CreatedAt
+}
+
+// PostDetails пост вместе с данными автора, категории и отметкой лайка текущего пользователя.
+// Заполняется репозиторием одним запросом, чтобы не загружать связанные сущности по одной
+type PostDetails struct {
+	Post
+	AuthorUsername string         `db:"author_username"`
+	AuthorNickname string         `db:"author_nickname"`
+	AuthorAvatar   *string        `db:"author_avatar"`
+	CategoryName   string         `db:"category_name"`
+	CategorySlug   string         `db:"category_slug"`
+	IsLiked        bool           `db:"is_liked"`
+	SortScore      float64        `db:"sort_score"` // Оценка для сортировки trending, нужна для курсора
+	Coauthors      CoauthorList   `db:"coauthors"`  // Принявшие приглашение соавторы
+	Tools          ToolList       `db:"tools"`
+	Mentions       MentionList    `db:"mentions"` // Упоминания пользователей в описании
+	Reactions      ReactionCounts `db:"reaction_counts"`
+	MyReactions    ReactionList   `db:"viewer_reactions"` // Реакции текущего пользователя
+}
+
+// RelatedCandidate пост-кандидат в похожие с признаками для ранжирования
+type RelatedCandidate struct {
+	PostID      int       `db:"id"`
+	UserID      int       `db:"user_id"`
+	CategoryID  int       `db:"category_id"`
+	LikesCount  int       `db:"likes_count"`
+	CoLikes     int       `db:"co_likes"` // Сколько пользователей лайкнули и исходный пост, и этот
+	PublishedAt time.Time `db:"published_at"`
 }
 
 // PostCreate модель для создания поста
@@ -24,30 +77,52 @@ type PostCreate struct {
 	Description string `json:"description" binding:"required,min=3,max=5000"`
 	CategoryID  int    `json:"category_id" binding:"required"`
 	// Media будет обрабатываться отдельно через multipart/form-data
+	Draft     bool       `json:"draft"`      // Сохранить как черновик без отправки на модерацию
+	PublishAt *time.Time `json:"publish_at"` // Опубликовать не раньше указанного времени
+	License   string     `json:"license" binding:"omitempty,oneof=all-rights-reserved cc-by cc-by-sa cc-by-nd cc-by-nc cc-by-nc-sa cc-by-nc-nd cc0"`
+	SourceURL string     `json:"source_url"`
+	CreditURL string     `json:"credit_url"`
+	ToolIDs   []int      `json:"tool_ids"`
 }
 
 // PostUpdate модель для обновления поста
 type PostUpdate struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	CategoryID  int    `json:"category_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	CategoryID  int        `json:"category_id"`
+	PublishAt   *time.Time `json:"publish_at"`
+	License     string     `json:"license" binding:"omitempty,oneof=all-rights-reserved cc-by cc-by-sa cc-by-nd cc-by-nc cc-by-nc-sa cc-by-nc-nd cc0"`
+	SourceURL   *string    `json:"source_url"` // Пустая строка убирает ссылку
+	CreditURL   *string    `json:"credit_url"` // Пустая строка убирает ссылку
+	ToolIDs     []int      `json:"tool_ids"`   // Если передан, полностью заменяет список инструментов
 }
 
 // PostResponse модель ответа с информацией о посте
 type PostResponse struct {
-	ID           int       `json:"id"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	MediaType    string    `json:"media_type"`
-	MediaURL     string    `json:"media_url"`
-	Author       UserBrief `json:"user"`
-	Category     Category  `json:"category"`
-	Status       string    `json:"status"`
-	RejectReason *string   `json:"reject_reason,omitempty"`
-	LikesCount   int       `json:"likes_count"`
-	IsLiked      bool      `json:"is_liked"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           int             `json:"id"`
+	Title        string          `json:"title"`
+	Description  string          `json:"description"`
+	MediaType    string          `json:"media_type"`
+	MediaURL     string          `json:"media_url"`
+	Author       UserBrief       `json:"user"`
+	Coauthors    []CoauthorBrief `json:"coauthors"`
+	License      License         `json:"license"`
+	SourceURL    string          `json:"source_url,omitempty"`
+	CreditURL    string          `json:"credit_url,omitempty"`
+	Tools        []ToolBrief     `json:"tools"`
+	Mentions     []MentionBrief  `json:"mentions"`
+	Category     Category        `json:"category"`
+	Status       string          `json:"status"`
+	RejectReason *string         `json:"reject_reason,omitempty"`
+	LikesCount   int             `json:"likes_count"`
+	ViewsCount   int             `json:"views_count"`
+	IsLiked      bool            `json:"is_liked"`
+	Reactions    ReactionCounts  `json:"reactions"`
+	MyReactions  []string        `json:"my_reactions"`
+	PublishAt    *time.Time      `json:"publish_at,omitempty"`
+	PublishedAt  *time.Time      `json:"published_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
 }
 
 // UserBrief краткая информация о пользователе для включения в ответ о посте
@@ -70,16 +145,53 @@ type PostFilter struct {
 	UserID      int    `form:"user_id"`
 	SearchQuery string `form:"q"`
 	Status      string `form:"status"`
-	SortBy      string `form:"sort_by" binding:"omitempty,oneof=date popularity"`
+	ToolID      int    `form:"tool_id"`
+	License     string `form:"license" binding:"omitempty,oneof=all-rights-reserved cc-by cc-by-sa cc-by-nd cc-by-nc cc-by-nc-sa cc-by-nc-nd cc0"`
+	SortBy      string `form:"sort_by" binding:"omitempty,oneof=date popularity trending"`
+	Period      string `form:"period" binding:"omitempty,oneof=day week month"` // Окно для сортировки trending
 	SortOrder   string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 	Page        int    `form:"page" binding:"omitempty,min=1"`
 	PerPage     int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Cursor      string `form:"cursor"` // Если указан, используется keyset-пагинация вместо page
+	ViewerID    int    `form:"-"`      // ID текущего пользователя для отметки is_liked, 0 для гостя
+	HideMuted   bool   `form:"-"`      // Скрыть посты авторов, заглушенных текущим пользователем
+}
+
+// NormalizedSort возвращает поле и направление сортировки с учетом значений по умолчанию
+func (f PostFilter) NormalizedSort() (string, string) {
+	sortBy := "date"
+	if f.SortBy == "popularity" || f.SortBy == "trending" {
+		sortBy = f.SortBy
+	}
+
+	sortOrder := "desc"
+	if strings.ToLower(f.SortOrder) == "asc" {
+		sortOrder = "asc"
+	}
+
+	return sortBy, sortOrder
+}
+
+// NewFeedCursor создает курсор, указывающий на позицию сразу после поста
+func NewFeedCursor(post PostDetails, filter PostFilter) FeedCursor {
+	sortBy, sortOrder := filter.NormalizedSort()
+
+	return FeedCursor{
+		SortBy:     sortBy,
+		SortOrder:  sortOrder,
+		Period:     filter.Period,
+		CreatedAt:  post.FeedTime(),
+		LikesCount: post.LikesCount,
+		Score:      post.SortScore,
+		ID:         post.ID,
+	}
 }
 
 // FeedResponse модель ответа для ленты постов
 type FeedResponse struct {
 	Items      []PostResponse `json:"posts"`
 	Pagination Pagination     `json:"pagination"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Курсор следующей страницы для бесконечной прокрутки
 }
 
 // Pagination модель для информации о пагинации
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownReaction возвращается, если тип реакции не входит в настроенный набор
+var ErrUnknownReaction = errors.New("неизвестная реакция")
+
+// Объекты, на которые можно поставить реакцию
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+// ReactionUser пользователь, поставивший реакцию
+type ReactionUser struct {
+	UserID    int       `db:"user_id"`
+	Username  string    `db:"username"`
+	Nickname  string    `db:"nickname"`
+	Avatar    *string   `db:"avatar"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ViewerReaction реакция текущего пользователя на комментарий
+type ViewerReaction struct {
+	TargetID int    `db:"target_id"`
+	Type     string `db:"type"`
+}
+
+// ReactionCounts счетчики реакций по типам, хранятся в JSONB-колонке
+type ReactionCounts map[string]int
+
+// Value сериализует счетчики для записи в базу
+func (c ReactionCounts) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan восстанавливает счетчики из JSONB
+func (c *ReactionCounts) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for reaction counts: %T", src)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// ReactionList список типов реакций, выбирается из базы одним JSON-массивом
+type ReactionList []string
+
+// Value сериализует список для записи в базу
+func (l ReactionList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan восстанавливает список из JSON
+func (l *ReactionList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for reactions: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+// ReactionUserResponse модель ответа с пользователем, поставившим реакцию
+type ReactionUserResponse struct {
+	User      UserBrief `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReactionUsersResponse модель ответа со списком отреагировавших пользователей
+type ReactionUsersResponse struct {
+	Type       string                 `json:"type"`
+	Users      []ReactionUserResponse `json:"users"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// ReactionUsersFilter параметры пагинации списка отреагировавших
+type ReactionUsersFilter struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PerPage  int `form:"per_page" binding:"omitempty,min=1,max=100"`
+	ViewerID int `form:"-"` // Текущий пользователь: от него скрываются пользователи, связанные с ним блокировкой
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSelfReport возвращается при жалобе на собственный пост или комментарий
+var ErrSelfReport = errors.New("нельзя пожаловаться на свой контент")
+
+// Объекты жалоб
+const (
+	ReportTargetPost    = "post"
+	ReportTargetComment = "comment"
+)
+
+// Статусы жалоб
+const (
+	ReportStatusOpen     = "open"
+	ReportStatusResolved = "resolved"
+)
+
+// Решения модератора по жалобам на объект
+const (
+	ReportActionDismiss = "dismiss" // Жалобы необоснованны, скрытый объект снова показывается
+	ReportActionHide    = "hide"    // Объект скрывается, но остается у автора
+	ReportActionDelete  = "delete"  // Объект перемещается в корзину
+	ReportActionWarn    = "warn"    // Автор получает предупреждение, объект не меняется
+)
+
+// Report жалоба пользователя на пост или комментарий
+type Report struct {
+	ID         int        `db:"id"`
+	ReporterID int        `db:"reporter_id"`
+	TargetType string     `db:"target_type"`
+	TargetID   int        `db:"target_id"`
+	Reason     string     `db:"reason"`
+	Details    *string    `db:"details"`
+	Status     string     `db:"status"`
+	Resolution *string    `db:"resolution"`
+	ResolvedBy *int       `db:"resolved_by"`
+	ResolvedAt *time.Time `db:"resolved_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// ReportDetails жалоба вместе с данными пожаловавшегося пользователя
+type ReportDetails struct {
+	Report
+	ReporterUsername string  `db:"reporter_username"`
+	ReporterNickname string  `db:"reporter_nickname"`
+	ReporterAvatar   *string `db:"reporter_avatar"`
+}
+
+// ReportGroup жалобы на один объект, собранные для очереди модерации
+type ReportGroup struct {
+	TargetType      string     `db:"target_type"`
+	TargetID        int        `db:"target_id"`
+	PostID          *int       `db:"post_id"` // Пост объекта: сам пост или пост комментария
+	Preview         *string    `db:"preview"` // Заголовок поста или текст комментария
+	AuthorID        *int       `db:"author_id"`
+	AuthorUsername  *string    `db:"author_username"`
+	AuthorNickname  *string    `db:"author_nickname"`
+	AuthorAvatar    *string    `db:"author_avatar"`
+	Hidden          bool       `db:"hidden"`
+	ReportsCount    int        `db:"reports_count"`
+	Reasons         ReasonList `db:"reasons"`
+	FirstReportedAt time.Time  `db:"first_reported_at"`
+	LastReportedAt  time.Time  `db:"last_reported_at"`
+}
+
+// ReasonList причины жалоб на объект, выбирается из базы одним JSON-массивом
+type ReasonList []string
+
+// Scan восстанавливает список из JSON
+func (l *ReasonList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for reasons: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+// ReportCreate модель для создания жалобы
+type ReportCreate struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam harassment hate nudity violence copyright other"`
+	Details string `json:"details" binding:"max=1000"` // Необязательное пояснение
+}
+
+// ReportFilter параметры очереди жалоб
+type ReportFilter struct {
+	Status     string `form:"status" binding:"omitempty,oneof=open resolved"`     // По умолчанию open
+	TargetType string `form:"target_type" binding:"omitempty,oneof=post comment"` // Только посты или только комментарии
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	PerPage    int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// ReportResolve решение модератора по жалобам на объект
+type ReportResolve struct {
+	Action  string `json:"action" binding:"required,oneof=dismiss hide delete warn"`
+	Message string `json:"message" binding:"max=1000"` // Текст предупреждения автору для warn
+}
+
+// ReportGroupResponse модель ответа с жалобами на один объект
+type ReportGroupResponse struct {
+	TargetType      string     `json:"target_type"`
+	TargetID        int        `json:"target_id"`
+	PostID          *int       `json:"post_id,omitempty"`
+	Preview         string     `json:"preview"`
+	Author          *UserBrief `json:"author,omitempty"` // Не указан, если объект уже удален
+	Hidden          bool       `json:"hidden"`
+	ReportsCount    int        `json:"reports_count"`
+	Reasons         []string   `json:"reasons"`
+	FirstReportedAt time.Time  `json:"first_reported_at"`
+	LastReportedAt  time.Time  `json:"last_reported_at"`
+}
+
+// ReportGroupsResponse модель ответа с очередью жалоб
+type ReportGroupsResponse struct {
+	Reports    []ReportGroupResponse `json:"reports"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// ReportResponse модель ответа с отдельной жалобой
+type ReportResponse struct {
+	ID         int        `json:"id"`
+	Reporter   UserBrief  `json:"reporter"`
+	Reason     string     `json:"reason"`
+	Details    *string    `json:"details,omitempty"`
+	Status     string     `json:"status"`
+	Resolution *string    `json:"resolution,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FieldChange изменение одного поля поста
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// FieldChanges список изменений полей поста, хранится в JSONB
+type FieldChanges []FieldChange
+
+// Value сериализует изменения для записи в базу
+func (c FieldChanges) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan восстанавливает изменения из JSONB
+func (c *FieldChanges) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for field changes: %T", src)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Has проверяет, изменялось ли поле
+func (c FieldChanges) Has(field string) bool {
+	for _, change := range c {
+		if change.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// PostRevision запись истории правок поста
+type PostRevision struct {
+	ID             int          `db:"id"`
+	PostID         int          `db:"post_id"`
+	EditorID       int          `db:"editor_id"`
+	Changes        FieldChanges `db:"changes"`
+	PreviousStatus string       `db:"previous_status"`
+	Remoderated    bool         `db:"remoderated"`
+	CreatedAt      time.Time    `db:"created_at"`
+}
+
+// PostRevisionDetails правка вместе с данными редактора
+type PostRevisionDetails struct {
+	PostRevision
+	EditorUsername string  `db:"editor_username"`
+	EditorNickname string  `db:"editor_nickname"`
+	EditorAvatar   *string `db:"editor_avatar"`
+}
+
+// PostRevisionResponse модель ответа с информацией о правке поста
+type PostRevisionResponse struct {
+	ID             int           `json:"id"`
+	Editor         UserBrief     `json:"editor"`
+	Changes        []FieldChange `json:"changes"`
+	PreviousStatus string        `json:"previous_status"`
+	Remoderated    bool          `json:"remoderated"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
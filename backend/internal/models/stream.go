@@ -0,0 +1,47 @@
+package models
+
+import "errors"
+
+// ErrTooManyStreamPosts возвращается, если в потоке запрошено больше постов, чем разрешено
+var ErrTooManyStreamPosts = errors.New("слишком много постов в потоке")
+
+// События потока реального времени
+const (
+	StreamEventNotification = "notification"
+	StreamEventUnreadCount  = "unread_count"
+	StreamEventPostCounts   = "post_counts"
+	StreamEventModeration   = "moderation"
+	StreamEventMessage      = "message"
+	StreamEventMessagesRead = "messages_read"
+	StreamEventReport       = "report"
+)
+
+// PostCounts счетчики поста, которые обновляются у зрителей в реальном времени
+type PostCounts struct {
+	PostID        int `json:"post_id" db:"id"`
+	LikesCount    int `json:"likes_count" db:"likes_count"`
+	CommentsCount int `json:"comments_count" db:"comments_count"`
+}
+
+// NotificationEvent новое уведомление вместе с обновленным счетчиком непрочитанных
+type NotificationEvent struct {
+	Notification NotificationResponse `json:"notification"`
+	UnreadCount  int                  `json:"unread_count"`
+}
+
+// ModerationEvent изменение очереди модерации. Для постов, отправленных на модерацию
+// фоновой задачей, PostID не указан, а Count содержит их количество
+type ModerationEvent struct {
+	PostID *int   `json:"post_id,omitempty"`
+	Status string `json:"status"`
+	Count  int    `json:"count,omitempty"`
+}
+
+// ReportEvent изменение очереди жалоб: новая жалоба на объект или решение модератора
+type ReportEvent struct {
+	TargetType   string `json:"target_type"`
+	TargetID     int    `json:"target_id"`
+	Status       string `json:"status"`
+	ReportsCount int    `json:"reports_count,omitempty"` // Число открытых жалоб на объект
+	Hidden       bool   `json:"hidden"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownTool возвращается, если в посте указан инструмент не из каталога
+var ErrUnknownTool = errors.New("неизвестный инструмент")
+
+// Tool представляет инструмент из каталога (Figma, Blender, Photoshop...)
+type Tool struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Slug      string    `json:"slug" db:"slug"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ToolCreate модель для добавления инструмента в каталог
+type ToolCreate struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+// ToolUpdate модель для обновления инструмента
+type ToolUpdate struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+// ToolBrief краткая информация об инструменте для включения в ответ о посте
+type ToolBrief struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// ToolList список инструментов поста, выбирается из базы одним JSON-массивом
+type ToolList []ToolBrief
+
+// Value сериализует список для записи в базу
+func (l ToolList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan восстанавливает список из JSON
+func (l *ToolList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for tools: %T", src)
+	}
+
+	return json.Unmarshal(data, l)
+}
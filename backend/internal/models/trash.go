@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRestoreExpired возвращается при попытке восстановить запись, срок хранения которой в корзине истек
+var ErrRestoreExpired = errors.New("срок восстановления истек")
+
+// TrashItem удаленный пост или комментарий в корзине
+type TrashItem struct {
+	Type       string    `json:"type" db:"type"` // "post" или "comment"
+	ID         int       `json:"id" db:"id"`
+	PostID     int       `json:"post_id" db:"post_id"`
+	UserID     int       `json:"user_id" db:"user_id"`
+	Title      string    `json:"title" db:"title"` // Заголовок поста или текст комментария
+	DeletedAt  time.Time `json:"deleted_at" db:"deleted_at"`
+	DeletedBy  *int      `json:"deleted_by" db:"deleted_by"`
+	ExpiresAt  time.Time `json:"expires_at" db:"-"`  // Время окончательного удаления
+	CanRestore bool      `json:"can_restore" db:"-"` // Может ли текущий пользователь восстановить запись
+}
+
+// TrashFilter модель для фильтрации корзины
+type TrashFilter struct {
+	Type    string `form:"type" binding:"omitempty,oneof=post comment"`
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	UserID  int    `form:"-"` // Владелец записей, 0 для всей корзины
+}
+
+// TrashResponse модель ответа со списком корзины
+type TrashResponse struct {
+	Items      []TrashItem `json:"items"`
+	Pagination Pagination  `json:"pagination"`
+}
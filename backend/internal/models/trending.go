@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// TrendingPeriods окна, за которые считается активность для сортировки trending
+var TrendingPeriods = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// TrendingParams параметры расчета оценки трендовых постов
+type TrendingParams struct {
+	Period        string
+	Window        time.Duration
+	Gravity       float64
+	LikeWeight    float64
+	CommentWeight float64
+	ViewWeight    float64
+}
@@ -4,18 +4,23 @@ import "time"
 
 // User представляет модель пользователя
 type User struct {
-	ID           int       `json:"id" db:"id"`
-	Username     string    `json:"username" db:"username"`
-	Nickname     string    `json:"nickname" db:"nickname"`
-	Email        string    `json:"email" db:"email"`
-	Password     string    `json:"-" db:"password_hash"`
-	Avatar       *string   `json:"avatar" db:"avatar"`
-	Description  *string   `json:"description" db:"description"`
-	VkLink       *string   `json:"vk_link" db:"vk_link"`
-	TelegramLink *string   `json:"telegram_link" db:"telegram_link"`
-	Role         string    `json:"role" db:"role"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID              int        `json:"id" db:"id"`
+	Username        string     `json:"username" db:"username"`
+	Nickname        string     `json:"nickname" db:"nickname"`
+	Email           string     `json:"email" db:"email"`
+	Password        string     `json:"-" db:"password_hash"`
+	Avatar          *string    `json:"avatar" db:"avatar"`
+	Description     *string    `json:"description" db:"description"`
+	VkLink          *string    `json:"vk_link" db:"vk_link"`
+	TelegramLink    *string    `json:"telegram_link" db:"telegram_link"`
+	Role            string     `json:"role" db:"role"`
+	FollowersCount  int        `json:"followers_count" db:"followers_count"`
+	FollowingCount  int        `json:"following_count" db:"following_count"`
+	DigestFrequency string     `json:"-" db:"digest_frequency"`
+	DigestSentAt    *time.Time `json:"-" db:"digest_sent_at"`
+	DMPolicy        string     `json:"-" db:"dm_policy"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // UserSignUp модель для регистрации пользователя
@@ -44,14 +49,19 @@ type UserUpdate struct {
 
 // UserResponse модель ответа с информацией о пользователе
 type UserResponse struct {
-	ID           int       `json:"id"`
-	Username     string    `json:"username"`
-	Nickname     string    `json:"nickname"`
-	Email        string    `json:"email"`
-	Avatar       string    `json:"avatar"`
-	Description  string    `json:"description"`
-	VkLink       string    `json:"vk_link"`
-	TelegramLink string    `json:"telegram_link"`
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID             int       `json:"id"`
+	Username       string    `json:"username"`
+	Nickname       string    `json:"nickname"`
+	Email          string    `json:"email"`
+	Avatar         string    `json:"avatar"`
+	Description    string    `json:"description"`
+	VkLink         string    `json:"vk_link"`
+	TelegramLink   string    `json:"telegram_link"`
+	Role           string    `json:"role"`
+	FollowersCount int       `json:"followers_count"`
+	FollowingCount int       `json:"following_count"`
+	IsFollowing    bool      `json:"is_following"` // Подписан ли текущий пользователь
+	IsBlocked      bool      `json:"is_blocked"`   // Заблокирован ли текущим пользователем
+	IsMuted        bool      `json:"is_muted"`     // Заглушен ли текущим пользователем
+	CreatedAt      time.Time `json:"created_at"`
 }
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AnalyticsPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAnalyticsPostgres(db *sqlx.DB) *AnalyticsPostgres {
+	return &AnalyticsPostgres{db: db}
+}
+
+// AddViews добавляет накопленные просмотры к дневным счетчикам и счетчикам постов.
+// Просмотры удаленных к этому моменту постов пропускаются
+func (r *AnalyticsPostgres) AddViews(ctx context.Context, counts []models.PostViewCount) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dailyQuery := `
+		INSERT INTO post_daily_stats (post_id, day, views)
+		SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)
+		ON CONFLICT (post_id, day) DO UPDATE SET views = post_daily_stats.views + EXCLUDED.views
+	`
+
+	referrerQuery := `
+		INSERT INTO post_referrer_stats (post_id, day, referrer, views)
+		SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)
+		ON CONFLICT (post_id, day, referrer) DO UPDATE SET views = post_referrer_stats.views + EXCLUDED.views
+	`
+
+	postQuery := `UPDATE posts SET views_count = views_count + $1 WHERE id = $2`
+
+	for _, count := range counts {
+		if _, err := tx.ExecContext(ctx, dailyQuery, count.PostID, count.Day, count.Views); err != nil {
+			return fmt.Errorf("failed to add daily views: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, referrerQuery, count.PostID, count.Day, count.Referrer, count.Views); err != nil {
+			return fmt.Errorf("failed to add referrer views: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, postQuery, count.Views, count.PostID); err != nil {
+			return fmt.Errorf("failed to update views count: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit views: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostDailyStats получает просмотры, лайки и комментарии поста по дням начиная с from
+func (r *AnalyticsPostgres) GetPostDailyStats(ctx context.Context, postID int, from time.Time) ([]models.PostDailyStat, error) {
+	var stats []models.PostDailyStat
+
+	query := `
+		SELECT d::date AS day,
+			COALESCE(s.views, 0) AS views,
+			COALESCE(l.cnt, 0) AS likes,
+			COALESCE(cm.cnt, 0) AS comments
+		FROM generate_series($2::date, CURRENT_DATE, INTERVAL '1 day') d
+		LEFT JOIN post_daily_stats s ON s.post_id = $1 AND s.day = d::date
+		LEFT JOIN (
+			SELECT created_at::date AS day, COUNT(*) AS cnt FROM likes
+			WHERE post_id = $1 AND created_at >= $2::date
+			GROUP BY 1
+		) l ON l.day = d::date
+		LEFT JOIN (
+			SELECT created_at::date AS day, COUNT(*) AS cnt FROM comments
+			WHERE post_id = $1 AND created_at >= $2::date AND deleted_at IS NULL
+			GROUP BY 1
+		) cm ON cm.day = d::date
+		ORDER BY day
+	`
+
+	if err := r.db.SelectContext(ctx, &stats, query, postID, from); err != nil {
+		return nil, fmt.Errorf("failed to get post daily stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetPostTopReferrers получает источники с наибольшим числом просмотров поста начиная с from
+func (r *AnalyticsPostgres) GetPostTopReferrers(ctx context.Context, postID int, from time.Time, limit int) ([]models.ReferrerStat, error) {
+	var referrers []models.ReferrerStat
+
+	query := `
+		SELECT referrer, SUM(views) AS views
+		FROM post_referrer_stats
+		WHERE post_id = $1 AND day >= $2::date
+		GROUP BY referrer
+		ORDER BY views DESC, referrer
+		LIMIT $3
+	`
+
+	if err := r.db.SelectContext(ctx, &referrers, query, postID, from, limit); err != nil {
+		return nil, fmt.Errorf("failed to get post referrers: %w", err)
+	}
+
+	return referrers, nil
+}
+
+// GetCreatorSummary получает агрегированные показатели всех постов автора
+func (r *AnalyticsPostgres) GetCreatorSummary(ctx context.Context, userID int) (models.CreatorSummary, error) {
+	var summary models.CreatorSummary
+
+	query := `
+		SELECT
+			COUNT(*) AS posts,
+			COUNT(*) FILTER (WHERE status = 'approved') AS approved_posts,
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending_posts,
+			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected_posts,
+			COALESCE(SUM(views_count), 0) AS views,
+			COALESCE(SUM(likes_count), 0) AS likes,
+			(
+				SELECT COUNT(*) FROM comments c
+				JOIN posts cp ON cp.id = c.post_id
+				WHERE cp.user_id = $1 AND c.user_id <> $1 AND c.deleted_at IS NULL AND cp.deleted_at IS NULL
+			) AS comments,
+			(SELECT COUNT(*) FROM user_follows WHERE followee_id = $1) AS followers,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (moderated_at - submitted_at)))
+				FILTER (WHERE moderated_at >= submitted_at) AS median_moderation_seconds
+		FROM posts
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	if err := r.db.GetContext(ctx, &summary, query, userID); err != nil {
+		return models.CreatorSummary{}, fmt.Errorf("failed to get creator summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+// GetCreatorDailyStats получает активность вокруг работ автора по дням начиная с from
+func (r *AnalyticsPostgres) GetCreatorDailyStats(ctx context.Context, userID int, from time.Time) ([]models.CreatorDailyStat, error) {
+	var stats []models.CreatorDailyStat
+
+	query := `
+		SELECT d::date AS day,
+			COALESCE(v.cnt, 0) AS views,
+			COALESCE(l.cnt, 0) AS likes,
+			COALESCE(cm.cnt, 0) AS comments,
+			COALESCE(f.cnt, 0) AS new_followers
+		FROM generate_series($2::date, CURRENT_DATE, INTERVAL '1 day') d
+		LEFT JOIN (
+			SELECT s.day, SUM(s.views) AS cnt
+			FROM post_daily_stats s
+			JOIN posts p ON p.id = s.post_id
+			WHERE p.user_id = $1 AND s.day >= $2::date AND p.deleted_at IS NULL
+			GROUP BY s.day
+		) v ON v.day = d::date
+		LEFT JOIN (
+			SELECT l.created_at::date AS day, COUNT(*) AS cnt
+			FROM likes l
+			JOIN posts p ON p.id = l.post_id
+			WHERE p.user_id = $1 AND l.created_at >= $2::date AND p.deleted_at IS NULL
+			GROUP BY 1
+		) l ON l.day = d::date
+		LEFT JOIN (
+			SELECT c.created_at::date AS day, COUNT(*) AS cnt
+			FROM comments c
+			JOIN posts p ON p.id = c.post_id
+			WHERE p.user_id = $1 AND c.user_id <> $1 AND c.created_at >= $2::date
+				AND c.deleted_at IS NULL AND p.deleted_at IS NULL
+			GROUP BY 1
+		) cm ON cm.day = d::date
+		LEFT JOIN (
+			SELECT created_at::date AS day, COUNT(*) AS cnt
+			FROM user_follows
+			WHERE followee_id = $1 AND created_at >= $2::date
+			GROUP BY 1
+		) f ON f.day = d::date
+		ORDER BY day
+	`
+
+	if err := r.db.SelectContext(ctx, &stats, query, userID, from); err != nil {
+		return nil, fmt.Errorf("failed to get creator daily stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetCreatorTopPosts получает опубликованные посты автора с наибольшей активностью начиная с from
+func (r *AnalyticsPostgres) GetCreatorTopPosts(ctx context.Context, userID int, from time.Time, limit int) ([]models.TopPostStat, error) {
+	var posts []models.TopPostStat
+
+	query := `
+		SELECT * FROM (
+			SELECT p.id AS post_id, p.title,
+				COALESCE((SELECT SUM(s.views) FROM post_daily_stats s WHERE s.post_id = p.id AND s.day >= $2::date), 0) AS views,
+				(SELECT COUNT(*) FROM likes l WHERE l.post_id = p.id AND l.created_at >= $2::date) AS likes,
+				(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.created_at >= $2::date AND c.deleted_at IS NULL) AS comments
+			FROM posts p
+			WHERE p.user_id = $1 AND p.status = 'approved' AND p.deleted_at IS NULL
+		) stats
+		ORDER BY likes DESC, comments DESC, views DESC, post_id DESC
+		LIMIT $3
+	`
+
+	if err := r.db.SelectContext(ctx, &posts, query, userID, from, limit); err != nil {
+		return nil, fmt.Errorf("failed to get creator top posts: %w", err)
+	}
+
+	return posts, nil
+}
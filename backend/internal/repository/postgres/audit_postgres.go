@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAuditPostgres(db *sqlx.DB) *AuditPostgres {
+	return &AuditPostgres{db: db}
+}
+
+// insertModerationAction сохраняет запись журнала действий модераторов в транзакции
+// самого действия, чтобы решение модератора не осталось без записи в журнале
+func insertModerationAction(ctx context.Context, tx *sqlx.Tx, action models.ModerationAction) error {
+	query := `
+		INSERT INTO moderation_actions
+		(moderator_id, action, target_type, target_id, reason, previous_state, new_state, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		action.ModeratorID,
+		action.Action,
+		action.TargetType,
+		action.TargetID,
+		action.Reason,
+		action.PreviousState,
+		action.NewState,
+		action.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create moderation action: %w", err)
+	}
+
+	return nil
+}
+
+// execModeration выполняет изменяющий запрос модератора и, если запрос изменил хотя бы
+// одну строку, сохраняет запись журнала в той же транзакции. Без записи (action == nil)
+// запрос выполняется как обычно. Возвращает число измененных строк
+func execModeration(ctx context.Context, db *sqlx.DB, action *models.ModerationAction, query string, args ...interface{}) (int64, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if action != nil && affected > 0 {
+		if err := insertModerationAction(ctx, tx, *action); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return affected, nil
+}
+
+// GetAll получает страницу журнала действий модераторов с учетом фильтра, новые сверху
+func (r *AuditPostgres) GetAll(ctx context.Context, filter models.AuditFilter) ([]models.ModerationActionDetails, int, error) {
+	var conditions []string
+	var params []interface{}
+
+	if filter.ModeratorID != 0 {
+		conditions = append(conditions, fmt.Sprintf("a.moderator_id = $%d", len(params)+1))
+		params = append(params, filter.ModeratorID)
+	}
+
+	if filter.TargetType != "" {
+		conditions = append(conditions, fmt.Sprintf("a.target_type = $%d", len(params)+1))
+		params = append(params, filter.TargetType)
+	}
+
+	if filter.TargetID != 0 {
+		conditions = append(conditions, fmt.Sprintf("a.target_id = $%d", len(params)+1))
+		params = append(params, filter.TargetID)
+	}
+
+	if filter.Action != "" {
+		conditions = append(conditions, fmt.Sprintf("a.action = $%d", len(params)+1))
+		params = append(params, filter.Action)
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("a.created_at >= $%d", len(params)+1))
+		params = append(params, filter.From)
+	}
+
+	// Дата окончания включается в период целиком
+	if !filter.To.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("a.created_at < $%d", len(params)+1))
+		params = append(params, filter.To.AddDate(0, 0, 1))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM moderation_actions a" + where
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count moderation actions: %w", err)
+	}
+
+	query := `
+		SELECT a.id, a.moderator_id, a.action, a.target_type, a.target_id, a.reason,
+			a.previous_state, a.new_state, a.created_at,
+			u.username AS moderator_username, u.nickname AS moderator_nickname, u.avatar AS moderator_avatar
+		FROM moderation_actions a
+		LEFT JOIN users u ON u.id = a.moderator_id
+	` + where
+	query += fmt.Sprintf(" ORDER BY a.created_at DESC, a.id DESC LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
+	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	var actions []models.ModerationActionDetails
+	if err := r.db.SelectContext(ctx, &actions, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get moderation actions: %w", err)
+	}
+
+	return actions, total, nil
+}
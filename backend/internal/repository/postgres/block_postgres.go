@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BlockPostgres struct {
+	db *sqlx.DB
+}
+
+func NewBlockPostgres(db *sqlx.DB) *BlockPostgres {
+	return &BlockPostgres{db: db}
+}
+
+// Block блокирует пользователя и удаляет подписки между пользователями в обе стороны.
+// Возвращает false, если пользователь уже заблокирован
+func (r *BlockPostgres) Block(ctx context.Context, blockerID int, blockedID int, createdAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := tx.ExecContext(ctx, query, blockerID, blockedID, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to block user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Счетчики подписок обновляются триггером на user_follows
+	unfollowQuery := `
+		DELETE FROM user_follows
+		WHERE (follower_id = $1 AND followee_id = $2) OR (follower_id = $2 AND followee_id = $1)
+	`
+	if _, err := tx.ExecContext(ctx, unfollowQuery, blockerID, blockedID); err != nil {
+		return false, fmt.Errorf("failed to remove follows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Unblock снимает блокировку пользователя
+func (r *BlockPostgres) Unblock(ctx context.Context, blockerID int, blockedID int) error {
+	query := `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("block not found")
+	}
+
+	return nil
+}
+
+// Mute заглушает пользователя. Возвращает false, если пользователь уже заглушен
+func (r *BlockPostgres) Mute(ctx context.Context, muterID int, mutedID int, createdAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO user_mutes (muter_id, muted_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, muterID, mutedID, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mute user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Unmute снимает заглушение пользователя
+func (r *BlockPostgres) Unmute(ctx context.Context, muterID int, mutedID int) error {
+	query := `DELETE FROM user_mutes WHERE muter_id = $1 AND muted_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, muterID, mutedID)
+	if err != nil {
+		return fmt.Errorf("failed to unmute user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("mute not found")
+	}
+
+	return nil
+}
+
+// IsBlockedBetween проверяет, заблокировал ли кто-либо из двух пользователей другого
+func (r *BlockPostgres) IsBlockedBetween(ctx context.Context, userID int, otherID int) (bool, error) {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &exists, query, userID, otherID); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return exists, nil
+}
+
+// IsBlockedWithPost проверяет, связан ли пользователь блокировкой в любую сторону
+// с автором поста или с кем-либо из принявших приглашение соавторов
+func (r *BlockPostgres) IsBlockedWithPost(ctx context.Context, userID int, postID int) (bool, error) {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM (
+				SELECT user_id FROM posts WHERE id = $2
+				UNION
+				SELECT user_id FROM post_coauthors WHERE post_id = $2 AND status = 'accepted'
+			) credited
+			JOIN user_blocks ub
+				ON (ub.blocker_id = credited.user_id AND ub.blocked_id = $1)
+				OR (ub.blocker_id = $1 AND ub.blocked_id = credited.user_id)
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &exists, query, userID, postID); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetRelation возвращает, заблокировал и заглушил ли userID пользователя otherID
+func (r *BlockPostgres) GetRelation(ctx context.Context, userID int, otherID int) (bool, bool, error) {
+	var relation struct {
+		Blocked bool `db:"blocked"`
+		Muted   bool `db:"muted"`
+	}
+
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2) AS blocked,
+			EXISTS (SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = $2) AS muted
+	`
+
+	if err := r.db.GetContext(ctx, &relation, query, userID, otherID); err != nil {
+		return false, false, fmt.Errorf("failed to get relation: %w", err)
+	}
+
+	return relation.Blocked, relation.Muted, nil
+}
+
+// GetBlockedIDs получает ID пользователей, связанных с userID блокировкой в любую сторону
+func (r *BlockPostgres) GetBlockedIDs(ctx context.Context, userID int) ([]int, error) {
+	var ids []int
+
+	query := `
+		SELECT blocked_id FROM user_blocks WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM user_blocks WHERE blocked_id = $1
+	`
+
+	if err := r.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetMutedIDs получает ID пользователей, заглушенных userID
+func (r *BlockPostgres) GetMutedIDs(ctx context.Context, userID int) ([]int, error) {
+	var ids []int
+
+	query := `SELECT muted_id FROM user_mutes WHERE muter_id = $1`
+
+	if err := r.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get muted users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetBlocked получает страницу пользователей, заблокированных userID, новые сверху
+func (r *BlockPostgres) GetBlocked(ctx context.Context, userID int, limit, offset int) ([]models.RestrictedUser, int, error) {
+	return r.getRestricted(ctx, "user_blocks", "blocker_id", "blocked_id", userID, limit, offset)
+}
+
+// GetMuted получает страницу пользователей, заглушенных userID, новые сверху
+func (r *BlockPostgres) GetMuted(ctx context.Context, userID int, limit, offset int) ([]models.RestrictedUser, int, error) {
+	return r.getRestricted(ctx, "user_mutes", "muter_id", "muted_id", userID, limit, offset)
+}
+
+// getRestricted выбирает пользователей из таблицы table: ownerColumn задает сторону
+// пользователя userID, userColumn — сторону, пользователи которой попадают в список
+func (r *BlockPostgres) getRestricted(ctx context.Context, table, ownerColumn, userColumn string, userID, limit, offset int) ([]models.RestrictedUser, int, error) {
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = $1`, table, ownerColumn)
+	if err := r.db.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count restricted users: %w", err)
+	}
+
+	var users []models.RestrictedUser
+	query := fmt.Sprintf(`
+		SELECT u.id AS user_id, u.username, u.nickname, u.avatar, r.created_at
+		FROM %s r
+		JOIN users u ON u.id = r.%s
+		WHERE r.%s = $1
+		ORDER BY r.created_at DESC, u.id DESC
+		LIMIT $2 OFFSET $3
+	`, table, userColumn, ownerColumn)
+
+	if err := r.db.SelectContext(ctx, &users, query, userID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get restricted users: %w", err)
+	}
+
+	return users, total, nil
+}
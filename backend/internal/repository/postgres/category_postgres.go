@@ -17,10 +17,16 @@ func NewCategoryPostgres(db *sqlx.DB) *CategoryPostgres {
 	return &CategoryPostgres{db: db}
 }
 
-// Create создает новую категорию
-func (r *CategoryPostgres) Create(ctx context.Context, category models.Category) (int, error) {
+// Create создает новую категорию и сохраняет запись журнала модерации в той же транзакции
+func (r *CategoryPostgres) Create(ctx context.Context, category models.Category, action *models.ModerationAction) (int, error) {
 	var id int
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO categories 
 		(name, slug, created_at, updated_at) 
@@ -29,7 +35,7 @@ func (r *CategoryPostgres) Create(ctx context.Context, category models.Category)
 		RETURNING id
 	`
 
-	row := r.db.QueryRowContext(
+	row := tx.QueryRowContext(
 		ctx,
 		query,
 		category.Name,
@@ -42,6 +48,18 @@ func (r *CategoryPostgres) Create(ctx context.Context, category models.Category)
 		return 0, fmt.Errorf("failed to create category: %w", err)
 	}
 
+	// ID новой категории известен только после вставки
+	if action != nil {
+		action.TargetID = id
+		if err := insertModerationAction(ctx, tx, *action); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return id, nil
 }
 
@@ -50,7 +68,7 @@ func (r *CategoryPostgres) GetByID(ctx context.Context, id int) (models.Category
 	var category models.Category
 
 	query := `
-		SELECT id, name, slug, created_at, updated_at
+		SELECT id, name, slug, followers_count, created_at, updated_at
 		FROM categories 
 		WHERE id = $1
 	`
@@ -67,7 +85,7 @@ func (r *CategoryPostgres) GetAll(ctx context.Context) ([]models.Category, error
 	var categories []models.Category
 
 	query := `
-		SELECT id, name, slug, created_at, updated_at
+		SELECT id, name, slug, followers_count, created_at, updated_at
 		FROM categories 
 		ORDER BY name ASC
 	`
@@ -130,8 +148,8 @@ func (r *CategoryPostgres) HasRelatedPosts(ctx context.Context, id int) (bool, e
 	return count > 0, nil
 }
 
-// Update обновляет категорию
-func (r *CategoryPostgres) Update(ctx context.Context, category models.Category) error {
+// Update обновляет категорию и сохраняет запись журнала модерации в той же транзакции
+func (r *CategoryPostgres) Update(ctx context.Context, category models.Category, action *models.ModerationAction) error {
 	query := `
 		UPDATE categories
 		SET name = COALESCE(NULLIF($1, ''), name),
@@ -140,8 +158,10 @@ func (r *CategoryPostgres) Update(ctx context.Context, category models.Category)
 		WHERE id = $4
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := execModeration(
 		ctx,
+		r.db,
+		action,
 		query,
 		category.Name,
 		category.Slug,
@@ -156,11 +176,11 @@ func (r *CategoryPostgres) Update(ctx context.Context, category models.Category)
 	return nil
 }
 
-// Delete удаляет категорию
-func (r *CategoryPostgres) Delete(ctx context.Context, id int) error {
+// Delete удаляет категорию и сохраняет запись журнала модерации в той же транзакции
+func (r *CategoryPostgres) Delete(ctx context.Context, id int, action *models.ModerationAction) error {
 	query := `DELETE FROM categories WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := execModeration(ctx, r.db, action, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CoauthorPostgres struct {
+	db *sqlx.DB
+}
+
+func NewCoauthorPostgres(db *sqlx.DB) *CoauthorPostgres {
+	return &CoauthorPostgres{db: db}
+}
+
+// Invite создает приглашение в соавторы. Отклоненное ранее приглашение можно отправить повторно
+func (r *CoauthorPostgres) Invite(ctx context.Context, coauthor models.PostCoauthor) error {
+	query := `
+		INSERT INTO post_coauthors (post_id, user_id, role, can_edit, status, created_at)
+		VALUES ($1, $2, $3, $4, 'pending', $5)
+		ON CONFLICT (post_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role,
+			can_edit = EXCLUDED.can_edit,
+			status = 'pending',
+			created_at = EXCLUDED.created_at,
+			responded_at = NULL
+		WHERE post_coauthors.status = 'declined'
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		coauthor.PostID,
+		coauthor.UserID,
+		coauthor.Role,
+		coauthor.CanEdit,
+		coauthor.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to invite coauthor: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to invite coauthor: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("coauthor already exists")
+	}
+
+	return nil
+}
+
+// Get получает запись соавтора поста
+func (r *CoauthorPostgres) Get(ctx context.Context, postID int, userID int) (models.PostCoauthor, error) {
+	var coauthor models.PostCoauthor
+
+	query := `
+		SELECT post_id, user_id, role, can_edit, status, created_at, responded_at
+		FROM post_coauthors
+		WHERE post_id = $1 AND user_id = $2
+	`
+
+	if err := r.db.GetContext(ctx, &coauthor, query, postID, userID); err != nil {
+		return models.PostCoauthor{}, fmt.Errorf("coauthor not found: %w", err)
+	}
+
+	return coauthor, nil
+}
+
+// GetByPostID получает всех приглашенных соавторов поста вместе с данными пользователей
+func (r *CoauthorPostgres) GetByPostID(ctx context.Context, postID int) ([]models.PostCoauthorDetails, error) {
+	var coauthors []models.PostCoauthorDetails
+
+	query := `
+		SELECT pc.post_id, pc.user_id, pc.role, pc.can_edit, pc.status, pc.created_at, pc.responded_at,
+			u.username, u.nickname, u.avatar
+		FROM post_coauthors pc
+		JOIN users u ON u.id = pc.user_id
+		WHERE pc.post_id = $1
+		ORDER BY pc.created_at, pc.user_id
+	`
+
+	if err := r.db.SelectContext(ctx, &coauthors, query, postID); err != nil {
+		return nil, fmt.Errorf("failed to get coauthors: %w", err)
+	}
+
+	return coauthors, nil
+}
+
+// GetInvitationsByUserID получает ожидающие ответа приглашения пользователя в соавторы
+func (r *CoauthorPostgres) GetInvitationsByUserID(ctx context.Context, userID int) ([]models.CoauthorInvitation, error) {
+	var invitations []models.CoauthorInvitation
+
+	query := `
+		SELECT pc.post_id, pc.user_id, pc.role, pc.can_edit, pc.status, pc.created_at, pc.responded_at,
+			p.title AS post_title, p.media_path AS post_media_path,
+			u.id AS author_id, u.username AS author_username, u.nickname AS author_nickname, u.avatar AS author_avatar
+		FROM post_coauthors pc
+		JOIN posts p ON p.id = pc.post_id AND p.deleted_at IS NULL
+		JOIN users u ON u.id = p.user_id
+		WHERE pc.user_id = $1 AND pc.status = 'pending'
+		ORDER BY pc.created_at DESC
+	`
+
+	if err := r.db.SelectContext(ctx, &invitations, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get coauthor invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Update изменяет роль и права соавтора
+func (r *CoauthorPostgres) Update(ctx context.Context, postID int, userID int, role string, canEdit bool) error {
+	query := `
+		UPDATE post_coauthors
+		SET role = $1, can_edit = $2
+		WHERE post_id = $3 AND user_id = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, role, canEdit, postID, userID); err != nil {
+		return fmt.Errorf("failed to update coauthor: %w", err)
+	}
+
+	return nil
+}
+
+// Respond сохраняет ответ на приглашение. Возвращает false, если приглашение уже не ожидает ответа
+func (r *CoauthorPostgres) Respond(ctx context.Context, postID int, userID int, status string) (bool, error) {
+	query := `
+		UPDATE post_coauthors
+		SET status = $1, responded_at = $2
+		WHERE post_id = $3 AND user_id = $4 AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, time.Now(), postID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to respond to invitation: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to respond to invitation: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Delete удаляет соавтора или приглашение
+func (r *CoauthorPostgres) Delete(ctx context.Context, postID int, userID int) error {
+	query := `DELETE FROM post_coauthors WHERE post_id = $1 AND user_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, postID, userID); err != nil {
+		return fmt.Errorf("failed to delete coauthor: %w", err)
+	}
+
+	return nil
+}
+
+// CanEdit проверяет, что пользователь - принявший приглашение соавтор с правом редактирования
+func (r *CoauthorPostgres) CanEdit(ctx context.Context, postID int, userID int) (bool, error) {
+	var canEdit bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM post_coauthors
+			WHERE post_id = $1 AND user_id = $2 AND status = 'accepted' AND can_edit
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &canEdit, query, postID, userID); err != nil {
+		return false, fmt.Errorf("failed to check coauthor rights: %w", err)
+	}
+
+	return canEdit, nil
+}
@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type CollectionPostgres struct {
+	db *sqlx.DB
+}
+
+func NewCollectionPostgres(db *sqlx.DB) *CollectionPostgres {
+	return &CollectionPostgres{db: db}
+}
+
+// collectionDetailsQuery выбирает коллекции вместе с владельцем, количеством видимых постов
+// и медиафайлом первого из них для обложки по умолчанию
+const collectionDetailsQuery = `
+	SELECT c.id, c.user_id, c.title, c.description, c.cover_path, c.is_public, c.created_at, c.updated_at,
+		u.username AS owner_username, u.nickname AS owner_nickname, u.avatar AS owner_avatar,
+		(
+			SELECT COUNT(*) FROM collection_items ci
+			JOIN posts p ON p.id = ci.post_id
+			WHERE ci.collection_id = c.id AND p.status = 'approved' AND p.deleted_at IS NULL
+		) AS items_count,
+		(
+			SELECT p.media_path FROM collection_items ci
+			JOIN posts p ON p.id = ci.post_id
+			WHERE ci.collection_id = c.id AND p.status = 'approved' AND p.deleted_at IS NULL
+			ORDER BY ci.position
+			LIMIT 1
+		) AS fallback_cover
+	FROM collections c
+	JOIN users u ON u.id = c.user_id
+`
+
+// Create создает новую коллекцию
+func (r *CollectionPostgres) Create(ctx context.Context, collection models.Collection) (int, error) {
+	var id int
+
+	query := `
+		INSERT INTO collections (user_id, title, description, is_public, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	row := r.db.QueryRowContext(
+		ctx,
+		query,
+		collection.UserID,
+		collection.Title,
+		collection.Description,
+		collection.IsPublic,
+		collection.CreatedAt,
+		collection.UpdatedAt,
+	)
+
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID получает коллекцию по ID
+func (r *CollectionPostgres) GetByID(ctx context.Context, id int) (models.Collection, error) {
+	var collection models.Collection
+
+	query := `
+		SELECT id, user_id, title, description, cover_path, is_public, created_at, updated_at
+		FROM collections
+		WHERE id = $1
+	`
+
+	if err := r.db.GetContext(ctx, &collection, query, id); err != nil {
+		return models.Collection{}, fmt.Errorf("collection not found: %w", err)
+	}
+
+	return collection, nil
+}
+
+// GetDetailsByID получает коллекцию по ID вместе со связанными данными
+func (r *CollectionPostgres) GetDetailsByID(ctx context.Context, id int) (models.CollectionDetails, error) {
+	var collection models.CollectionDetails
+
+	query := collectionDetailsQuery + ` WHERE c.id = $1`
+
+	if err := r.db.GetContext(ctx, &collection, query, id); err != nil {
+		return models.CollectionDetails{}, fmt.Errorf("collection not found: %w", err)
+	}
+
+	return collection, nil
+}
+
+// GetByUserID получает коллекции пользователя от новых к старым.
+// Приватные коллекции включаются только по запросу владельца
+func (r *CollectionPostgres) GetByUserID(ctx context.Context, userID int, includePrivate bool) ([]models.CollectionDetails, error) {
+	var collections []models.CollectionDetails
+
+	query := collectionDetailsQuery + `
+		WHERE c.user_id = $1 AND (c.is_public OR $2)
+		ORDER BY c.created_at DESC, c.id DESC
+	`
+
+	if err := r.db.SelectContext(ctx, &collections, query, userID, includePrivate); err != nil {
+		return nil, fmt.Errorf("failed to get user collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// GetByPostID получает публичные коллекции, содержащие пост, и приватные коллекции зрителя
+func (r *CollectionPostgres) GetByPostID(ctx context.Context, postID int, viewerID int) ([]models.CollectionDetails, error) {
+	var collections []models.CollectionDetails
+
+	query := collectionDetailsQuery + `
+		WHERE c.id IN (SELECT collection_id FROM collection_items WHERE post_id = $1)
+			AND (c.is_public OR c.user_id = $2)
+		ORDER BY c.updated_at DESC, c.id DESC
+	`
+
+	if err := r.db.SelectContext(ctx, &collections, query, postID, viewerID); err != nil {
+		return nil, fmt.Errorf("failed to get post collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// Update обновляет коллекцию
+func (r *CollectionPostgres) Update(ctx context.Context, collection models.Collection) error {
+	query := `
+		UPDATE collections
+		SET title = $1,
+			description = $2,
+			is_public = $3,
+			updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		collection.Title,
+		collection.Description,
+		collection.IsPublic,
+		collection.UpdatedAt,
+		collection.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCover обновляет обложку коллекции
+func (r *CollectionPostgres) UpdateCover(ctx context.Context, id int, coverPath string) error {
+	query := `UPDATE collections SET cover_path = $1, updated_at = $2 WHERE id = $3`
+
+	_, err := r.db.ExecContext(ctx, query, coverPath, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update collection cover: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет коллекцию вместе с ее элементами
+func (r *CollectionPostgres) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM collections WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+
+	return nil
+}
+
+// AddItem добавляет пост в конец коллекции
+func (r *CollectionPostgres) AddItem(ctx context.Context, collectionID int, postID int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем коллекцию, чтобы параллельные добавления не получили одинаковую позицию
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM collections WHERE id = $1 FOR UPDATE`, collectionID); err != nil {
+		return fmt.Errorf("failed to lock collection: %w", err)
+	}
+
+	query := `
+		INSERT INTO collection_items (collection_id, post_id, position, created_at)
+		SELECT $1, $2, COALESCE(MAX(position), 0) + 1, NOW()
+		FROM collection_items
+		WHERE collection_id = $1
+		ON CONFLICT (collection_id, post_id) DO NOTHING
+	`
+
+	result, err := tx.ExecContext(ctx, query, collectionID, postID)
+	if err != nil {
+		return fmt.Errorf("failed to add post to collection: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to add post to collection: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("post already exists in collection")
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE collections SET updated_at = NOW() WHERE id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveItem удаляет пост из коллекции
+func (r *CollectionPostgres) RemoveItem(ctx context.Context, collectionID int, postID int) error {
+	query := `DELETE FROM collection_items WHERE collection_id = $1 AND post_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, collectionID, postID)
+	if err != nil {
+		return fmt.Errorf("failed to remove post from collection: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove post from collection: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("post not found in collection")
+	}
+
+	return nil
+}
+
+// GetVisiblePostIDs получает в текущем порядке ID постов коллекции, которые видит
+// пользователь: опубликованных, не удаленных и не от авторов, связанных с ним блокировкой
+func (r *CollectionPostgres) GetVisiblePostIDs(ctx context.Context, collectionID int, viewerID int) ([]int, error) {
+	var ids []int
+
+	where := " WHERE ci.collection_id = $1 AND p.status = 'approved' AND p.deleted_at IS NULL"
+	where, params := postVisibilityConditions(where, []interface{}{collectionID}, models.PostFilter{ViewerID: viewerID})
+
+	query := "SELECT ci.post_id FROM collection_items ci JOIN posts p ON p.id = ci.post_id" + where + " ORDER BY ci.position, ci.post_id"
+
+	if err := r.db.SelectContext(ctx, &ids, query, params...); err != nil {
+		return nil, fmt.Errorf("failed to get collection items: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Reorder расставляет посты коллекции в указанном порядке. Посты, которых нет
+// в списке (скрытые от владельца), сохраняют взаимный порядок и идут после них
+func (r *CollectionPostgres) Reorder(ctx context.Context, collectionID int, postIDs []int) error {
+	query := `
+		WITH ordered AS (
+			SELECT ci.post_id,
+				ROW_NUMBER() OVER (ORDER BY o.position NULLS LAST, ci.position, ci.post_id) AS position
+			FROM collection_items ci
+			LEFT JOIN unnest($2::int[]) WITH ORDINALITY AS o(post_id, position) ON o.post_id = ci.post_id
+			WHERE ci.collection_id = $1
+		)
+		UPDATE collection_items ci
+		SET position = ordered.position
+		FROM ordered
+		WHERE ci.collection_id = $1 AND ci.post_id = ordered.post_id
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, collectionID, pq.Array(postIDs)); err != nil {
+		return fmt.Errorf("failed to reorder collection: %w", err)
+	}
+
+	return nil
+}
@@ -23,9 +23,9 @@ func (r *CommentPostgres) Create(ctx context.Context, comment models.Comment) (i
 
 	query := `
 		INSERT INTO comments 
-		(user_id, post_id, content, created_at, updated_at) 
+		(user_id, post_id, parent_id, depth, content, media_index, pos_x, pos_y, width, height, created_at, updated_at) 
 		VALUES 
-		($1, $2, $3, $4, $5)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`
 
@@ -34,7 +34,14 @@ func (r *CommentPostgres) Create(ctx context.Context, comment models.Comment) (i
 		query,
 		comment.UserID,
 		comment.PostID,
+		comment.ParentID,
+		comment.Depth,
 		comment.Content,
+		comment.MediaIndex,
+		comment.X,
+		comment.Y,
+		comment.Width,
+		comment.Height,
 		comment.CreatedAt,
 		comment.UpdatedAt,
 	)
@@ -51,9 +58,10 @@ func (r *CommentPostgres) GetByID(ctx context.Context, id int) (models.Comment,
 	var comment models.Comment
 
 	query := `
-		SELECT id, user_id, post_id, content, created_at, updated_at
+		SELECT id, user_id, post_id, parent_id, depth, replies_count, reaction_counts, content, media_index, pos_x, pos_y, width, height,
+			   resolved_at, resolved_by, created_at, updated_at, hidden_at
 		FROM comments 
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	if err := r.db.GetContext(ctx, &comment, query, id); err != nil {
@@ -63,24 +71,110 @@ func (r *CommentPostgres) GetByID(ctx context.Context, id int) (models.Comment,
 	return comment, nil
 }
 
-// GetByPostID получает все комментарии к посту
-func (r *CommentPostgres) GetByPostID(ctx context.Context, postID int) ([]models.Comment, error) {
-	var comments []models.Comment
+// GetDeletedByID получает комментарий из корзины по ID
+func (r *CommentPostgres) GetDeletedByID(ctx context.Context, id int) (models.Comment, error) {
+	var comment models.Comment
 
 	query := `
-		SELECT id, user_id, post_id, content, created_at, updated_at
+		SELECT id, user_id, post_id, parent_id, depth, replies_count, reaction_counts, content, media_index, pos_x, pos_y, width, height,
+			   resolved_at, resolved_by, created_at, updated_at, deleted_at, deleted_by
 		FROM comments 
-		WHERE post_id = $1
-		ORDER BY created_at ASC
+		WHERE id = $1 AND deleted_at IS NOT NULL
 	`
 
-	if err := r.db.SelectContext(ctx, &comments, query, postID); err != nil {
+	if err := r.db.GetContext(ctx, &comment, query, id); err != nil {
+		return models.Comment{}, fmt.Errorf("comment not found: %w", err)
+	}
+
+	return comment, nil
+}
+
+// GetByPostID получает страницу комментариев к посту с учетом фильтра: комментарии верхнего уровня
+// или, если указан filter.ParentID, прямые ответы на комментарий. Сортировка от старых к новым
+func (r *CommentPostgres) GetByPostID(ctx context.Context, postID int, filter models.CommentFilter) ([]models.Comment, error) {
+	var comments []models.Comment
+
+	query := `
+		SELECT c.id, c.user_id, c.post_id, c.parent_id, c.depth, c.replies_count, c.reaction_counts, c.content,
+			c.media_index, c.pos_x, c.pos_y, c.width, c.height,
+			c.resolved_at, c.resolved_by, c.created_at, c.updated_at
+		FROM comments c
+		JOIN posts p ON p.id = c.post_id AND p.deleted_at IS NULL
+		WHERE c.post_id = $1 AND c.deleted_at IS NULL AND c.hidden_at IS NULL
+	`
+	params := []interface{}{postID}
+
+	if filter.ParentID != 0 {
+		query += fmt.Sprintf(" AND c.parent_id = $%d", len(params)+1)
+		params = append(params, filter.ParentID)
+	} else {
+		query += " AND c.parent_id IS NULL"
+	}
+
+	if filter.Resolved != nil {
+		if *filter.Resolved {
+			query += " AND c.resolved_at IS NOT NULL"
+		} else {
+			query += " AND c.resolved_at IS NULL"
+		}
+	}
+
+	if filter.Annotated != nil {
+		if *filter.Annotated {
+			query += " AND c.media_index IS NOT NULL"
+		} else {
+			query += " AND c.media_index IS NULL"
+		}
+	}
+
+	// Комментарии пользователей, связанных с текущим блокировкой или заглушенных им, скрываются
+	if filter.ViewerID != 0 {
+		n := len(params) + 1
+		query += fmt.Sprintf(` AND NOT EXISTS (
+			SELECT 1 FROM user_blocks ub
+			WHERE (ub.blocker_id = c.user_id AND ub.blocked_id = $%d)
+				OR (ub.blocker_id = $%d AND ub.blocked_id = c.user_id)
+		) AND NOT EXISTS (SELECT 1 FROM user_mutes um WHERE um.muter_id = $%d AND um.muted_id = c.user_id)`, n, n, n)
+		params = append(params, filter.ViewerID)
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := models.DecodeCommentCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		query += fmt.Sprintf(" AND (c.created_at, c.id) > ($%d, $%d)", len(params)+1, len(params)+2)
+		params = append(params, cursor.CreatedAt, cursor.ID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY c.created_at ASC, c.id ASC LIMIT $%d", len(params)+1)
+	params = append(params, filter.PerPage)
+
+	if err := r.db.SelectContext(ctx, &comments, query, params...); err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
 	return comments, nil
 }
 
+// CountByPostID получает количество комментариев к посту
+func (r *CommentPostgres) CountByPostID(ctx context.Context, postID int) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*) 
+		FROM comments 
+		WHERE post_id = $1 AND deleted_at IS NULL AND hidden_at IS NULL
+	`
+
+	if err := r.db.GetContext(ctx, &count, query, postID); err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return count, nil
+}
+
 // Update обновляет комментарий
 func (r *CommentPostgres) Update(ctx context.Context, id int, comment models.CommentUpdate) error {
 	query := `
@@ -104,14 +198,84 @@ func (r *CommentPostgres) Update(ctx context.Context, id int, comment models.Com
 	return nil
 }
 
-// Delete удаляет комментарий
-func (r *CommentPostgres) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM comments WHERE id = $1`
+// SetResolved отмечает комментарий учтенным или снова открытым
+func (r *CommentPostgres) SetResolved(ctx context.Context, id int, resolvedBy int, resolved bool) error {
+	query := `
+		UPDATE comments
+		SET resolved_at = CASE WHEN $1 THEN COALESCE(resolved_at, $2) ELSE NULL END,
+			resolved_by = CASE WHEN $1 THEN COALESCE(resolved_by, $3) ELSE NULL END
+		WHERE id = $4 AND deleted_at IS NULL
+	`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.db.ExecContext(ctx, query, resolved, time.Now(), resolvedBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to update comment resolution: %w", err)
+	}
+
+	return nil
+}
+
+// Delete перемещает комментарий в корзину. Удаление модератором сохраняется
+// в журнале модерации в той же транзакции
+func (r *CommentPostgres) Delete(ctx context.Context, id int, deletedBy int, action *models.ModerationAction) error {
+	query := `
+		UPDATE comments
+		SET deleted_at = $1,
+			deleted_by = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	_, err := execModeration(ctx, r.db, action, query, time.Now(), deletedBy, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
 
 	return nil
 }
+
+// SetHidden скрывает комментарий по жалобам или снова показывает его.
+// Возвращает false, если комментарий уже в нужном состоянии.
+// Запись журнала модерации сохраняется, только если состояние изменилось
+func (r *CommentPostgres) SetHidden(ctx context.Context, id int, hidden bool, action *models.ModerationAction) (bool, error) {
+	query := `
+		UPDATE comments
+		SET hidden_at = CASE WHEN $1 THEN $2::timestamptz ELSE NULL END
+		WHERE id = $3 AND deleted_at IS NULL AND (hidden_at IS NULL) = $1
+	`
+
+	rowsAffected, err := execModeration(ctx, r.db, action, query, hidden, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to update comment visibility: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Restore восстанавливает комментарий из корзины
+func (r *CommentPostgres) Restore(ctx context.Context, id int) error {
+	query := `
+		UPDATE comments
+		SET deleted_at = NULL,
+			deleted_by = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore comment: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет комментарии, перемещенные в корзину раньше before
+func (r *CommentPostgres) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM comments WHERE deleted_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted comments: %w", err)
+	}
+
+	return result.RowsAffected()
+}
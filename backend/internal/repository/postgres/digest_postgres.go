@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type DigestPostgres struct {
+	db *sqlx.DB
+}
+
+func NewDigestPostgres(db *sqlx.DB) *DigestPostgres {
+	return &DigestPostgres{db: db}
+}
+
+// ClaimDue выбирает пользователей, предыдущий дайджест которых отправлен раньше
+// dailyBefore или weeklyBefore (в зависимости от периодичности), и сразу отмечает
+// дайджест отправленным. SKIP LOCKED не дает нескольким экземплярам приложения
+// отправить один дайджест дважды. Пользователи из exclude пропускаются.
+// В ответе SentAt содержит время предыдущего дайджеста
+func (r *DigestPostgres) ClaimDue(ctx context.Context, now, dailyBefore, weeklyBefore time.Time, exclude []int, limit int) ([]models.DigestRecipient, error) {
+	var recipients []models.DigestRecipient
+
+	query := `
+		WITH due AS (
+			SELECT id, digest_sent_at
+			FROM users
+			WHERE ((digest_frequency = $2 AND (digest_sent_at IS NULL OR digest_sent_at <= $3))
+				OR (digest_frequency = $4 AND (digest_sent_at IS NULL OR digest_sent_at <= $5)))
+				AND NOT (id = ANY($7))
+			ORDER BY id
+			LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE users u
+		SET digest_sent_at = $1
+		FROM due
+		WHERE u.id = due.id
+		RETURNING u.id, u.email, u.username, u.nickname, u.digest_frequency, due.digest_sent_at
+	`
+
+	if err := r.db.SelectContext(ctx, &recipients, query,
+		now, models.DigestDaily, dailyBefore, models.DigestWeekly, weeklyBefore, limit, pq.Array(exclude),
+	); err != nil {
+		return nil, fmt.Errorf("failed to claim digests: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// GetPosts получает самые популярные работы, опубликованные после since
+// дизайнерами, на которых подписан пользователь
+func (r *DigestPostgres) GetPosts(ctx context.Context, userID int, since time.Time, limit int) ([]models.DigestPost, error) {
+	var posts []models.DigestPost
+
+	query := `
+		SELECT p.id, p.title, p.media_type, p.media_path, p.likes_count,
+			   u.username AS author_username, u.nickname AS author_nickname
+		FROM user_follows f
+		JOIN posts p ON p.user_id = f.followee_id
+		JOIN users u ON u.id = p.user_id
+		WHERE f.follower_id = $1
+			AND p.status = 'approved'
+			AND p.deleted_at IS NULL
+			AND p.published_at > $2
+			AND NOT EXISTS (SELECT 1 FROM user_mutes um WHERE um.muter_id = $1 AND um.muted_id = p.user_id)
+		ORDER BY p.likes_count DESC, p.id DESC
+		LIMIT $3
+	`
+
+	if err := r.db.SelectContext(ctx, &posts, query, userID, since, limit); err != nil {
+		return nil, fmt.Errorf("failed to get digest posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// GetActivity получает непрочитанные уведомления пользователя после since
+// тех типов, для которых включена доставка по email
+func (r *DigestPostgres) GetActivity(ctx context.Context, userID int, since time.Time, limit int) ([]models.NotificationDetails, error) {
+	var notifications []models.NotificationDetails
+
+	query := notificationDetailsQuery + `
+		WHERE n.user_id = $1
+			AND n.read_at IS NULL
+			AND n.created_at > $3
+			AND COALESCE((
+				SELECT np.channel FROM notification_preferences np
+				WHERE np.user_id = n.user_id AND np.type = n.type
+			), $4) = $5
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $6
+	`
+
+	if err := r.db.SelectContext(ctx, &notifications, query,
+		userID, notificationActorsLimit, since,
+		models.DefaultNotificationChannel, models.NotificationChannelEmail, limit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get digest activity: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// Release возвращает время предыдущего дайджеста, если письмо не удалось отправить,
+// чтобы следующий проход попробовал снова
+func (r *DigestPostgres) Release(ctx context.Context, userID int, claimedAt time.Time, sentAt *time.Time) error {
+	query := `UPDATE users SET digest_sent_at = $3 WHERE id = $1 AND digest_sent_at = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, claimedAt, sentAt); err != nil {
+		return fmt.Errorf("failed to release digest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type FollowPostgres struct {
+	db *sqlx.DB
+}
+
+func NewFollowPostgres(db *sqlx.DB) *FollowPostgres {
+	return &FollowPostgres{db: db}
+}
+
+// FollowUser подписывает пользователя на другого пользователя. Возвращает false, если подписка уже есть
+func (r *FollowPostgres) FollowUser(ctx context.Context, followerID int, followeeID int, createdAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO user_follows (follower_id, followee_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, followerID, followeeID, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UnfollowUser отменяет подписку на пользователя
+func (r *FollowPostgres) UnfollowUser(ctx context.Context, followerID int, followeeID int) error {
+	query := `DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, followerID, followeeID)
+	if err != nil {
+		return fmt.Errorf("failed to unfollow user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("follow not found")
+	}
+
+	return nil
+}
+
+// IsFollowingUser проверяет, подписан ли пользователь на другого пользователя
+func (r *FollowPostgres) IsFollowingUser(ctx context.Context, followerID int, followeeID int) (bool, error) {
+	var exists bool
+
+	query := `SELECT EXISTS (SELECT 1 FROM user_follows WHERE follower_id = $1 AND followee_id = $2)`
+
+	if err := r.db.GetContext(ctx, &exists, query, followerID, followeeID); err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+
+	return exists, nil
+}
+
+// FollowCategory подписывает пользователя на категорию. Возвращает false, если подписка уже есть
+func (r *FollowPostgres) FollowCategory(ctx context.Context, userID int, categoryID int, createdAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO category_follows (user_id, category_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, categoryID, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UnfollowCategory отменяет подписку на категорию
+func (r *FollowPostgres) UnfollowCategory(ctx context.Context, userID int, categoryID int) error {
+	query := `DELETE FROM category_follows WHERE user_id = $1 AND category_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to unfollow category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("follow not found")
+	}
+
+	return nil
+}
+
+// GetFollowedCategoryIDs получает ID категорий, на которые подписан пользователь
+func (r *FollowPostgres) GetFollowedCategoryIDs(ctx context.Context, userID int) ([]int, error) {
+	var ids []int
+
+	query := `SELECT category_id FROM category_follows WHERE user_id = $1`
+
+	if err := r.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get followed categories: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetFollowers получает страницу подписчиков пользователя, новые сверху.
+// is_following отмечает, подписан ли на подписчика viewerID
+func (r *FollowPostgres) GetFollowers(ctx context.Context, userID int, viewerID int, limit, offset int) ([]models.FollowUser, int, error) {
+	return r.getFollowUsers(ctx, "followee_id", "follower_id", userID, viewerID, limit, offset)
+}
+
+// GetFollowing получает страницу пользователей, на которых подписан пользователь, новые сверху
+func (r *FollowPostgres) GetFollowing(ctx context.Context, userID int, viewerID int, limit, offset int) ([]models.FollowUser, int, error) {
+	return r.getFollowUsers(ctx, "follower_id", "followee_id", userID, viewerID, limit, offset)
+}
+
+// getFollowUsers выбирает связанных подпиской пользователей: whereColumn задает сторону
+// пользователя userID, userColumn — сторону, пользователи которой попадают в список
+func (r *FollowPostgres) getFollowUsers(ctx context.Context, whereColumn, userColumn string, userID, viewerID, limit, offset int) ([]models.FollowUser, int, error) {
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM user_follows WHERE %s = $1`, whereColumn)
+	if err := r.db.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count follows: %w", err)
+	}
+
+	var users []models.FollowUser
+	query := fmt.Sprintf(`
+		SELECT u.id AS user_id, u.username, u.nickname, u.avatar, u.followers_count,
+			EXISTS (
+				SELECT 1 FROM user_follows vf WHERE vf.follower_id = $2 AND vf.followee_id = u.id
+			) AS is_following,
+			f.created_at AS followed_at
+		FROM user_follows f
+		JOIN users u ON u.id = f.%s
+		WHERE f.%s = $1
+		ORDER BY f.created_at DESC, u.id DESC
+		LIMIT $3 OFFSET $4
+	`, userColumn, whereColumn)
+
+	if err := r.db.SelectContext(ctx, &users, query, userID, viewerID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get follows: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetFollowedCategories получает страницу категорий, на которые подписан пользователь, новые сверху
+func (r *FollowPostgres) GetFollowedCategories(ctx context.Context, userID int, limit, offset int) ([]models.FollowedCategory, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM category_follows WHERE user_id = $1`, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count followed categories: %w", err)
+	}
+
+	var categories []models.FollowedCategory
+	query := `
+		SELECT c.id, c.name, c.slug, c.followers_count, c.created_at, c.updated_at, f.created_at AS followed_at
+		FROM category_follows f
+		JOIN categories c ON c.id = f.category_id
+		WHERE f.user_id = $1
+		ORDER BY f.created_at DESC, c.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	if err := r.db.SelectContext(ctx, &categories, query, userID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get followed categories: %w", err)
+	}
+
+	return categories, total, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type MentionPostgres struct {
+	db *sqlx.DB
+}
+
+func NewMentionPostgres(db *sqlx.DB) *MentionPostgres {
+	return &MentionPostgres{db: db}
+}
+
+// Replace заменяет упоминания в описании поста (commentID = nil) или в комментарии.
+// Пользователи, которые уже получили уведомление до правки, повторно его не получают
+func (r *MentionPostgres) Replace(ctx context.Context, postID int, commentID *int, mentions []models.Mention) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `
+		DELETE FROM mentions
+		WHERE post_id = $1 AND comment_id IS NOT DISTINCT FROM $2
+		RETURNING mentioned_user_id, notified_at
+	`
+
+	var previous []models.Mention
+	if err := tx.SelectContext(ctx, &previous, deleteQuery, postID, commentID); err != nil {
+		return fmt.Errorf("failed to delete mentions: %w", err)
+	}
+
+	// Запоминаем, кто уже получил уведомление, чтобы перенести отметку на новые записи
+	notifiedAt := make(map[int]*time.Time, len(previous))
+	for _, mention := range previous {
+		if mention.NotifiedAt != nil {
+			notifiedAt[mention.MentionedUserID] = mention.NotifiedAt
+		}
+	}
+
+	insertQuery := `
+		INSERT INTO mentions (post_id, comment_id, author_id, mentioned_user_id, text_offset, text_length, notified_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	for _, mention := range mentions {
+		_, err := tx.ExecContext(
+			ctx,
+			insertQuery,
+			postID,
+			commentID,
+			mention.AuthorID,
+			mention.MentionedUserID,
+			mention.Offset,
+			mention.Length,
+			notifiedAt[mention.MentionedUserID],
+			mention.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save mention: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCommentIDs получает упоминания в комментариях вместе с данными упомянутых пользователей
+func (r *MentionPostgres) GetByCommentIDs(ctx context.Context, commentIDs []int) ([]models.MentionDetails, error) {
+	var mentions []models.MentionDetails
+
+	query := `
+		SELECT m.id, m.post_id, m.comment_id, m.author_id, m.mentioned_user_id, m.text_offset, m.text_length,
+			m.notified_at, m.created_at, u.username, u.nickname
+		FROM mentions m
+		JOIN users u ON u.id = m.mentioned_user_id
+		WHERE m.comment_id = ANY($1)
+		ORDER BY m.comment_id, m.text_offset
+	`
+
+	if err := r.db.SelectContext(ctx, &mentions, query, pq.Array(commentIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+// NotifyPending создает уведомления по упоминаниям в опубликованных постах и в комментариях к ним.
+// Упоминание в еще не опубликованном посте ждет публикации. Пользователи, отключившие
+// уведомления об упоминаниях, их не получают
+func (r *MentionPostgres) NotifyPending(ctx context.Context, now time.Time) ([]models.Notification, error) {
+	var notifications []models.Notification
+
+	query := `
+		WITH due AS (
+			UPDATE mentions m
+			SET notified_at = $1
+			FROM posts p
+			WHERE m.notified_at IS NULL
+				AND p.id = m.post_id
+				AND p.status = 'approved'
+				AND p.deleted_at IS NULL
+				AND (m.comment_id IS NULL OR EXISTS (
+					SELECT 1 FROM comments c WHERE c.id = m.comment_id AND c.deleted_at IS NULL AND c.hidden_at IS NULL
+				))
+			RETURNING m.mentioned_user_id, m.author_id, m.post_id, m.comment_id
+		)
+		INSERT INTO notifications (user_id, actor_id, actor_ids, type, post_id, comment_id, created_at)
+		SELECT mentioned_user_id, author_id, ARRAY[author_id], $2, post_id, comment_id, $1
+		FROM due
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notification_preferences np
+			WHERE np.user_id = due.mentioned_user_id AND np.type = $2 AND np.channel = $3
+		) AND NOT EXISTS (
+			SELECT 1 FROM user_blocks ub
+			WHERE (ub.blocker_id = due.mentioned_user_id AND ub.blocked_id = due.author_id)
+				OR (ub.blocker_id = due.author_id AND ub.blocked_id = due.mentioned_user_id)
+		)
+		RETURNING id, user_id, actor_id, type, post_id, comment_id, created_at
+	`
+
+	if err := r.db.SelectContext(ctx, &notifications, query, now, models.NotificationTypeMention, models.NotificationChannelOff); err != nil {
+		return nil, fmt.Errorf("failed to create mention notifications: %w", err)
+	}
+
+	return notifications, nil
+}
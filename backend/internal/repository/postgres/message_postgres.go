@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"designhub/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// conversationDetailsQuery выбирает диалоги с точки зрения участника $1:
+// собеседника, последнее сообщение и количество непрочитанных
+const conversationDetailsQuery = `
+	SELECT c.id, me.status, me.last_read_message_id, c.created_at,
+		peer.user_id AS peer_id, u.username AS peer_username, u.nickname AS peer_nickname,
+		COALESCE(u.avatar, '') AS peer_avatar,
+		peer.last_read_message_id AS peer_last_read_message_id, peer.last_read_at AS peer_last_read_at,
+		m.id AS last_message_id, m.sender_id AS last_message_sender_id, m.body AS last_message_body,
+		m.created_at AS last_message_created_at,
+		(
+			SELECT COUNT(*) FROM messages um
+			WHERE um.conversation_id = c.id AND um.id > me.last_read_message_id AND um.sender_id <> me.user_id
+		) AS unread_count
+	FROM conversation_members me
+	JOIN conversations c ON c.id = me.conversation_id
+	JOIN conversation_members peer ON peer.conversation_id = c.id AND peer.user_id <> me.user_id
+	JOIN users u ON u.id = peer.user_id
+	LEFT JOIN messages m ON m.id = c.last_message_id
+`
+
+type MessagePostgres struct {
+	db *sqlx.DB
+}
+
+func NewMessagePostgres(db *sqlx.DB) *MessagePostgres {
+	return &MessagePostgres{db: db}
+}
+
+// FindConversation возвращает ID диалога двух пользователей или 0, если они еще не переписывались
+func (r *MessagePostgres) FindConversation(ctx context.Context, userID int, peerID int) (int, error) {
+	var id int
+
+	user1, user2 := orderedPair(userID, peerID)
+	query := `SELECT id FROM conversations WHERE user1_id = $1 AND user2_id = $2`
+
+	if err := r.db.GetContext(ctx, &id, query, user1, user2); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to find conversation: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateConversation создает диалог. Инициатор сразу видит его во входящих,
+// а у собеседника диалог получает статус peerStatus. Если диалог уже создан
+// параллельным запросом, возвращается его ID
+func (r *MessagePostgres) CreateConversation(ctx context.Context, userID int, peerID int, peerStatus string, createdAt time.Time) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	user1, user2 := orderedPair(userID, peerID)
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул ID существующего диалога
+	query := `
+		INSERT INTO conversations (user1_id, user2_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user1_id, user2_id) DO UPDATE SET user1_id = EXCLUDED.user1_id
+		RETURNING id
+	`
+
+	if err := tx.GetContext(ctx, &id, query, user1, user2, createdAt); err != nil {
+		return 0, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	membersQuery := `
+		INSERT INTO conversation_members (conversation_id, user_id, status)
+		VALUES ($1, $2, $3), ($1, $4, $5)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, membersQuery, id, userID, models.ConversationStatusAccepted, peerID, peerStatus); err != nil {
+		return 0, fmt.Errorf("failed to add conversation members: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetMembers получает участников диалога
+func (r *MessagePostgres) GetMembers(ctx context.Context, conversationID int) ([]models.ConversationMember, error) {
+	var members []models.ConversationMember
+
+	query := `
+		SELECT conversation_id, user_id, status, last_read_message_id, last_read_at
+		FROM conversation_members
+		WHERE conversation_id = $1
+	`
+
+	if err := r.db.SelectContext(ctx, &members, query, conversationID); err != nil {
+		return nil, fmt.Errorf("failed to get conversation members: %w", err)
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("conversation not found")
+	}
+
+	return members, nil
+}
+
+// SetStatus меняет состояние диалога для участника
+func (r *MessagePostgres) SetStatus(ctx context.Context, conversationID int, userID int, status string) error {
+	query := `UPDATE conversation_members SET status = $3 WHERE conversation_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, conversationID, userID, status)
+	if err != nil {
+		return fmt.Errorf("failed to update conversation status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("conversation not found")
+	}
+
+	return nil
+}
+
+// CreateMessage сохраняет сообщение, делает его последним в диалоге и отмечает
+// прочитанным для отправителя
+func (r *MessagePostgres) CreateMessage(ctx context.Context, message models.Message) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+
+	query := `
+		INSERT INTO messages (conversation_id, sender_id, body, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	if err := tx.GetContext(ctx, &id, query, message.ConversationID, message.SenderID, message.Body, message.CreatedAt); err != nil {
+		return 0, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	conversationQuery := `UPDATE conversations SET last_message_id = $2, last_message_at = $3 WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, conversationQuery, message.ConversationID, id, message.CreatedAt); err != nil {
+		return 0, fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	readQuery := `
+		UPDATE conversation_members
+		SET last_read_message_id = $3, last_read_at = $4
+		WHERE conversation_id = $1 AND user_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, readQuery, message.ConversationID, message.SenderID, id, message.CreatedAt); err != nil {
+		return 0, fmt.Errorf("failed to mark message read: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetMessage получает сообщение по ID
+func (r *MessagePostgres) GetMessage(ctx context.Context, messageID int) (models.Message, error) {
+	var message models.Message
+
+	query := `SELECT id, conversation_id, sender_id, body, created_at FROM messages WHERE id = $1`
+
+	if err := r.db.GetContext(ctx, &message, query, messageID); err != nil {
+		return models.Message{}, fmt.Errorf("message not found: %w", err)
+	}
+
+	return message, nil
+}
+
+// GetMessages получает страницу сообщений диалога, новые сверху. Запрос filter.Query
+// ищет подстроку в тексте сообщений
+func (r *MessagePostgres) GetMessages(ctx context.Context, conversationID int, filter models.MessageFilter) ([]models.Message, error) {
+	var messages []models.Message
+
+	query := `
+		SELECT id, conversation_id, sender_id, body, created_at
+		FROM messages
+		WHERE conversation_id = $1
+	`
+	params := []interface{}{conversationID}
+
+	if filter.Query != "" {
+		query += fmt.Sprintf(" AND body ILIKE $%d", len(params)+1)
+		params = append(params, "%"+filter.Query+"%")
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := models.DecodeMessageCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		query += fmt.Sprintf(" AND id < $%d", len(params)+1)
+		params = append(params, cursor.ID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(params)+1)
+	params = append(params, filter.PerPage)
+
+	if err := r.db.SelectContext(ctx, &messages, query, params...); err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// GetConversation получает диалог с точки зрения участника
+func (r *MessagePostgres) GetConversation(ctx context.Context, conversationID int, userID int) (models.ConversationDetails, error) {
+	var conversation models.ConversationDetails
+
+	query := conversationDetailsQuery + " WHERE me.user_id = $1 AND c.id = $2"
+
+	if err := r.db.GetContext(ctx, &conversation, query, userID, conversationID); err != nil {
+		return models.ConversationDetails{}, fmt.Errorf("conversation not found: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// GetConversations получает страницу диалогов пользователя с заданным статусом,
+// диалоги с новыми сообщениями сверху
+func (r *MessagePostgres) GetConversations(ctx context.Context, userID int, status string, limit, offset int) ([]models.ConversationDetails, int, error) {
+	var total int
+
+	countQuery := `SELECT COUNT(*) FROM conversation_members WHERE user_id = $1 AND status = $2`
+
+	if err := r.db.GetContext(ctx, &total, countQuery, userID, status); err != nil {
+		return nil, 0, fmt.Errorf("failed to count conversations: %w", err)
+	}
+
+	var conversations []models.ConversationDetails
+
+	query := conversationDetailsQuery + `
+		WHERE me.user_id = $1 AND me.status = $2
+		ORDER BY c.last_message_at DESC NULLS LAST, c.id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	if err := r.db.SelectContext(ctx, &conversations, query, userID, status, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get conversations: %w", err)
+	}
+
+	return conversations, total, nil
+}
+
+// MarkRead отмечает прочитанными все сообщения диалога для участника.
+// Возвращает ID последнего прочитанного сообщения
+func (r *MessagePostgres) MarkRead(ctx context.Context, conversationID int, userID int, readAt time.Time) (int, error) {
+	var lastReadID int
+
+	query := `
+		UPDATE conversation_members me
+		SET last_read_message_id = COALESCE(c.last_message_id, 0), last_read_at = $3
+		FROM conversations c
+		WHERE c.id = me.conversation_id AND me.conversation_id = $1 AND me.user_id = $2
+		RETURNING me.last_read_message_id
+	`
+
+	if err := r.db.GetContext(ctx, &lastReadID, query, conversationID, userID, readAt); err != nil {
+		return 0, fmt.Errorf("conversation not found: %w", err)
+	}
+
+	return lastReadID, nil
+}
+
+// UpdateDMPolicy меняет настройку, кто может писать пользователю
+func (r *MessagePostgres) UpdateDMPolicy(ctx context.Context, userID int, policy string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET dm_policy = $2 WHERE id = $1`, userID, policy)
+	if err != nil {
+		return fmt.Errorf("failed to update message settings: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// orderedPair упорядочивает пару пользователей так, как она хранится в conversations
+func orderedPair(a, b int) (int, int) {
+	if a < b {
+		return a, b
+	}
+	return b, a
+}
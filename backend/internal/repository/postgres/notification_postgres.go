@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"designhub/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// notificationActorsLimit сколько последних участников события отдается вместе с уведомлением
+const notificationActorsLimit = 3
+
+// notificationDetailsQuery выбирает уведомления с последними участниками и заголовком поста.
+// $1 — получатель, $2 — количество участников
+const notificationDetailsQuery = `
+	SELECT n.id, n.user_id, n.actor_id, n.type, n.post_id, n.comment_id, n.reject_reason, n.group_key,
+		n.read_at, n.created_at,
+		cardinality(n.actor_ids) AS actors_count,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', u.id, 'username', u.username, 'nickname', u.nickname, 'avatar', COALESCE(u.avatar, '')
+			) ORDER BY a.ord DESC)
+			FROM unnest(n.actor_ids) WITH ORDINALITY AS a(id, ord)
+			JOIN users u ON u.id = a.id
+			WHERE a.ord > cardinality(n.actor_ids) - $2
+		), '[]') AS actors,
+		p.title AS post_title
+	FROM notifications n
+	LEFT JOIN posts p ON p.id = n.post_id
+`
+
+type NotificationPostgres struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPostgres(db *sqlx.DB) *NotificationPostgres {
+	return &NotificationPostgres{db: db}
+}
+
+// Create сохраняет уведомление. Если у получателя уже есть непрочитанное уведомление
+// с тем же group_key, событие присоединяется к нему: участник переносится в конец списка,
+// а время уведомления обновляется. Если пользователь отключил уведомления этого типа,
+// заглушил участника или связан с ним блокировкой, ничего не сохраняется и возвращается 0
+func (r *NotificationPostgres) Create(ctx context.Context, notification models.Notification) (int, error) {
+	var id int
+
+	query := `
+		INSERT INTO notifications
+		(user_id, actor_id, actor_ids, type, post_id, comment_id, reject_reason, group_key, created_at)
+		SELECT $1::int, $2::int, CASE WHEN $2::int IS NULL THEN '{}'::int[] ELSE ARRAY[$2::int] END,
+			$3::varchar, $4::int, $5::int, $6::text, $7::varchar, $8::timestamptz
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notification_preferences
+			WHERE user_id = $1 AND type = $3 AND channel = $9
+		) AND NOT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		) AND NOT EXISTS (
+			SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = $2
+		)
+		ON CONFLICT (user_id, group_key) WHERE read_at IS NULL AND group_key IS NOT NULL
+		DO UPDATE SET
+			actor_id = EXCLUDED.actor_id,
+			actor_ids = array_append(array_remove(notifications.actor_ids, EXCLUDED.actor_id), EXCLUDED.actor_id),
+			comment_id = EXCLUDED.comment_id,
+			created_at = EXCLUDED.created_at
+		RETURNING id
+	`
+
+	row := r.db.QueryRowContext(
+		ctx,
+		query,
+		notification.UserID,
+		notification.ActorID,
+		notification.Type,
+		notification.PostID,
+		notification.CommentID,
+		notification.RejectReason,
+		notification.GroupKey,
+		notification.CreatedAt,
+		models.NotificationChannelOff,
+	)
+
+	if err := row.Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID получает уведомление пользователя по ID
+func (r *NotificationPostgres) GetByID(ctx context.Context, userID int, id int) (models.NotificationDetails, error) {
+	var notification models.NotificationDetails
+
+	query := notificationDetailsQuery + " WHERE n.user_id = $1 AND n.id = $3"
+
+	if err := r.db.GetContext(ctx, &notification, query, userID, notificationActorsLimit, id); err != nil {
+		return models.NotificationDetails{}, fmt.Errorf("notification not found: %w", err)
+	}
+
+	return notification, nil
+}
+
+// GetByUserID получает страницу уведомлений пользователя, новые сверху
+func (r *NotificationPostgres) GetByUserID(ctx context.Context, userID int, filter models.NotificationFilter) ([]models.NotificationDetails, int, error) {
+	where := " WHERE n.user_id = $1"
+	if filter.Unread {
+		where += " AND n.read_at IS NULL"
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM notifications n"+where, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	var notifications []models.NotificationDetails
+	query := notificationDetailsQuery + where + `
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	offset := (filter.Page - 1) * filter.PerPage
+	if err := r.db.SelectContext(ctx, &notifications, query, userID, notificationActorsLimit, filter.PerPage, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread возвращает количество непрочитанных уведомлений пользователя
+func (r *NotificationPostgres) CountUnread(ctx context.Context, userID int) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead отмечает уведомления пользователя прочитанными. Пустой список ids отмечает все.
+// Возвращает количество отмеченных уведомлений
+func (r *NotificationPostgres) MarkRead(ctx context.Context, userID int, ids []int, readAt time.Time) (int64, error) {
+	query := `UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`
+	params := []interface{}{userID, readAt}
+
+	if len(ids) > 0 {
+		query += " AND id = ANY($3)"
+		params = append(params, pq.Array(ids))
+	}
+
+	result, err := r.db.ExecContext(ctx, query, params...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Exists проверяет, что уведомление принадлежит пользователю
+func (r *NotificationPostgres) Exists(ctx context.Context, userID int, id int) (bool, error) {
+	var exists bool
+
+	query := `SELECT EXISTS (SELECT 1 FROM notifications WHERE id = $1 AND user_id = $2)`
+
+	if err := r.db.GetContext(ctx, &exists, query, id, userID); err != nil {
+		return false, fmt.Errorf("failed to check notification: %w", err)
+	}
+
+	return exists, nil
+}
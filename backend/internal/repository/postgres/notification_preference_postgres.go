@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationPreferencePostgres struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPreferencePostgres(db *sqlx.DB) *NotificationPreferencePostgres {
+	return &NotificationPreferencePostgres{db: db}
+}
+
+// Get получает настройки уведомлений пользователя. Types содержит только
+// типы, которые пользователь настраивал
+func (r *NotificationPreferencePostgres) Get(ctx context.Context, userID int) (models.NotificationPreferences, error) {
+	var preferences models.NotificationPreferences
+
+	if err := r.db.GetContext(ctx, &preferences.Digest, `SELECT digest_frequency FROM users WHERE id = $1`, userID); err != nil {
+		return models.NotificationPreferences{}, fmt.Errorf("user not found: %w", err)
+	}
+
+	query := `SELECT type, channel FROM notification_preferences WHERE user_id = $1`
+
+	if err := r.db.SelectContext(ctx, &preferences.Types, query, userID); err != nil {
+		return models.NotificationPreferences{}, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// Update сохраняет каналы для указанных типов и, если задана, периодичность дайджеста
+func (r *NotificationPreferencePostgres) Update(ctx context.Context, userID int, types map[string]string, digest *string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsertQuery := `
+		INSERT INTO notification_preferences (user_id, type, channel)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, type) DO UPDATE SET channel = EXCLUDED.channel
+	`
+
+	for notificationType, channel := range types {
+		if _, err := tx.ExecContext(ctx, upsertQuery, userID, notificationType, channel); err != nil {
+			return fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+
+	if digest != nil {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET digest_frequency = $2 WHERE id = $1`, userID, *digest); err != nil {
+			return fmt.Errorf("failed to update digest frequency: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetDigestFrequency меняет периодичность дайджеста пользователя
+func (r *NotificationPreferencePostgres) SetDigestFrequency(ctx context.Context, userID int, frequency string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET digest_frequency = $2 WHERE id = $1`, userID, frequency)
+	if err != nil {
+		return fmt.Errorf("failed to update digest frequency: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"designhub/internal/models"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type PostPostgres struct {
@@ -22,15 +22,28 @@ func NewPostPostgres(db *sqlx.DB) *PostPostgres {
 func (r *PostPostgres) Create(ctx context.Context, post models.Post) (int, error) {
 	var id int
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Пост, созданный не черновиком, сразу отправлен на модерацию
+	var submittedAt *time.Time
+	if post.Status == "pending" {
+		submittedAt = &post.CreatedAt
+	}
+
 	query := `
 		INSERT INTO posts 
-		(user_id, category_id, title, description, media_path, media_type, status, reject_reason, created_at, updated_at) 
+		(user_id, category_id, title, description, media_path, media_type, status, reject_reason, publish_at,
+		 license, source_url, credit_url, submitted_at, created_at, updated_at) 
 		VALUES 
-		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11, ''), COALESCE($12, ''), $13, $14, $15)
 		RETURNING id
 	`
 
-	row := r.db.QueryRowContext(
+	row := tx.QueryRowContext(
 		ctx,
 		query,
 		post.UserID,
@@ -41,6 +54,11 @@ func (r *PostPostgres) Create(ctx context.Context, post models.Post) (int, error
 		post.MediaType,
 		post.Status,
 		post.RejectReason,
+		post.PublishAt,
+		post.License,
+		post.SourceURL,
+		post.CreditURL,
+		submittedAt,
 		post.CreatedAt,
 		post.UpdatedAt,
 	)
@@ -49,6 +67,14 @@ func (r *PostPostgres) Create(ctx context.Context, post models.Post) (int, error
 		return 0, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	if err := replacePostTools(ctx, tx, id, post.ToolIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return id, nil
 }
 
@@ -58,9 +84,9 @@ func (r *PostPostgres) GetByID(ctx context.Context, id int) (models.Post, error)
 
 	query := `
 		SELECT id, user_id, category_id, title, description, media_path, media_type, status, reject_reason,
-			   created_at, updated_at, (SELECT COUNT(*) FROM likes WHERE post_id = posts.id) as likes_count 
+			   publish_at, license, source_url, credit_url, published_at, created_at, updated_at, likes_count, views_count
 		FROM posts 
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	if err := r.db.GetContext(ctx, &post, query, id); err != nil {
@@ -70,265 +96,275 @@ func (r *PostPostgres) GetByID(ctx context.Context, id int) (models.Post, error)
 	return post, nil
 }
 
-// GetAll получает все посты с фильтрацией и пагинацией
-func (r *PostPostgres) GetAll(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error) {
-	var posts []models.Post
-	var total int
+// GetDeletedByID получает пост из корзины по ID
+func (r *PostPostgres) GetDeletedByID(ctx context.Context, id int) (models.Post, error) {
+	var post models.Post
 
-	// Базовый запрос
 	query := `
 		SELECT id, user_id, category_id, title, description, media_path, media_type, status, reject_reason,
-			created_at, updated_at, (SELECT COUNT(*) FROM likes WHERE post_id = posts.id) as likes_count 
-		FROM posts
-		WHERE 1=1
+			   publish_at, license, source_url, credit_url, published_at, created_at, updated_at, likes_count, views_count,
+			   deleted_at, deleted_by
+		FROM posts 
+		WHERE id = $1 AND deleted_at IS NOT NULL
 	`
 
-	// Запрос для подсчета общего количества
-	countQuery := "SELECT COUNT(*) FROM posts WHERE 1=1"
-
-	// Параметры
-	var params []interface{}
-	var paramIndex int = 1
-
-	// Добавляем фильтры
-	if filter.CategoryID != 0 {
-		query += fmt.Sprintf(" AND category_id = $%d", paramIndex)
-		countQuery += fmt.Sprintf(" AND category_id = $%d", paramIndex)
-		params = append(params, filter.CategoryID)
-		paramIndex++
+	if err := r.db.GetContext(ctx, &post, query, id); err != nil {
+		return models.Post{}, fmt.Errorf("post not found: %w", err)
 	}
 
-	if filter.UserID != 0 {
-		query += fmt.Sprintf(" AND user_id = $%d", paramIndex)
-		countQuery += fmt.Sprintf(" AND user_id = $%d", paramIndex)
-		params = append(params, filter.UserID)
-		paramIndex++
-	}
+	return post, nil
+}
 
-	// В PostFilter больше нет Status, поэтому добавляем по умолчанию approved
-	query += fmt.Sprintf(" AND status = $%d", paramIndex)
-	countQuery += fmt.Sprintf(" AND status = $%d", paramIndex)
-	params = append(params, "approved")
-	paramIndex++
+// GetDetailsByID получает пост по ID вместе с автором, категорией и отметкой лайка зрителя
+func (r *PostPostgres) GetDetailsByID(ctx context.Context, id int, viewerID int) (models.PostDetails, error) {
+	var post models.PostDetails
 
-	if filter.SearchQuery != "" {
-		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", paramIndex, paramIndex)
-		countQuery += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", paramIndex, paramIndex)
-		params = append(params, "%"+filter.SearchQuery+"%")
-		paramIndex++
-	}
+	query, params := postDetailsQuery("posts p", " WHERE p.id = $1 AND p.deleted_at IS NULL", []interface{}{id}, viewerID, "0")
 
-	// Сортировка
-	query += " ORDER BY "
-	switch filter.SortBy {
-	case "popularity":
-		query += "likes_count"
-	case "date":
-		fallthrough
-	default:
-		query += "created_at"
+	if err := r.db.GetContext(ctx, &post, query, params...); err != nil {
+		return models.PostDetails{}, fmt.Errorf("post not found: %w", err)
 	}
 
-	// Направление сортировки
-	if strings.ToLower(filter.SortOrder) == "asc" {
-		query += " ASC"
-	} else {
-		query += " DESC"
-	}
+	return post, nil
+}
 
-	// Пагинация
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+// GetDetailsByIDs получает посты по списку ID вместе со связанными данными.
+// Порядок результата не гарантируется
+func (r *PostPostgres) GetDetailsByIDs(ctx context.Context, ids []int, viewerID int) ([]models.PostDetails, error) {
+	var posts []models.PostDetails
 
-	// Выполняем запрос для подсчета общего количества
-	if err := r.db.GetContext(ctx, &total, countQuery, params[:paramIndex-1]...); err != nil {
-		return nil, 0, fmt.Errorf("failed to count posts: %w", err)
-	}
+	query, params := postDetailsQuery("posts p", " WHERE p.id = ANY($1) AND p.deleted_at IS NULL", []interface{}{pq.Array(ids)}, viewerID, "0")
 
-	// Выполняем запрос для получения постов
 	if err := r.db.SelectContext(ctx, &posts, query, params...); err != nil {
-		return nil, 0, fmt.Errorf("failed to get posts: %w", err)
+		return nil, fmt.Errorf("failed to get posts: %w", err)
 	}
 
-	return posts, total, nil
+	return posts, nil
 }
 
-// GetByUserID получает посты пользователя
-func (r *PostPostgres) GetByUserID(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error) {
-	// Устанавливаем ID пользователя в фильтр
-	// Используем метод GetAll с обновленным фильтром
-	return r.GetAll(ctx, filter)
-}
-
-// GetLikedByUserID получает посты, лайкнутые пользователем
-func (r *PostPostgres) GetLikedByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.Post, int, error) {
-	var posts []models.Post
-	var total int
+// GetRelatedCandidates получает опубликованные посты, похожие на указанный:
+// из той же категории, того же автора или лайкнутые теми же пользователями
+func (r *PostPostgres) GetRelatedCandidates(ctx context.Context, post models.Post, limit int) ([]models.RelatedCandidate, error) {
+	var candidates []models.RelatedCandidate
 
-	// Базовый запрос
 	query := `
-		SELECT p.id, p.user_id, p.category_id, p.title, p.description, p.media_path, p.media_type, p.status, p.reject_reason,
-			p.created_at, p.updated_at, (SELECT COUNT(*) FROM likes WHERE post_id = p.id) as likes_count 
+		SELECT p.id, p.user_id, p.category_id, p.likes_count, p.published_at, COALESCE(cl.co_likes, 0) AS co_likes
 		FROM posts p
-		JOIN likes l ON p.id = l.post_id
-		WHERE l.user_id = $1 AND p.status = 'approved'
+		LEFT JOIN (
+			SELECT l2.post_id, COUNT(*) AS co_likes
+			FROM likes l1
+			JOIN likes l2 ON l2.user_id = l1.user_id AND l2.post_id <> l1.post_id
+			WHERE l1.post_id = $1
+			GROUP BY l2.post_id
+		) cl ON cl.post_id = p.id
+		WHERE p.status = 'approved'
+			AND p.deleted_at IS NULL
+			AND p.id <> $1
+			AND (cl.post_id IS NOT NULL OR p.category_id = $2 OR p.user_id = $3)
+		ORDER BY co_likes DESC, p.published_at DESC
+		LIMIT $4
 	`
 
-	// Запрос для подсчета общего количества
-	countQuery := `
-		SELECT COUNT(*) 
-		FROM posts p
-		JOIN likes l ON p.id = l.post_id
-		WHERE l.user_id = $1 AND p.status = 'approved'
-	`
+	if err := r.db.SelectContext(ctx, &candidates, query, post.ID, post.CategoryID, post.UserID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get related candidates: %w", err)
+	}
 
-	// Параметры
-	var params []interface{}
-	params = append(params, userID)
-	paramIndex := 2
+	return candidates, nil
+}
 
-	// Добавляем фильтры
-	if filter.CategoryID != 0 {
-		query += fmt.Sprintf(" AND p.category_id = $%d", paramIndex)
-		countQuery += fmt.Sprintf(" AND p.category_id = $%d", paramIndex)
-		params = append(params, filter.CategoryID)
-		paramIndex++
-	}
+// GetAll получает все опубликованные посты с фильтрацией и пагинацией
+func (r *PostPostgres) GetAll(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	// В PostFilter больше нет Status, поэтому добавляем по умолчанию approved
+	where, params := postFilterConditions(" WHERE p.status = $1", []interface{}{"approved"}, filter)
 
-	if filter.SearchQuery != "" {
-		query += fmt.Sprintf(" AND (p.title ILIKE $%d OR p.description ILIKE $%d)", paramIndex, paramIndex)
-		countQuery += fmt.Sprintf(" AND (p.title ILIKE $%d OR p.description ILIKE $%d)", paramIndex, paramIndex)
-		params = append(params, "%"+filter.SearchQuery+"%")
-		paramIndex++
-	}
+	return r.selectFeed(ctx, "posts p", where, params, filter)
+}
 
-	// Сортировка
-	query += " ORDER BY "
-	switch filter.SortBy {
-	case "popularity":
-		query += "likes_count"
-	case "date":
-		fallthrough
-	default:
-		query += "p.created_at"
-	}
+// GetByUserID получает опубликованные посты пользователя, включая работы,
+// в которых он принял приглашение в соавторы
+func (r *PostPostgres) GetByUserID(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	userID := filter.UserID
+
+	// Автор проверяется вместе с соавторством, поэтому отдельное условие по user_id не нужно
+	conditions := filter
+	conditions.UserID = 0
+
+	where, params := postFilterConditions(`
+		WHERE p.status = 'approved'
+			AND (
+				p.user_id = $1
+				OR p.id IN (SELECT post_id FROM post_coauthors WHERE user_id = $1 AND status = 'accepted')
+			)`,
+		[]interface{}{userID},
+		conditions,
+	)
 
-	// Направление сортировки
-	if strings.ToLower(filter.SortOrder) == "asc" {
-		query += " ASC"
-	} else {
-		query += " DESC"
-	}
+	return r.selectFeed(ctx, "posts p", where, params, filter)
+}
 
-	// Пагинация
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+// GetLikedByUserID получает посты, лайкнутые пользователем
+func (r *PostPostgres) GetLikedByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	where, params := postFilterConditions(
+		" WHERE l.user_id = $1 AND p.status = 'approved'",
+		[]interface{}{userID},
+		filter,
+	)
 
-	// Выполняем запрос для подсчета общего количества
-	if err := r.db.GetContext(ctx, &total, countQuery, params[:paramIndex-1]...); err != nil {
-		return nil, 0, fmt.Errorf("failed to count liked posts: %w", err)
-	}
+	return r.selectFeed(ctx, "posts p JOIN likes l ON p.id = l.post_id", where, params, filter)
+}
 
-	// Выполняем запрос для получения постов
-	if err := r.db.SelectContext(ctx, &posts, query, params...); err != nil {
-		return nil, 0, fmt.Errorf("failed to get liked posts: %w", err)
-	}
+// GetFollowingFeed получает опубликованные посты авторов и категорий, на которые подписан пользователь.
+// Лента собирается при чтении: подзапросы по первичным ключам подписок
+// позволяют планировщику использовать индексы постов по автору и категории.
+func (r *PostPostgres) GetFollowingFeed(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	where, params := postFilterConditions(`
+		WHERE p.status = 'approved'
+			AND (
+				p.user_id IN (SELECT followee_id FROM user_follows WHERE follower_id = $1)
+				OR p.category_id IN (SELECT category_id FROM category_follows WHERE user_id = $1)
+			)`,
+		[]interface{}{userID},
+		filter,
+	)
 
-	return posts, total, nil
+	return r.selectFeed(ctx, "posts p", where, params, filter)
 }
 
 // GetPendingModeration получает посты, ожидающие модерации
-func (r *PostPostgres) GetPendingModeration(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error) {
-	// Устанавливаем статус в 'pending' и используем GetAll
-	// В новой модели PostFilter нет поля Status, поэтому мы должны изменить запрос
+func (r *PostPostgres) GetPendingModeration(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	where, params := postFilterConditions(" WHERE p.status = 'pending'", nil, filter)
 
-	var posts []models.Post
-	var total int
+	return r.selectFeed(ctx, "posts p", where, params, filter)
+}
 
-	// Базовый запрос
-	query := `
-		SELECT id, user_id, category_id, title, description, media_path, media_type, status, reject_reason,
-			created_at, updated_at, (SELECT COUNT(*) FROM likes WHERE post_id = posts.id) as likes_count 
-		FROM posts
-		WHERE status = 'pending'
-	`
+// GetDraftsByUserID получает черновики пользователя
+func (r *PostPostgres) GetDraftsByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	where, params := postFilterConditions(" WHERE p.user_id = $1 AND p.status = 'draft'", []interface{}{userID}, filter)
 
-	// Запрос для подсчета общего количества
-	countQuery := "SELECT COUNT(*) FROM posts WHERE status = 'pending'"
+	return r.selectFeed(ctx, "posts p", where, params, filter)
+}
 
-	// Параметры
-	var params []interface{}
-	var paramIndex int = 1
+// GetScheduledByUserID получает еще не опубликованные посты пользователя с назначенным временем публикации
+func (r *PostPostgres) GetScheduledByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	where, params := postFilterConditions(
+		" WHERE p.user_id = $1 AND p.publish_at IS NOT NULL AND p.status IN ('draft', 'pending', 'scheduled')",
+		[]interface{}{userID},
+		filter,
+	)
 
-	// Добавляем фильтры
-	if filter.CategoryID != 0 {
-		query += fmt.Sprintf(" AND category_id = $%d", paramIndex)
-		countQuery += fmt.Sprintf(" AND category_id = $%d", paramIndex)
-		params = append(params, filter.CategoryID)
-		paramIndex++
-	}
+	return r.selectFeed(ctx, "posts p", where, params, filter)
+}
 
-	if filter.UserID != 0 {
-		query += fmt.Sprintf(" AND user_id = $%d", paramIndex)
-		countQuery += fmt.Sprintf(" AND user_id = $%d", paramIndex)
-		params = append(params, filter.UserID)
-		paramIndex++
-	}
+// GetByCollectionID получает опубликованные посты коллекции в порядке, заданном ее владельцем
+func (r *PostPostgres) GetByCollectionID(ctx context.Context, collectionID int, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	var posts []models.PostDetails
+	var total int
 
-	if filter.SearchQuery != "" {
-		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", paramIndex, paramIndex)
-		countQuery += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", paramIndex, paramIndex)
-		params = append(params, "%"+filter.SearchQuery+"%")
-		paramIndex++
+	from := "collection_items ci JOIN posts p ON p.id = ci.post_id"
+	where := " WHERE ci.collection_id = $1 AND p.status = 'approved' AND p.deleted_at IS NULL"
+	params := []interface{}{collectionID}
+	where, params = postVisibilityConditions(where, params, filter)
+
+	countQuery := "SELECT COUNT(*) FROM " + from + where
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts: %w", err)
 	}
 
-	// Сортировка
-	query += " ORDER BY created_at DESC"
+	query, params := postDetailsQuery(from, where, params, filter.ViewerID, "0")
 
-	// Пагинация
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
+	// Порядок коллекции задается вручную, поэтому вместо курсора используется LIMIT/OFFSET
+	query += fmt.Sprintf(" ORDER BY ci.position, p.id LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
 	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
 
-	fmt.Printf("GetPendingModeration SQL: %s\n", query)
-	fmt.Printf("GetPendingModeration Params: %v\n", params)
+	if err := r.db.SelectContext(ctx, &posts, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	return posts, total, nil
+}
+
+// selectFeed выполняет запрос ленты: считает общее количество постов
+// (кроме режима курсора) и выбирает страницу вместе со связанными данными
+func (r *PostPostgres) selectFeed(ctx context.Context, from, where string, params []interface{}, filter models.PostFilter) ([]models.PostDetails, int, error) {
+	var posts []models.PostDetails
+	var total int
+
+	// Посты из корзины не попадают ни в одну ленту
+	where += " AND p.deleted_at IS NULL"
 
-	// Выполняем запрос для подсчета общего количества
-	if err := r.db.GetContext(ctx, &total, countQuery, params[:paramIndex-1]...); err != nil {
-		return nil, 0, fmt.Errorf("failed to count pending posts: %w", err)
+	// Для сортировки trending в ленту попадают только посты с рассчитанной оценкой за период
+	scoreExpr := "0"
+	if sortBy, _ := filter.NormalizedSort(); sortBy == "trending" {
+		period := filter.Period
+		if period == "" {
+			period = "week"
+		}
+
+		from += fmt.Sprintf(" JOIN post_trending_scores ts ON ts.post_id = p.id AND ts.period = $%d", len(params)+1)
+		params = append(params, period)
+		scoreExpr = "ts.score"
 	}
 
-	fmt.Printf("GetPendingModeration Total: %d\n", total)
+	// Выполняем запрос для подсчета общего количества (в режиме курсора не нужен)
+	if filter.Cursor == "" {
+		countQuery := "SELECT COUNT(*) FROM " + from + where
+		if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+			return nil, 0, fmt.Errorf("failed to count posts: %w", err)
+		}
+	}
 
-	// Выполняем запрос для получения постов
-	if err := r.db.SelectContext(ctx, &posts, query, params...); err != nil {
-		return nil, 0, fmt.Errorf("failed to get pending posts: %w", err)
+	query, params := postDetailsQuery(from, where, params, filter.ViewerID, scoreExpr)
+
+	// Сортировка и пагинация
+	query, params, err := applyFeedPaging(query, params, filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	fmt.Printf("GetPendingModeration Retrieved posts: %d\n", len(posts))
-	for i, post := range posts {
-		fmt.Printf("Post %d: ID=%d, Title=%s, Status=%s\n", i+1, post.ID, post.Title, post.Status)
+	// Выполняем запрос для получения постов
+	if err := r.db.SelectContext(ctx, &posts, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get posts: %w", err)
 	}
 
 	return posts, total, nil
 }
 
-// Update обновляет пост
-func (r *PostPostgres) Update(ctx context.Context, post models.Post) error {
+// Update обновляет пост и, если передана правка, сохраняет ее в истории в той же транзакции.
+// Непустой статус поста заменяет текущий, например при возврате на модерацию
+func (r *PostPostgres) Update(ctx context.Context, post models.Post, revision *models.PostRevision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE posts
 		SET title = COALESCE(NULLIF($1, ''), title),
 			description = COALESCE(NULLIF($2, ''), description),
 			category_id = COALESCE(NULLIF($3, 0), category_id),
-			updated_at = $4
-		WHERE id = $5
+			publish_at = COALESCE($4, publish_at),
+			status = COALESCE(NULLIF($5, ''), status),
+			reject_reason = CASE WHEN $5 = '' THEN reject_reason ELSE NULL END,
+			submitted_at = CASE WHEN $5 = 'pending' AND status <> 'pending' THEN $9 ELSE submitted_at END,
+			license = COALESCE(NULLIF($6, ''), license),
+			source_url = COALESCE($7, source_url),
+			credit_url = COALESCE($8, credit_url),
+			updated_at = $9
+		WHERE id = $10
 	`
 
-	_, err := r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		post.Title,
 		post.Description,
 		post.CategoryID,
+		post.PublishAt,
+		post.Status,
+		post.License,
+		post.SourceURL,
+		post.CreditURL,
 		post.UpdatedAt,
 		post.ID,
 	)
@@ -337,11 +373,99 @@ func (r *PostPostgres) Update(ctx context.Context, post models.Post) error {
 		return fmt.Errorf("failed to update post: %w", err)
 	}
 
+	// Список инструментов заменяется целиком, только если он передан
+	if post.ToolIDs != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM post_tools WHERE post_id = $1`, post.ID); err != nil {
+			return fmt.Errorf("failed to clear post tools: %w", err)
+		}
+
+		if err := replacePostTools(ctx, tx, post.ID, post.ToolIDs); err != nil {
+			return err
+		}
+	}
+
+	if revision != nil {
+		revisionQuery := `
+			INSERT INTO post_revisions (post_id, editor_id, changes, previous_status, remoderated, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+
+		_, err = tx.ExecContext(
+			ctx,
+			revisionQuery,
+			revision.PostID,
+			revision.EditorID,
+			revision.Changes,
+			revision.PreviousStatus,
+			revision.Remoderated,
+			revision.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save post revision: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-// UpdateStatus обновляет статус поста
-func (r *PostPostgres) UpdateStatus(ctx context.Context, id int, status string, moderatorId int, rejectReason string) error {
+// GetToolIDs получает ID инструментов, указанных в посте
+func (r *PostPostgres) GetToolIDs(ctx context.Context, postID int) ([]int, error) {
+	var ids []int
+
+	query := `SELECT tool_id FROM post_tools WHERE post_id = $1 ORDER BY tool_id`
+
+	if err := r.db.SelectContext(ctx, &ids, query, postID); err != nil {
+		return nil, fmt.Errorf("failed to get post tools: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetCounts получает счетчики лайков и видимых комментариев поста
+func (r *PostPostgres) GetCounts(ctx context.Context, id int) (models.PostCounts, error) {
+	var counts models.PostCounts
+
+	query := `
+		SELECT p.id, p.likes_count,
+			(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.deleted_at IS NULL AND c.hidden_at IS NULL) AS comments_count
+		FROM posts p
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`
+
+	if err := r.db.GetContext(ctx, &counts, query, id); err != nil {
+		return models.PostCounts{}, fmt.Errorf("post not found: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetRevisions получает историю правок поста от новых к старым вместе с данными редакторов
+func (r *PostPostgres) GetRevisions(ctx context.Context, postID int) ([]models.PostRevisionDetails, error) {
+	var revisions []models.PostRevisionDetails
+
+	query := `
+		SELECT pr.id, pr.post_id, pr.editor_id, pr.changes, pr.previous_status, pr.remoderated, pr.created_at,
+			u.username AS editor_username, u.nickname AS editor_nickname, u.avatar AS editor_avatar
+		FROM post_revisions pr
+		JOIN users u ON u.id = pr.editor_id
+		WHERE pr.post_id = $1
+		ORDER BY pr.created_at DESC, pr.id DESC
+	`
+
+	if err := r.db.SelectContext(ctx, &revisions, query, postID); err != nil {
+		return nil, fmt.Errorf("failed to get post revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// UpdateStatus обновляет статус поста и запоминает модератора, принявшего решение.
+// Запись журнала модерации сохраняется в той же транзакции
+func (r *PostPostgres) UpdateStatus(ctx context.Context, id int, status string, moderatorId int, rejectReason string, action *models.ModerationAction) error {
 	var query string
 	var args []interface{}
 
@@ -351,38 +475,364 @@ func (r *PostPostgres) UpdateStatus(ctx context.Context, id int, status string,
 			UPDATE posts
 			SET status = $1,
 				reject_reason = $2,
+				moderated_at = $3,
+				moderated_by = $4,
 				updated_at = $3
-			WHERE id = $4
+			WHERE id = $5
 		`
-		args = []interface{}{status, rejectReason, time.Now(), id}
+		args = []interface{}{status, rejectReason, time.Now(), moderatorId, id}
 	} else {
-		// Если статус "approved" или причина отклонения пуста
+		// Если статус "approved" или причина отклонения пуста. Время публикации
+		// запоминается при первом одобрении, повторная модерация его не меняет
+		now := time.Now()
+		var publishedAt *time.Time
+		if status == "approved" {
+			publishedAt = &now
+		}
+
 		query = `
 			UPDATE posts
 			SET status = $1,
 				reject_reason = NULL,
+				moderated_at = $2,
+				moderated_by = $3,
+				published_at = COALESCE(published_at, $4),
 				updated_at = $2
-			WHERE id = $3
+			WHERE id = $5
 		`
-		args = []interface{}{status, time.Now(), id}
+		args = []interface{}{status, now, moderatorId, publishedAt, id}
 	}
 
-	_, err := r.db.ExecContext(ctx, query, args...)
-	if err != nil {
+	if _, err := execModeration(ctx, r.db, action, query, args...); err != nil {
 		return fmt.Errorf("failed to update post status: %w", err)
 	}
 
 	return nil
 }
 
-// Delete удаляет пост
-func (r *PostPostgres) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM posts WHERE id = $1`
+// Submit отправляет черновик на модерацию.
+// Возвращает false, если пост уже не является черновиком
+func (r *PostPostgres) Submit(ctx context.Context, id int) (bool, error) {
+	query := `
+		UPDATE posts
+		SET status = 'pending',
+			submitted_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND status = 'draft'
+	`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to submit post: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to submit post: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// SetHidden скрывает опубликованный пост по жалобам (статус hidden) или возвращает
+// скрытый пост в публикацию. Возвращает false, если пост не в исходном статусе.
+// Запись журнала модерации сохраняется, только если статус изменился
+func (r *PostPostgres) SetHidden(ctx context.Context, id int, hidden bool, action *models.ModerationAction) (bool, error) {
+	from, to := "hidden", "approved"
+	if hidden {
+		from, to = to, from
+	}
+
+	query := `
+		UPDATE posts
+		SET status = $1,
+			updated_at = $2
+		WHERE id = $3 AND status = $4 AND deleted_at IS NULL
+	`
+
+	affected, err := execModeration(ctx, r.db, action, query, to, time.Now(), id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update post visibility: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// SubmitDueDrafts отправляет на модерацию черновики, время публикации которых наступило
+func (r *PostPostgres) SubmitDueDrafts(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE posts
+		SET status = 'pending',
+			submitted_at = $1,
+			updated_at = $1
+		WHERE status = 'draft' AND publish_at <= $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit due drafts: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// PublishDue публикует одобренные отложенные посты, время публикации которых наступило.
+// Лента и тренды упорядочены по published_at, поэтому пост появляется в начале ленты,
+// а время создания остается прежним
+func (r *PostPostgres) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE posts
+		SET status = 'approved',
+			published_at = COALESCE(published_at, publish_at),
+			updated_at = $1
+		WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Delete перемещает пост в корзину. Удаление модератором сохраняется
+// в журнале модерации в той же транзакции
+func (r *PostPostgres) Delete(ctx context.Context, id int, deletedBy int, action *models.ModerationAction) error {
+	query := `
+		UPDATE posts
+		SET deleted_at = $1,
+			deleted_by = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	_, err := execModeration(ctx, r.db, action, query, time.Now(), deletedBy, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
 
 	return nil
 }
+
+// Restore восстанавливает пост из корзины
+func (r *PostPostgres) Restore(ctx context.Context, id int) error {
+	query := `
+		UPDATE posts
+		SET deleted_at = NULL,
+			deleted_by = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore post: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет посты, перемещенные в корзину раньше before.
+// Лайки и комментарии удаляются каскадно, возвращаются пути медиафайлов удаленных постов
+func (r *PostPostgres) PurgeDeleted(ctx context.Context, before time.Time) ([]string, error) {
+	var mediaPaths []string
+
+	query := `DELETE FROM posts WHERE deleted_at < $1 RETURNING media_path`
+
+	if err := r.db.SelectContext(ctx, &mediaPaths, query, before); err != nil {
+		return nil, fmt.Errorf("failed to purge deleted posts: %w", err)
+	}
+
+	return mediaPaths, nil
+}
+
+// postDetailsQuery формирует SELECT постов вместе с автором, категорией, соавторами
+// и отметкой лайка текущего пользователя одним запросом.
+// ID зрителя добавляется последним параметром после условий отбора,
+// scoreExpr задает значение столбца sort_score.
+func postDetailsQuery(from, where string, params []interface{}, viewerID int, scoreExpr string) (string, []interface{}) {
+	query := fmt.Sprintf(`
+		SELECT p.id, p.user_id, p.category_id, p.title, p.description, p.media_path, p.media_type, p.status, p.reject_reason,
+			p.publish_at, p.license, p.source_url, p.credit_url, p.published_at, p.created_at, p.updated_at, p.likes_count, p.views_count,
+			u.username AS author_username, u.nickname AS author_nickname, u.avatar AS author_avatar,
+			c.name AS category_name, c.slug AS category_slug,
+			EXISTS (SELECT 1 FROM likes vl WHERE vl.post_id = p.id AND vl.user_id = $%[1]d) AS is_liked,
+			p.reaction_counts,
+			COALESCE((
+				SELECT json_agg(vr.type ORDER BY vr.type)
+				FROM post_reactions vr
+				WHERE vr.post_id = p.id AND vr.user_id = $%[1]d
+			), '[]') AS viewer_reactions,
+			%[2]s AS sort_score,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'id', cu.id, 'username', cu.username, 'nickname', cu.nickname,
+					'avatar', COALESCE(cu.avatar, ''), 'role', pc.role
+				) ORDER BY pc.responded_at, cu.id)
+				FROM post_coauthors pc
+				JOIN users cu ON cu.id = pc.user_id
+				WHERE pc.post_id = p.id AND pc.status = 'accepted'
+			), '[]') AS coauthors,
+			COALESCE((
+				SELECT json_agg(json_build_object('id', t.id, 'name', t.name, 'slug', t.slug) ORDER BY t.name)
+				FROM post_tools pt
+				JOIN tools t ON t.id = pt.tool_id
+				WHERE pt.post_id = p.id
+			), '[]') AS tools,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'user_id', mu.id, 'username', mu.username, 'nickname', mu.nickname,
+					'offset', m.text_offset, 'length', m.text_length
+				) ORDER BY m.text_offset)
+				FROM mentions m
+				JOIN users mu ON mu.id = m.mentioned_user_id
+				WHERE m.post_id = p.id AND m.comment_id IS NULL
+			), '[]') AS mentions
+		FROM %[3]s
+		JOIN users u ON u.id = p.user_id
+		JOIN categories c ON c.id = p.category_id
+	`, len(params)+1, scoreExpr, from)
+
+	return query + where, append(params, viewerID)
+}
+
+// postFilterConditions дописывает к условию WHERE фильтры из PostFilter
+func postFilterConditions(where string, params []interface{}, filter models.PostFilter) (string, []interface{}) {
+	if filter.CategoryID != 0 {
+		where += fmt.Sprintf(" AND p.category_id = $%d", len(params)+1)
+		params = append(params, filter.CategoryID)
+	}
+
+	if filter.UserID != 0 {
+		where += fmt.Sprintf(" AND p.user_id = $%d", len(params)+1)
+		params = append(params, filter.UserID)
+	}
+
+	if filter.SearchQuery != "" {
+		where += fmt.Sprintf(" AND (p.title ILIKE $%d OR p.description ILIKE $%d)", len(params)+1, len(params)+1)
+		params = append(params, "%"+filter.SearchQuery+"%")
+	}
+
+	if filter.ToolID != 0 {
+		where += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM post_tools pt WHERE pt.post_id = p.id AND pt.tool_id = $%d)", len(params)+1)
+		params = append(params, filter.ToolID)
+	}
+
+	if filter.License != "" {
+		where += fmt.Sprintf(" AND p.license = $%d", len(params)+1)
+		params = append(params, filter.License)
+	}
+
+	where, params = postVisibilityConditions(where, params, filter)
+
+	return where, params
+}
+
+// postVisibilityConditions скрывает посты, автор или принявший приглашение соавтор которых
+// связан с текущим пользователем блокировкой в любую сторону, а при HideMuted — и посты
+// заглушенных им авторов
+func postVisibilityConditions(where string, params []interface{}, filter models.PostFilter) (string, []interface{}) {
+	if filter.ViewerID == 0 {
+		return where, params
+	}
+
+	n := len(params) + 1
+	where += fmt.Sprintf(` AND NOT EXISTS (
+		SELECT 1 FROM user_blocks ub
+		WHERE (ub.blocker_id = p.user_id AND ub.blocked_id = $%d)
+			OR (ub.blocker_id = $%d AND ub.blocked_id = p.user_id)
+	) AND NOT EXISTS (
+		SELECT 1 FROM post_coauthors bpc
+		JOIN user_blocks ub
+			ON (ub.blocker_id = bpc.user_id AND ub.blocked_id = $%d)
+			OR (ub.blocker_id = $%d AND ub.blocked_id = bpc.user_id)
+		WHERE bpc.post_id = p.id AND bpc.status = 'accepted'
+	)`, n, n, n, n)
+
+	if filter.HideMuted {
+		where += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM user_mutes um WHERE um.muter_id = $%d AND um.muted_id = p.user_id)", n)
+	}
+
+	params = append(params, filter.ViewerID)
+
+	return where, params
+}
+
+// replacePostTools сохраняет инструменты поста внутри транзакции
+func replacePostTools(ctx context.Context, tx *sqlx.Tx, postID int, toolIDs []int) error {
+	if len(toolIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO post_tools (post_id, tool_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, query, postID, pq.Array(toolIDs)); err != nil {
+		return fmt.Errorf("failed to save post tools: %w", err)
+	}
+
+	return nil
+}
+
+// postFeedTime время поста для сортировки по дате: время публикации,
+// а для черновиков и постов на модерации — время создания
+const postFeedTime = "COALESCE(p.published_at, p.created_at)"
+
+// applyFeedPaging дописывает к запросу ленты сортировку и пагинацию.
+// Если в фильтре указан курсор, используется keyset-пагинация по паре
+// (значение сортировки, id), иначе классическая LIMIT/OFFSET.
+// Запрос должен обращаться к таблице постов через псевдоним p,
+// а для сортировки trending — к таблице оценок через псевдоним ts.
+func applyFeedPaging(query string, params []interface{}, filter models.PostFilter) (string, []interface{}, error) {
+	sortBy, sortOrder := filter.NormalizedSort()
+
+	sortColumn := postFeedTime
+	switch sortBy {
+	case "popularity":
+		sortColumn = "p.likes_count"
+	case "trending":
+		sortColumn = "ts.score"
+	}
+
+	direction, comparison := "DESC", "<"
+	if sortOrder == "asc" {
+		direction, comparison = "ASC", ">"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := models.DecodeFeedCursor(filter.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Курсор должен соответствовать текущей сортировке, иначе позиция не имеет смысла
+		if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder || cursor.Period != filter.Period {
+			return "", nil, models.ErrInvalidCursor
+		}
+
+		var cursorValue interface{} = cursor.CreatedAt
+		switch sortBy {
+		case "popularity":
+			cursorValue = cursor.LikesCount
+		case "trending":
+			cursorValue = cursor.Score
+		}
+
+		query += fmt.Sprintf(" AND (%s, p.id) %s ($%d, $%d)", sortColumn, comparison, len(params)+1, len(params)+2)
+		params = append(params, cursorValue, cursor.ID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, p.id %s", sortColumn, direction, direction)
+
+	if filter.Cursor != "" {
+		query += fmt.Sprintf(" LIMIT $%d", len(params)+1)
+		params = append(params, filter.PerPage)
+	} else {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
+		params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	}
+
+	return query, params, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ReactionPostgres struct {
+	db *sqlx.DB
+}
+
+func NewReactionPostgres(db *sqlx.DB) *ReactionPostgres {
+	return &ReactionPostgres{db: db}
+}
+
+// reactionTable возвращает таблицу реакций и столбец объекта для поста или комментария
+func reactionTable(target string) (string, string, error) {
+	switch target {
+	case models.ReactionTargetPost:
+		return "post_reactions", "post_id", nil
+	case models.ReactionTargetComment:
+		return "comment_reactions", "comment_id", nil
+	default:
+		return "", "", fmt.Errorf("unknown reaction target: %s", target)
+	}
+}
+
+// Add ставит реакцию. Возвращает false, если такая реакция уже стоит
+func (r *ReactionPostgres) Add(ctx context.Context, target string, targetID int, userID int, reactionType string, createdAt time.Time) (bool, error) {
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (%s, user_id, type, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`, table, column)
+
+	result, err := r.db.ExecContext(ctx, query, targetID, userID, reactionType, createdAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Remove снимает реакцию
+func (r *ReactionPostgres) Remove(ctx context.Context, target string, targetID int, userID int, reactionType string) error {
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND user_id = $2 AND type = $3`, table, column)
+
+	result, err := r.db.ExecContext(ctx, query, targetID, userID, reactionType)
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reaction not found")
+	}
+
+	return nil
+}
+
+// GetUsers получает страницу пользователей, поставивших реакцию указанного типа, новые сверху.
+// Пользователи, связанные со зрителем блокировкой в любую сторону, не показываются
+func (r *ReactionPostgres) GetUsers(ctx context.Context, target string, targetID int, reactionType string, viewerID int, limit, offset int) ([]models.ReactionUser, int, error) {
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := fmt.Sprintf(`
+		WHERE r.%s = $1 AND r.type = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM user_blocks ub
+				WHERE (ub.blocker_id = r.user_id AND ub.blocked_id = $3)
+					OR (ub.blocker_id = $3 AND ub.blocked_id = r.user_id)
+			)
+	`, column)
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s r`, table) + where
+	if err := r.db.GetContext(ctx, &total, countQuery, targetID, reactionType, viewerID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	var users []models.ReactionUser
+	query := fmt.Sprintf(`
+		SELECT u.id AS user_id, u.username, u.nickname, u.avatar, r.created_at
+		FROM %s r
+		JOIN users u ON u.id = r.user_id
+	`, table) + where + `
+		ORDER BY r.created_at DESC, u.id DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	if err := r.db.SelectContext(ctx, &users, query, targetID, reactionType, viewerID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get reaction users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetByUserCommentIDs получает реакции пользователя на комментарии из списка
+func (r *ReactionPostgres) GetByUserCommentIDs(ctx context.Context, userID int, commentIDs []int) ([]models.ViewerReaction, error) {
+	var reactions []models.ViewerReaction
+
+	query := `
+		SELECT comment_id AS target_id, type
+		FROM comment_reactions
+		WHERE user_id = $1 AND comment_id = ANY($2)
+		ORDER BY type
+	`
+
+	if err := r.db.SelectContext(ctx, &reactions, query, userID, pq.Array(commentIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get comment reactions: %w", err)
+	}
+
+	return reactions, nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ReportPostgres struct {
+	db *sqlx.DB
+}
+
+func NewReportPostgres(db *sqlx.DB) *ReportPostgres {
+	return &ReportPostgres{db: db}
+}
+
+// Create сохраняет жалобу. Возвращает false, если пользователь уже жаловался на этот объект
+func (r *ReportPostgres) Create(ctx context.Context, report models.Report) (bool, error) {
+	query := `
+		INSERT INTO reports (reporter_id, target_type, target_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (reporter_id, target_type, target_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		report.ReporterID, report.TargetType, report.TargetID, report.Reason, report.Details, report.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CountOpen получает число открытых жалоб на объект. Жалобы уникальны по автору,
+// поэтому это число независимых пользователей, пожаловавшихся на объект
+func (r *ReportPostgres) CountOpen(ctx context.Context, targetType string, targetID int) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(*) FROM reports WHERE target_type = $1 AND target_id = $2 AND status = $3`
+
+	if err := r.db.GetContext(ctx, &count, query, targetType, targetID, models.ReportStatusOpen); err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetGroups получает страницу очереди жалоб, сгруппированных по объекту.
+// Сверху объекты с наибольшим числом жалоб, при равенстве — с самой свежей жалобой
+func (r *ReportPostgres) GetGroups(ctx context.Context, filter models.ReportFilter) ([]models.ReportGroup, int, error) {
+	where := " WHERE r.status = $1"
+	params := []interface{}{filter.Status}
+
+	if filter.TargetType != "" {
+		where += fmt.Sprintf(" AND r.target_type = $%d", len(params)+1)
+		params = append(params, filter.TargetType)
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(DISTINCT (r.target_type, r.target_id)) FROM reports r" + where
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	// Объект может быть уже удален окончательно, поэтому данные о нем необязательны
+	query := `
+		WITH grouped AS (
+			SELECT r.target_type, r.target_id,
+				COUNT(*) AS reports_count,
+				json_agg(DISTINCT r.reason) AS reasons,
+				MIN(r.created_at) AS first_reported_at,
+				MAX(r.created_at) AS last_reported_at
+			FROM reports r` + where + `
+			GROUP BY r.target_type, r.target_id
+		)
+		SELECT g.target_type, g.target_id, g.reports_count, g.reasons, g.first_reported_at, g.last_reported_at,
+			COALESCE(p.id, c.post_id) AS post_id,
+			COALESCE(p.title, c.content) AS preview,
+			u.id AS author_id, u.username AS author_username, u.nickname AS author_nickname, u.avatar AS author_avatar,
+			COALESCE(p.status = 'hidden', c.hidden_at IS NOT NULL, false) AS hidden
+		FROM grouped g
+		LEFT JOIN posts p ON g.target_type = 'post' AND p.id = g.target_id
+		LEFT JOIN comments c ON g.target_type = 'comment' AND c.id = g.target_id
+		LEFT JOIN users u ON u.id = COALESCE(p.user_id, c.user_id)
+	`
+	query += fmt.Sprintf(" ORDER BY g.reports_count DESC, g.last_reported_at DESC, g.target_id DESC LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
+	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	var groups []models.ReportGroup
+	if err := r.db.SelectContext(ctx, &groups, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	return groups, total, nil
+}
+
+// GetByTarget получает все жалобы на объект, новые сверху
+func (r *ReportPostgres) GetByTarget(ctx context.Context, targetType string, targetID int) ([]models.ReportDetails, error) {
+	var reports []models.ReportDetails
+
+	query := `
+		SELECT r.id, r.reporter_id, r.target_type, r.target_id, r.reason, r.details, r.status,
+			r.resolution, r.resolved_by, r.resolved_at, r.created_at,
+			u.username AS reporter_username, u.nickname AS reporter_nickname, u.avatar AS reporter_avatar
+		FROM reports r
+		JOIN users u ON u.id = r.reporter_id
+		WHERE r.target_type = $1 AND r.target_id = $2
+		ORDER BY r.created_at DESC, r.id DESC
+	`
+
+	if err := r.db.SelectContext(ctx, &reports, query, targetType, targetID); err != nil {
+		return nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// Resolve закрывает открытые жалобы на объект решением модератора и сохраняет
+// запись журнала модерации в той же транзакции. Возвращает число закрытых жалоб
+func (r *ReportPostgres) Resolve(ctx context.Context, targetType string, targetID int, resolution string, moderatorID int, resolvedAt time.Time, action *models.ModerationAction) (int64, error) {
+	query := `
+		UPDATE reports
+		SET status = $1,
+			resolution = $2,
+			resolved_by = $3,
+			resolved_at = $4
+		WHERE target_type = $5 AND target_id = $6 AND status = $7
+	`
+
+	affected, err := execModeration(ctx, r.db, action, query,
+		models.ReportStatusResolved, resolution, moderatorID, resolvedAt, targetType, targetID, models.ReportStatusOpen)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve reports: %w", err)
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ToolPostgres struct {
+	db *sqlx.DB
+}
+
+func NewToolPostgres(db *sqlx.DB) *ToolPostgres {
+	return &ToolPostgres{db: db}
+}
+
+// Create добавляет инструмент в каталог
+func (r *ToolPostgres) Create(ctx context.Context, tool models.Tool) (int, error) {
+	var id int
+
+	query := `
+		INSERT INTO tools (name, slug, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	row := r.db.QueryRowContext(ctx, query, tool.Name, tool.Slug, tool.CreatedAt, tool.UpdatedAt)
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to create tool: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID получает инструмент по ID
+func (r *ToolPostgres) GetByID(ctx context.Context, id int) (models.Tool, error) {
+	var tool models.Tool
+
+	query := `
+		SELECT id, name, slug, created_at, updated_at
+		FROM tools
+		WHERE id = $1
+	`
+
+	if err := r.db.GetContext(ctx, &tool, query, id); err != nil {
+		return models.Tool{}, fmt.Errorf("tool not found: %w", err)
+	}
+
+	return tool, nil
+}
+
+// GetAll получает весь каталог инструментов
+func (r *ToolPostgres) GetAll(ctx context.Context) ([]models.Tool, error) {
+	var tools []models.Tool
+
+	query := `
+		SELECT id, name, slug, created_at, updated_at
+		FROM tools
+		ORDER BY name ASC
+	`
+
+	if err := r.db.SelectContext(ctx, &tools, query); err != nil {
+		return nil, fmt.Errorf("failed to get tools: %w", err)
+	}
+
+	return tools, nil
+}
+
+// CountExisting возвращает, сколько из указанных ID есть в каталоге
+func (r *ToolPostgres) CountExisting(ctx context.Context, ids []int) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(*) FROM tools WHERE id = ANY($1)`
+
+	if err := r.db.GetContext(ctx, &count, query, pq.Array(ids)); err != nil {
+		return 0, fmt.Errorf("failed to check tools: %w", err)
+	}
+
+	return count, nil
+}
+
+// SlugExistsExcept проверяет существование инструмента с указанным slug, исключая инструмент с указанным ID.
+// Для нового инструмента передается ID 0
+func (r *ToolPostgres) SlugExistsExcept(ctx context.Context, slug string, id int) (bool, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM tools
+		WHERE slug = $1 AND id != $2
+	`
+
+	if err := r.db.GetContext(ctx, &count, query, slug, id); err != nil {
+		return false, fmt.Errorf("failed to check slug existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// HasRelatedPosts проверяет, указан ли инструмент хотя бы в одном посте
+func (r *ToolPostgres) HasRelatedPosts(ctx context.Context, id int) (bool, error) {
+	var exists bool
+
+	query := `SELECT EXISTS (SELECT 1 FROM post_tools WHERE tool_id = $1)`
+
+	if err := r.db.GetContext(ctx, &exists, query, id); err != nil {
+		return false, fmt.Errorf("failed to check related posts: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Update обновляет инструмент
+func (r *ToolPostgres) Update(ctx context.Context, tool models.Tool) error {
+	query := `
+		UPDATE tools
+		SET name = $1, slug = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, tool.Name, tool.Slug, tool.UpdatedAt, tool.ID); err != nil {
+		return fmt.Errorf("failed to update tool: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет инструмент из каталога
+func (r *ToolPostgres) Delete(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tools WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete tool: %w", err)
+	}
+
+	return nil
+}
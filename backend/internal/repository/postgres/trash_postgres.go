@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TrashPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTrashPostgres(db *sqlx.DB) *TrashPostgres {
+	return &TrashPostgres{db: db}
+}
+
+// GetItems получает удаленные посты и комментарии от недавно удаленных к давним.
+// Если в фильтре указан владелец, выбираются только его записи,
+// иначе вся корзина без чужих черновиков
+func (r *TrashPostgres) GetItems(ctx context.Context, filter models.TrashFilter) ([]models.TrashItem, int, error) {
+	var items []models.TrashItem
+	var total int
+	var params []interface{}
+
+	postWhere := " WHERE p.deleted_at IS NOT NULL"
+	commentWhere := " WHERE c.deleted_at IS NOT NULL"
+
+	if filter.UserID != 0 {
+		params = append(params, filter.UserID)
+		postWhere += " AND p.user_id = $1"
+		commentWhere += " AND c.user_id = $1"
+	} else {
+		postWhere += " AND p.status <> 'draft'"
+	}
+
+	var parts []string
+	if filter.Type == "" || filter.Type == "post" {
+		parts = append(parts, `
+			SELECT 'post' AS type, p.id, p.id AS post_id, p.user_id, p.title, p.deleted_at, p.deleted_by
+			FROM posts p`+postWhere)
+	}
+	if filter.Type == "" || filter.Type == "comment" {
+		parts = append(parts, `
+			SELECT 'comment' AS type, c.id, c.post_id, c.user_id, c.content AS title, c.deleted_at, c.deleted_by
+			FROM comments c`+commentWhere)
+	}
+
+	from := "("
+	for i, part := range parts {
+		if i > 0 {
+			from += " UNION ALL"
+		}
+		from += part
+	}
+	from += ") t"
+
+	countQuery := "SELECT COUNT(*) FROM " + from
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count trash items: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM %s ORDER BY t.deleted_at DESC, t.type, t.id DESC LIMIT $%d OFFSET $%d",
+		from, len(params)+1, len(params)+2,
+	)
+	params = append(params, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	if err := r.db.SelectContext(ctx, &items, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get trash items: %w", err)
+	}
+
+	return items, total, nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"designhub/internal/models"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TrendingPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTrendingPostgres(db *sqlx.DB) *TrendingPostgres {
+	return &TrendingPostgres{db: db}
+}
+
+// Recalculate пересчитывает оценки трендовых постов за период.
+// Оценка считается по формуле в стиле Hacker News: взвешенная активность
+// за окно делится на (возраст поста в часах + 2) в степени gravity.
+func (r *TrendingPostgres) Recalculate(ctx context.Context, params models.TrendingParams) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM post_trending_scores WHERE period = $1`, params.Period); err != nil {
+		return fmt.Errorf("failed to clear trending scores: %w", err)
+	}
+
+	query := `
+		INSERT INTO post_trending_scores (post_id, period, score, computed_at)
+		SELECT p.id, $1,
+			(COALESCE(l.cnt, 0) * $3 + COALESCE(cm.cnt, 0) * $4 + COALESCE(v.cnt, 0) * $6)
+				/ POWER(EXTRACT(EPOCH FROM (NOW() - p.published_at)) / 3600 + 2, $5),
+			NOW()
+		FROM posts p
+		LEFT JOIN (
+			SELECT post_id, COUNT(*) AS cnt FROM likes
+			WHERE created_at >= NOW() - $2 * INTERVAL '1 second'
+			GROUP BY post_id
+		) l ON l.post_id = p.id
+		LEFT JOIN (
+			SELECT post_id, COUNT(*) AS cnt FROM comments
+			WHERE created_at >= NOW() - $2 * INTERVAL '1 second' AND deleted_at IS NULL AND hidden_at IS NULL
+			GROUP BY post_id
+		) cm ON cm.post_id = p.id
+		LEFT JOIN (
+			SELECT post_id, SUM(views) AS cnt FROM post_daily_stats
+			WHERE day >= (NOW() - $2 * INTERVAL '1 second')::date
+			GROUP BY post_id
+		) v ON v.post_id = p.id
+		WHERE p.status = 'approved'
+			AND p.deleted_at IS NULL
+			AND (l.cnt IS NOT NULL OR cm.cnt IS NOT NULL OR v.cnt IS NOT NULL OR p.published_at >= NOW() - $2 * INTERVAL '1 second')
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		params.Period,
+		params.Window.Seconds(),
+		params.LikeWeight,
+		params.CommentWeight,
+		params.Gravity,
+		params.ViewWeight,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to calculate trending scores: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit trending scores: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // UserPostgres репозиторий для работы с пользователями в PostgreSQL
@@ -61,6 +62,36 @@ func (r *UserPostgres) GetByID(ctx context.Context, id int) (models.User, error)
 	return user, nil
 }
 
+// GetByIDs получает пользователей по списку ID одним запросом
+func (r *UserPostgres) GetByIDs(ctx context.Context, ids []int) ([]models.User, error) {
+	var users []models.User
+	query := `
+		SELECT * FROM users WHERE id = ANY($1)
+	`
+
+	err := r.db.SelectContext(ctx, &users, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("UserPostgres.GetByIDs: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetByNicknames получает пользователей, никнейм которых без учета регистра совпадает с одним из указанных
+func (r *UserPostgres) GetByNicknames(ctx context.Context, nicknames []string) ([]models.User, error) {
+	var users []models.User
+	query := `
+		SELECT * FROM users WHERE LOWER(nickname) = ANY($1)
+	`
+
+	err := r.db.SelectContext(ctx, &users, query, pq.Array(nicknames))
+	if err != nil {
+		return nil, fmt.Errorf("UserPostgres.GetByNicknames: %w", err)
+	}
+
+	return users, nil
+}
+
 // GetByEmail получает пользователя по email
 func (r *UserPostgres) GetByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
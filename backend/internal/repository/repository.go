@@ -4,6 +4,7 @@ import (
 	"context"
 	"designhub/internal/models"
 	"designhub/internal/repository/postgres"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -12,6 +13,8 @@ import (
 type User interface {
 	Create(ctx context.Context, user models.User) (int, error)
 	GetByID(ctx context.Context, id int) (models.User, error)
+	GetByIDs(ctx context.Context, ids []int) ([]models.User, error)
+	GetByNicknames(ctx context.Context, nicknames []string) ([]models.User, error)
 	GetByEmail(ctx context.Context, email string) (models.User, error)
 	Update(ctx context.Context, id int, user models.UserUpdate) error
 	UpdateAvatar(ctx context.Context, id int, avatarPath string) error
@@ -22,22 +25,45 @@ type User interface {
 type Post interface {
 	Create(ctx context.Context, post models.Post) (int, error)
 	GetByID(ctx context.Context, id int) (models.Post, error)
-	GetAll(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error)
-	GetByUserID(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error)
-	GetLikedByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.Post, int, error)
-	GetPendingModeration(ctx context.Context, filter models.PostFilter) ([]models.Post, int, error)
-	Update(ctx context.Context, post models.Post) error
-	UpdateStatus(ctx context.Context, id int, status string, moderatorId int, rejectReason string) error
-	Delete(ctx context.Context, id int) error
+	GetDetailsByID(ctx context.Context, id int, viewerID int) (models.PostDetails, error)
+	GetDetailsByIDs(ctx context.Context, ids []int, viewerID int) ([]models.PostDetails, error)
+	GetRelatedCandidates(ctx context.Context, post models.Post, limit int) ([]models.RelatedCandidate, error)
+	GetAll(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetByUserID(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetLikedByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetFollowingFeed(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetPendingModeration(ctx context.Context, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetDraftsByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetScheduledByUserID(ctx context.Context, userID int, filter models.PostFilter) ([]models.PostDetails, int, error)
+	GetByCollectionID(ctx context.Context, collectionID int, filter models.PostFilter) ([]models.PostDetails, int, error)
+	Update(ctx context.Context, post models.Post, revision *models.PostRevision) error
+	GetToolIDs(ctx context.Context, postID int) ([]int, error)
+	GetCounts(ctx context.Context, id int) (models.PostCounts, error)
+	GetRevisions(ctx context.Context, postID int) ([]models.PostRevisionDetails, error)
+	UpdateStatus(ctx context.Context, id int, status string, moderatorId int, rejectReason string, action *models.ModerationAction) error
+	SetHidden(ctx context.Context, id int, hidden bool, action *models.ModerationAction) (bool, error)
+	Submit(ctx context.Context, id int) (bool, error)
+	SubmitDueDrafts(ctx context.Context, now time.Time) (int64, error)
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	Delete(ctx context.Context, id int, deletedBy int, action *models.ModerationAction) error
+	GetDeletedByID(ctx context.Context, id int) (models.Post, error)
+	Restore(ctx context.Context, id int) error
+	PurgeDeleted(ctx context.Context, before time.Time) ([]string, error)
 }
 
 // Comment интерфейс репозитория для работы с комментариями
 type Comment interface {
 	Create(ctx context.Context, comment models.Comment) (int, error)
 	GetByID(ctx context.Context, id int) (models.Comment, error)
-	GetByPostID(ctx context.Context, postID int) ([]models.Comment, error)
+	GetByPostID(ctx context.Context, postID int, filter models.CommentFilter) ([]models.Comment, error)
+	CountByPostID(ctx context.Context, postID int) (int, error)
 	Update(ctx context.Context, id int, comment models.CommentUpdate) error
-	Delete(ctx context.Context, id int) error
+	SetResolved(ctx context.Context, id int, resolvedBy int, resolved bool) error
+	SetHidden(ctx context.Context, id int, hidden bool, action *models.ModerationAction) (bool, error)
+	Delete(ctx context.Context, id int, deletedBy int, action *models.ModerationAction) error
+	GetDeletedByID(ctx context.Context, id int) (models.Comment, error)
+	Restore(ctx context.Context, id int) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
 // Like интерфейс репозитория для работы с лайками
@@ -53,32 +79,215 @@ type Like interface {
 
 // Category интерфейс репозитория для работы с категориями
 type Category interface {
-	Create(ctx context.Context, category models.Category) (int, error)
+	Create(ctx context.Context, category models.Category, action *models.ModerationAction) (int, error)
 	GetByID(ctx context.Context, id int) (models.Category, error)
 	GetAll(ctx context.Context) ([]models.Category, error)
 	SlugExists(ctx context.Context, slug string) (bool, error)
 	SlugExistsExcept(ctx context.Context, slug string, id int) (bool, error)
 	HasRelatedPosts(ctx context.Context, id int) (bool, error)
-	Update(ctx context.Context, category models.Category) error
+	Update(ctx context.Context, category models.Category, action *models.ModerationAction) error
+	Delete(ctx context.Context, id int, action *models.ModerationAction) error
+}
+
+// Tool интерфейс репозитория для каталога инструментов
+type Tool interface {
+	Create(ctx context.Context, tool models.Tool) (int, error)
+	GetByID(ctx context.Context, id int) (models.Tool, error)
+	GetAll(ctx context.Context) ([]models.Tool, error)
+	CountExisting(ctx context.Context, ids []int) (int, error)
+	SlugExistsExcept(ctx context.Context, slug string, id int) (bool, error)
+	HasRelatedPosts(ctx context.Context, id int) (bool, error)
+	Update(ctx context.Context, tool models.Tool) error
 	Delete(ctx context.Context, id int) error
 }
 
+// Trending интерфейс репозитория для расчета трендовых постов
+type Trending interface {
+	Recalculate(ctx context.Context, params models.TrendingParams) error
+}
+
+// Analytics интерфейс репозитория для статистики просмотров и активности
+type Analytics interface {
+	AddViews(ctx context.Context, counts []models.PostViewCount) error
+	GetPostDailyStats(ctx context.Context, postID int, from time.Time) ([]models.PostDailyStat, error)
+	GetPostTopReferrers(ctx context.Context, postID int, from time.Time, limit int) ([]models.ReferrerStat, error)
+	GetCreatorSummary(ctx context.Context, userID int) (models.CreatorSummary, error)
+	GetCreatorDailyStats(ctx context.Context, userID int, from time.Time) ([]models.CreatorDailyStat, error)
+	GetCreatorTopPosts(ctx context.Context, userID int, from time.Time, limit int) ([]models.TopPostStat, error)
+}
+
+// Trash интерфейс репозитория для корзины удаленных постов и комментариев
+type Trash interface {
+	GetItems(ctx context.Context, filter models.TrashFilter) ([]models.TrashItem, int, error)
+}
+
+// Collection интерфейс репозитория для работы с коллекциями
+type Collection interface {
+	Create(ctx context.Context, collection models.Collection) (int, error)
+	GetByID(ctx context.Context, id int) (models.Collection, error)
+	GetDetailsByID(ctx context.Context, id int) (models.CollectionDetails, error)
+	GetByUserID(ctx context.Context, userID int, includePrivate bool) ([]models.CollectionDetails, error)
+	GetByPostID(ctx context.Context, postID int, viewerID int) ([]models.CollectionDetails, error)
+	Update(ctx context.Context, collection models.Collection) error
+	UpdateCover(ctx context.Context, id int, coverPath string) error
+	Delete(ctx context.Context, id int) error
+	AddItem(ctx context.Context, collectionID int, postID int) error
+	RemoveItem(ctx context.Context, collectionID int, postID int) error
+	GetVisiblePostIDs(ctx context.Context, collectionID int, viewerID int) ([]int, error)
+	Reorder(ctx context.Context, collectionID int, postIDs []int) error
+}
+
+// Coauthor интерфейс репозитория для работы с соавторами постов
+type Coauthor interface {
+	Invite(ctx context.Context, coauthor models.PostCoauthor) error
+	Get(ctx context.Context, postID int, userID int) (models.PostCoauthor, error)
+	GetByPostID(ctx context.Context, postID int) ([]models.PostCoauthorDetails, error)
+	GetInvitationsByUserID(ctx context.Context, userID int) ([]models.CoauthorInvitation, error)
+	Update(ctx context.Context, postID int, userID int, role string, canEdit bool) error
+	Respond(ctx context.Context, postID int, userID int, status string) (bool, error)
+	Delete(ctx context.Context, postID int, userID int) error
+	CanEdit(ctx context.Context, postID int, userID int) (bool, error)
+}
+
+// Mention интерфейс репозитория для упоминаний пользователей
+type Mention interface {
+	Replace(ctx context.Context, postID int, commentID *int, mentions []models.Mention) error
+	GetByCommentIDs(ctx context.Context, commentIDs []int) ([]models.MentionDetails, error)
+	NotifyPending(ctx context.Context, now time.Time) ([]models.Notification, error)
+}
+
+// Reaction интерфейс репозитория для реакций на посты и комментарии
+type Reaction interface {
+	Add(ctx context.Context, target string, targetID int, userID int, reactionType string, createdAt time.Time) (bool, error)
+	Remove(ctx context.Context, target string, targetID int, userID int, reactionType string) error
+	GetUsers(ctx context.Context, target string, targetID int, reactionType string, viewerID int, limit, offset int) ([]models.ReactionUser, int, error)
+	GetByUserCommentIDs(ctx context.Context, userID int, commentIDs []int) ([]models.ViewerReaction, error)
+}
+
+// Follow интерфейс репозитория для подписок на пользователей и категории
+type Follow interface {
+	FollowUser(ctx context.Context, followerID int, followeeID int, createdAt time.Time) (bool, error)
+	UnfollowUser(ctx context.Context, followerID int, followeeID int) error
+	IsFollowingUser(ctx context.Context, followerID int, followeeID int) (bool, error)
+	FollowCategory(ctx context.Context, userID int, categoryID int, createdAt time.Time) (bool, error)
+	UnfollowCategory(ctx context.Context, userID int, categoryID int) error
+	GetFollowedCategoryIDs(ctx context.Context, userID int) ([]int, error)
+	GetFollowers(ctx context.Context, userID int, viewerID int, limit, offset int) ([]models.FollowUser, int, error)
+	GetFollowing(ctx context.Context, userID int, viewerID int, limit, offset int) ([]models.FollowUser, int, error)
+	GetFollowedCategories(ctx context.Context, userID int, limit, offset int) ([]models.FollowedCategory, int, error)
+}
+
+// Notification интерфейс репозитория для уведомлений пользователей
+type Notification interface {
+	Create(ctx context.Context, notification models.Notification) (int, error)
+	GetByID(ctx context.Context, userID int, id int) (models.NotificationDetails, error)
+	GetByUserID(ctx context.Context, userID int, filter models.NotificationFilter) ([]models.NotificationDetails, int, error)
+	CountUnread(ctx context.Context, userID int) (int, error)
+	MarkRead(ctx context.Context, userID int, ids []int, readAt time.Time) (int64, error)
+	Exists(ctx context.Context, userID int, id int) (bool, error)
+}
+
+type Message interface {
+	FindConversation(ctx context.Context, userID int, peerID int) (int, error)
+	CreateConversation(ctx context.Context, userID int, peerID int, peerStatus string, createdAt time.Time) (int, error)
+	GetMembers(ctx context.Context, conversationID int) ([]models.ConversationMember, error)
+	SetStatus(ctx context.Context, conversationID int, userID int, status string) error
+	CreateMessage(ctx context.Context, message models.Message) (int, error)
+	GetMessage(ctx context.Context, messageID int) (models.Message, error)
+	GetMessages(ctx context.Context, conversationID int, filter models.MessageFilter) ([]models.Message, error)
+	GetConversation(ctx context.Context, conversationID int, userID int) (models.ConversationDetails, error)
+	GetConversations(ctx context.Context, userID int, status string, limit, offset int) ([]models.ConversationDetails, int, error)
+	MarkRead(ctx context.Context, conversationID int, userID int, readAt time.Time) (int, error)
+	UpdateDMPolicy(ctx context.Context, userID int, policy string) error
+}
+
+type NotificationPreference interface {
+	Get(ctx context.Context, userID int) (models.NotificationPreferences, error)
+	Update(ctx context.Context, userID int, types map[string]string, digest *string) error
+	SetDigestFrequency(ctx context.Context, userID int, frequency string) error
+}
+
+type Digest interface {
+	ClaimDue(ctx context.Context, now, dailyBefore, weeklyBefore time.Time, exclude []int, limit int) ([]models.DigestRecipient, error)
+	GetPosts(ctx context.Context, userID int, since time.Time, limit int) ([]models.DigestPost, error)
+	GetActivity(ctx context.Context, userID int, since time.Time, limit int) ([]models.NotificationDetails, error)
+	Release(ctx context.Context, userID int, claimedAt time.Time, sentAt *time.Time) error
+}
+
+type Block interface {
+	Block(ctx context.Context, blockerID int, blockedID int, createdAt time.Time) (bool, error)
+	Unblock(ctx context.Context, blockerID int, blockedID int) error
+	Mute(ctx context.Context, muterID int, mutedID int, createdAt time.Time) (bool, error)
+	Unmute(ctx context.Context, muterID int, mutedID int) error
+	IsBlockedBetween(ctx context.Context, userID int, otherID int) (bool, error)
+	IsBlockedWithPost(ctx context.Context, userID int, postID int) (bool, error)
+	GetRelation(ctx context.Context, userID int, otherID int) (bool, bool, error)
+	GetBlockedIDs(ctx context.Context, userID int) ([]int, error)
+	GetMutedIDs(ctx context.Context, userID int) ([]int, error)
+	GetBlocked(ctx context.Context, userID int, limit, offset int) ([]models.RestrictedUser, int, error)
+	GetMuted(ctx context.Context, userID int, limit, offset int) ([]models.RestrictedUser, int, error)
+}
+
+type Report interface {
+	Create(ctx context.Context, report models.Report) (bool, error)
+	CountOpen(ctx context.Context, targetType string, targetID int) (int, error)
+	GetGroups(ctx context.Context, filter models.ReportFilter) ([]models.ReportGroup, int, error)
+	GetByTarget(ctx context.Context, targetType string, targetID int) ([]models.ReportDetails, error)
+	Resolve(ctx context.Context, targetType string, targetID int, resolution string, moderatorID int, resolvedAt time.Time, action *models.ModerationAction) (int64, error)
+}
+
+type Audit interface {
+	GetAll(ctx context.Context, filter models.AuditFilter) ([]models.ModerationActionDetails, int, error)
+}
+
 // Repository главный интерфейс репозитория
 type Repository struct {
-	User     User
-	Post     Post
-	Comment  Comment
-	Like     Like
-	Category Category
+	User                   User
+	Post                   Post
+	Comment                Comment
+	Like                   Like
+	Category               Category
+	Trending               Trending
+	Analytics              Analytics
+	Trash                  Trash
+	Collection             Collection
+	Coauthor               Coauthor
+	Tool                   Tool
+	Mention                Mention
+	Reaction               Reaction
+	Follow                 Follow
+	Notification           Notification
+	NotificationPreference NotificationPreference
+	Digest                 Digest
+	Message                Message
+	Block                  Block
+	Report                 Report
+	Audit                  Audit
 }
 
 // NewRepository создает новый экземпляр репозитория
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		User:     postgres.NewUserPostgres(db),
-		Post:     postgres.NewPostPostgres(db),
-		Comment:  postgres.NewCommentPostgres(db),
-		Like:     postgres.NewLikePostgres(db),
-		Category: postgres.NewCategoryPostgres(db),
+		User:                   postgres.NewUserPostgres(db),
+		Post:                   postgres.NewPostPostgres(db),
+		Comment:                postgres.NewCommentPostgres(db),
+		Like:                   postgres.NewLikePostgres(db),
+		Category:               postgres.NewCategoryPostgres(db),
+		Trending:               postgres.NewTrendingPostgres(db),
+		Analytics:              postgres.NewAnalyticsPostgres(db),
+		Trash:                  postgres.NewTrashPostgres(db),
+		Collection:             postgres.NewCollectionPostgres(db),
+		Coauthor:               postgres.NewCoauthorPostgres(db),
+		Tool:                   postgres.NewToolPostgres(db),
+		Mention:                postgres.NewMentionPostgres(db),
+		Reaction:               postgres.NewReactionPostgres(db),
+		Follow:                 postgres.NewFollowPostgres(db),
+		Notification:           postgres.NewNotificationPostgres(db),
+		NotificationPreference: postgres.NewNotificationPreferencePostgres(db),
+		Digest:                 postgres.NewDigestPostgres(db),
+		Message:                postgres.NewMessagePostgres(db),
+		Block:                  postgres.NewBlockPostgres(db),
+		Report:                 postgres.NewReportPostgres(db),
+		Audit:                  postgres.NewAuditPostgres(db),
 	}
 }
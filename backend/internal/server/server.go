@@ -29,6 +29,12 @@ func (s *Server) Run() error {
 	return s.httpServer.ListenAndServe()
 }
 
+// RegisterOnShutdown регистрирует функцию, вызываемую в начале завершения работы.
+// Нужна для долгих соединений, которые Shutdown не закрывает сам
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
 // Shutdown выполняет корректное завершение работы сервера
 func (s *Server) Shutdown(ctx context.Context) error {
 	return s.httpServer.Shutdown(ctx)
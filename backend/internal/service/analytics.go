@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/cache"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Количество источников переходов в статистике поста
+	topReferrersLimit = 10
+
+	// Количество лучших работ в панели автора
+	dashboardTopPostsLimit = 5
+
+	// Источник для просмотров без заголовка Referer
+	directReferrer = "direct"
+
+	// Время на запись оставшихся просмотров при остановке
+	viewsFinalFlushTimeout = 5 * time.Second
+)
+
+// viewKey ключ накопленных просмотров: пост, день и источник
+type viewKey struct {
+	postID   int
+	day      string
+	referrer string
+}
+
+type AnalyticsService struct {
+	analyticsRepo repository.Analytics
+	postRepo      repository.Post
+	commentRepo   repository.Comment
+	userRepo      repository.User
+	cfg           config.AnalyticsConfig
+
+	// Недавние просмотры для дедупликации по зрителю или хэшу IP в пределах окна
+	seen *cache.TTLCache[string, struct{}]
+
+	mu      sync.Mutex
+	pending map[viewKey]int
+}
+
+func NewAnalyticsService(
+	analyticsRepo repository.Analytics,
+	postRepo repository.Post,
+	commentRepo repository.Comment,
+	userRepo repository.User,
+	cfg config.AnalyticsConfig,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		postRepo:      postRepo,
+		commentRepo:   commentRepo,
+		userRepo:      userRepo,
+		cfg:           cfg,
+		seen:          cache.NewTTLCache[string, struct{}](cfg.ViewDedupWindow),
+		pending:       make(map[viewKey]int),
+	}
+}
+
+// RecordView учитывает просмотр поста. Повторные просмотры одного зрителя
+// (или одного IP для гостей) в пределах окна дедупликации не считаются.
+// Просмотры копятся в памяти и записываются в базу фоновой задачей
+func (s *AnalyticsService) RecordView(postId int, viewerId int, ip string, referrer string) {
+	var dedupKey string
+	if viewerId != 0 {
+		dedupKey = fmt.Sprintf("%d:u:%d", postId, viewerId)
+	} else {
+		dedupKey = fmt.Sprintf("%d:ip:%s", postId, s.hashIP(ip))
+	}
+
+	if !s.seen.Add(dedupKey, struct{}{}) {
+		return
+	}
+
+	key := viewKey{
+		postID:   postId,
+		day:      time.Now().UTC().Format("2006-01-02"),
+		referrer: normalizeReferrer(referrer),
+	}
+
+	s.mu.Lock()
+	s.pending[key]++
+	s.mu.Unlock()
+}
+
+// FlushViews записывает накопленные просмотры в базу.
+// При ошибке просмотры возвращаются в буфер до следующей попытки
+func (s *AnalyticsService) FlushViews(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[viewKey]int)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	counts := make([]models.PostViewCount, 0, len(pending))
+	for key, views := range pending {
+		day, _ := time.Parse("2006-01-02", key.day)
+		counts = append(counts, models.PostViewCount{
+			PostID:   key.postID,
+			Day:      day,
+			Referrer: key.referrer,
+			Views:    views,
+		})
+	}
+
+	if err := s.analyticsRepo.AddViews(ctx, counts); err != nil {
+		s.mu.Lock()
+		for key, views := range pending {
+			s.pending[key] += views
+		}
+		s.mu.Unlock()
+
+		return fmt.Errorf("failed to flush views: %w", err)
+	}
+
+	return nil
+}
+
+// Run периодически записывает накопленные просмотры до отмены контекста,
+// после чего записывает оставшиеся
+func (s *AnalyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ViewFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), viewsFinalFlushTimeout)
+			defer cancel()
+
+			if err := s.FlushViews(flushCtx); err != nil {
+				logrus.Errorf("Final views flush failed: %s", err.Error())
+			}
+			return
+		case <-ticker.C:
+			if err := s.FlushViews(ctx); err != nil {
+				logrus.Errorf("Views flush failed: %s", err.Error())
+			}
+			s.seen.Cleanup()
+		}
+	}
+}
+
+// GetPostStats получает статистику поста за последние days дней (только для автора или модератора)
+func (s *AnalyticsService) GetPostStats(ctx context.Context, postId int, userId int, days int) (models.PostStatsResponse, error) {
+	// Получаем информацию о посте
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return models.PostStatsResponse{}, fmt.Errorf("post not found: %w", err)
+	}
+
+	// Проверяем, что пользователь - автор поста или модератор
+	if post.UserID != userId {
+		user, err := s.userRepo.GetByID(ctx, userId)
+		if err != nil || (user.Role != "moderator" && user.Role != "admin") {
+			return models.PostStatsResponse{}, fmt.Errorf("доступ запрещен")
+		}
+	}
+
+	from := time.Now().UTC().AddDate(0, 0, -(days - 1))
+
+	daily, err := s.analyticsRepo.GetPostDailyStats(ctx, postId, from)
+	if err != nil {
+		return models.PostStatsResponse{}, err
+	}
+
+	referrers, err := s.analyticsRepo.GetPostTopReferrers(ctx, postId, from, topReferrersLimit)
+	if err != nil {
+		return models.PostStatsResponse{}, err
+	}
+
+	commentsCount, err := s.commentRepo.CountByPostID(ctx, postId)
+	if err != nil {
+		return models.PostStatsResponse{}, err
+	}
+
+	return models.PostStatsResponse{
+		PostID:        post.ID,
+		ViewsCount:    post.ViewsCount,
+		LikesCount:    post.LikesCount,
+		CommentsCount: commentsCount,
+		Daily:         daily,
+		TopReferrers:  referrers,
+	}, nil
+}
+
+// GetCreatorDashboard получает сводную статистику портфолио автора за последние days дней
+func (s *AnalyticsService) GetCreatorDashboard(ctx context.Context, userId int, days int) (models.DashboardResponse, error) {
+	from := time.Now().UTC().AddDate(0, 0, -(days - 1))
+
+	summary, err := s.analyticsRepo.GetCreatorSummary(ctx, userId)
+	if err != nil {
+		return models.DashboardResponse{}, err
+	}
+
+	daily, err := s.analyticsRepo.GetCreatorDailyStats(ctx, userId, from)
+	if err != nil {
+		return models.DashboardResponse{}, err
+	}
+
+	topPosts, err := s.analyticsRepo.GetCreatorTopPosts(ctx, userId, from, dashboardTopPostsLimit)
+	if err != nil {
+		return models.DashboardResponse{}, err
+	}
+
+	response := models.DashboardResponse{
+		Totals:   summary,
+		Daily:    daily,
+		TopPosts: topPosts,
+	}
+
+	// Доля одобренных считается только среди постов, по которым модератор уже принял решение
+	if moderated := summary.ApprovedPosts + summary.RejectedPosts; moderated > 0 {
+		rate := float64(summary.ApprovedPosts) / float64(moderated)
+		response.ApprovalRate = &rate
+	}
+
+	return response, nil
+}
+
+// hashIP возвращает хэш IP-адреса с солью, чтобы не хранить адреса в открытом виде
+func (s *AnalyticsService) hashIP(ip string) string {
+	sum := sha256.Sum256([]byte(s.cfg.IPHashSalt + ip))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeReferrer приводит заголовок Referer к хосту источника
+func normalizeReferrer(referrer string) string {
+	parsed, err := url.Parse(referrer)
+	if err != nil || parsed.Hostname() == "" {
+		return directReferrer
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if len(host) > 255 {
+		host = host[:255]
+	}
+
+	return host
+}
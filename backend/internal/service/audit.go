@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+)
+
+type AuditService struct {
+	auditRepo repository.Audit
+}
+
+func NewAuditService(auditRepo repository.Audit) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// GetAll получает страницу журнала действий модераторов
+func (s *AuditService) GetAll(ctx context.Context, filter models.AuditFilter) (models.ModerationActionsResponse, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	actions, total, err := s.auditRepo.GetAll(ctx, filter)
+	if err != nil {
+		return models.ModerationActionsResponse{}, err
+	}
+
+	items := make([]models.ModerationActionResponse, 0, len(actions))
+	for _, action := range actions {
+		item := models.ModerationActionResponse{
+			ID:            action.ID,
+			Action:        action.Action,
+			TargetType:    action.TargetType,
+			TargetID:      action.TargetID,
+			Reason:        action.Reason,
+			PreviousState: action.PreviousState,
+			NewState:      action.NewState,
+			CreatedAt:     action.CreatedAt,
+		}
+		if action.ModeratorID != nil && action.ModeratorUsername != nil {
+			item.Moderator = &models.UserBrief{
+				ID:       *action.ModeratorID,
+				Username: *action.ModeratorUsername,
+				Nickname: *action.ModeratorNickname,
+			}
+			if action.ModeratorAvatar != nil {
+				item.Moderator.Avatar = *action.ModeratorAvatar
+			}
+		}
+		items = append(items, item)
+	}
+
+	return models.ModerationActionsResponse{
+		Actions: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"time"
+)
+
+type BlockService struct {
+	blockRepo repository.Block
+	userRepo  repository.User
+}
+
+func NewBlockService(blockRepo repository.Block, userRepo repository.User) *BlockService {
+	return &BlockService{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Block блокирует пользователя. Подписки между пользователями удаляются в обе стороны
+func (s *BlockService) Block(ctx context.Context, userId int, blockedId int) error {
+	if err := s.checkTarget(ctx, userId, blockedId); err != nil {
+		return err
+	}
+
+	added, err := s.blockRepo.Block(ctx, userId, blockedId, time.Now())
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("блокировка уже существует")
+	}
+
+	return nil
+}
+
+// Unblock снимает блокировку пользователя
+func (s *BlockService) Unblock(ctx context.Context, userId int, blockedId int) error {
+	return s.blockRepo.Unblock(ctx, userId, blockedId)
+}
+
+// Mute заглушает пользователя: его посты и комментарии скрываются из лент
+func (s *BlockService) Mute(ctx context.Context, userId int, mutedId int) error {
+	if err := s.checkTarget(ctx, userId, mutedId); err != nil {
+		return err
+	}
+
+	added, err := s.blockRepo.Mute(ctx, userId, mutedId, time.Now())
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("заглушение уже существует")
+	}
+
+	return nil
+}
+
+// Unmute снимает заглушение пользователя
+func (s *BlockService) Unmute(ctx context.Context, userId int, mutedId int) error {
+	return s.blockRepo.Unmute(ctx, userId, mutedId)
+}
+
+// GetBlocked получает страницу пользователей, заблокированных текущим пользователем
+func (s *BlockService) GetBlocked(ctx context.Context, userId int, filter models.RestrictionFilter) (models.RestrictedUsersResponse, error) {
+	return s.getUsers(ctx, userId, filter, s.blockRepo.GetBlocked)
+}
+
+// GetMuted получает страницу пользователей, заглушенных текущим пользователем
+func (s *BlockService) GetMuted(ctx context.Context, userId int, filter models.RestrictionFilter) (models.RestrictedUsersResponse, error) {
+	return s.getUsers(ctx, userId, filter, s.blockRepo.GetMuted)
+}
+
+// checkTarget проверяет, что пользователь не ограничивает сам себя и цель существует
+func (s *BlockService) checkTarget(ctx context.Context, userId int, targetId int) error {
+	if userId == targetId {
+		return models.ErrSelfRestrict
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, targetId); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	return nil
+}
+
+// getUsers выбирает страницу заблокированных или заглушенных через переданный метод репозитория
+func (s *BlockService) getUsers(
+	ctx context.Context,
+	userId int,
+	filter models.RestrictionFilter,
+	fetch func(ctx context.Context, userID int, limit, offset int) ([]models.RestrictedUser, int, error),
+) (models.RestrictedUsersResponse, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	users, total, err := fetch(ctx, userId, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return models.RestrictedUsersResponse{}, err
+	}
+
+	items := make([]models.RestrictedUserResponse, 0, len(users))
+	for _, user := range users {
+		brief := models.UserBrief{
+			ID:       user.UserID,
+			Username: user.Username,
+			Nickname: user.Nickname,
+		}
+		if user.Avatar != nil {
+			brief.Avatar = *user.Avatar
+		}
+
+		items = append(items, models.RestrictedUserResponse{User: brief, CreatedAt: user.CreatedAt})
+	}
+
+	return models.RestrictedUsersResponse{
+		Users: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
+
+// blockChecker проверяет блокировки между пользователями для сервисов,
+// которым нужно запрещать взаимодействие заблокированных пользователей
+type blockChecker struct {
+	blockRepo repository.Block
+}
+
+// between сообщает, заблокировал ли кто-либо из двух пользователей другого.
+// Для гостя и для одного и того же пользователя блокировки не бывает
+func (c blockChecker) between(ctx context.Context, userId int, otherId int) (bool, error) {
+	if userId == 0 || otherId == 0 || userId == otherId {
+		return false, nil
+	}
+
+	blocked, err := c.blockRepo.IsBlockedBetween(ctx, userId, otherId)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+// post сообщает, связан ли пользователь блокировкой с автором поста или с его соавторами.
+// Такой пост для пользователя не существует. Для гостя блокировки не бывает
+func (c blockChecker) post(ctx context.Context, userId int, postId int) (bool, error) {
+	if userId == 0 {
+		return false, nil
+	}
+
+	blocked, err := c.blockRepo.IsBlockedWithPost(ctx, userId, postId)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
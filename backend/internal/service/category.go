@@ -12,14 +12,15 @@ import (
 
 type CategoryService struct {
 	categoryRepo repository.Category
+	followRepo   repository.Follow
 }
 
-func NewCategoryService(categoryRepo repository.Category) *CategoryService {
-	return &CategoryService{categoryRepo: categoryRepo}
+func NewCategoryService(categoryRepo repository.Category, followRepo repository.Follow) *CategoryService {
+	return &CategoryService{categoryRepo: categoryRepo, followRepo: followRepo}
 }
 
 // Create создает новую категорию
-func (s *CategoryService) Create(ctx context.Context, category models.CategoryCreate) (int, error) {
+func (s *CategoryService) Create(ctx context.Context, moderatorId int, category models.CategoryCreate) (int, error) {
 	// Генерируем slug из названия категории
 	slug := generateSlug(category.Name)
 
@@ -40,23 +41,72 @@ func (s *CategoryService) Create(ctx context.Context, category models.CategoryCr
 		UpdatedAt: time.Now(),
 	}
 
-	return s.categoryRepo.Create(ctx, newCategory)
+	// ID записи журнала заполняет репозиторий после вставки категории
+	return s.categoryRepo.Create(ctx, newCategory, &models.ModerationAction{
+		ModeratorID: &moderatorId,
+		Action:      models.AuditActionCategoryCreate,
+		TargetType:  models.AuditTargetCategory,
+		NewState:    models.AuditState{"name": newCategory.Name, "slug": newCategory.Slug},
+		CreatedAt:   newCategory.CreatedAt,
+	})
 }
 
 // GetById получает категорию по ID
-func (s *CategoryService) GetByID(ctx context.Context, id int) (models.Category, error) {
-	return s.categoryRepo.GetByID(ctx, id)
+func (s *CategoryService) GetByID(ctx context.Context, id int, currentUserId int) (models.Category, error) {
+	category, err := s.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	followed, err := s.followedCategories(ctx, currentUserId)
+	if err != nil {
+		return models.Category{}, err
+	}
+	category.IsFollowing = followed[category.ID]
+
+	return category, nil
 }
 
 // GetAll получает все категории
-func (s *CategoryService) GetAll(ctx context.Context) ([]models.Category, error) {
-	return s.categoryRepo.GetAll(ctx)
+func (s *CategoryService) GetAll(ctx context.Context, currentUserId int) ([]models.Category, error) {
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	followed, err := s.followedCategories(ctx, currentUserId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		categories[i].IsFollowing = followed[categories[i].ID]
+	}
+
+	return categories, nil
+}
+
+// followedCategories возвращает множество категорий, на которые подписан пользователь
+func (s *CategoryService) followedCategories(ctx context.Context, userId int) (map[int]bool, error) {
+	followed := make(map[int]bool)
+	if userId == 0 {
+		return followed, nil
+	}
+
+	ids, err := s.followRepo.GetFollowedCategoryIDs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		followed[id] = true
+	}
+
+	return followed, nil
 }
 
 // Update обновляет категорию
-func (s *CategoryService) Update(ctx context.Context, id int, category models.CategoryUpdate) error {
+func (s *CategoryService) Update(ctx context.Context, id int, moderatorId int, category models.CategoryUpdate) error {
 	// Проверяем, что категория существует
-	_, err := s.categoryRepo.GetByID(ctx, id)
+	previous, err := s.categoryRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("category not found: %w", err)
 	}
@@ -84,13 +134,26 @@ func (s *CategoryService) Update(ctx context.Context, id int, category models.Ca
 		UpdatedAt: time.Now(),
 	}
 
-	return s.categoryRepo.Update(ctx, updatedCategory)
+	newState := models.AuditState{"name": previous.Name, "slug": previous.Slug}
+	if category.Name != "" {
+		newState = models.AuditState{"name": category.Name, "slug": slug}
+	}
+
+	return s.categoryRepo.Update(ctx, updatedCategory, &models.ModerationAction{
+		ModeratorID:   &moderatorId,
+		Action:        models.AuditActionCategoryUpdate,
+		TargetType:    models.AuditTargetCategory,
+		TargetID:      id,
+		PreviousState: models.AuditState{"name": previous.Name, "slug": previous.Slug},
+		NewState:      newState,
+		CreatedAt:     updatedCategory.UpdatedAt,
+	})
 }
 
 // Delete удаляет категорию
-func (s *CategoryService) Delete(ctx context.Context, id int) error {
+func (s *CategoryService) Delete(ctx context.Context, id int, moderatorId int) error {
 	// Проверяем, что категория существует
-	_, err := s.categoryRepo.GetByID(ctx, id)
+	previous, err := s.categoryRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("category not found: %w", err)
 	}
@@ -105,7 +168,15 @@ func (s *CategoryService) Delete(ctx context.Context, id int) error {
 	}
 
 	// Удаляем категорию
-	return s.categoryRepo.Delete(ctx, id)
+	return s.categoryRepo.Delete(ctx, id, &models.ModerationAction{
+		ModeratorID:   &moderatorId,
+		Action:        models.AuditActionCategoryDelete,
+		TargetType:    models.AuditTargetCategory,
+		TargetID:      id,
+		PreviousState: models.AuditState{"name": previous.Name, "slug": previous.Slug},
+		NewState:      models.AuditState{"deleted": true},
+		CreatedAt:     time.Now(),
+	})
 }
 
 // generateSlug генерирует slug из названия категории
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"time"
+)
+
+type CoauthorService struct {
+	coauthorRepo repository.Coauthor
+	postRepo     repository.Post
+	userRepo     repository.User
+}
+
+func NewCoauthorService(coauthorRepo repository.Coauthor, postRepo repository.Post, userRepo repository.User) *CoauthorService {
+	return &CoauthorService{
+		coauthorRepo: coauthorRepo,
+		postRepo:     postRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Invite приглашает пользователя в соавторы поста (только автор поста)
+func (s *CoauthorService) Invite(ctx context.Context, postId int, userId int, input models.CoauthorInvite) error {
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	if post.UserID != userId {
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	// Автор уже указан в посте и не может быть своим соавтором
+	if input.UserID == post.UserID {
+		return models.ErrInvalidCoauthor
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	coauthor := models.PostCoauthor{
+		PostID:    postId,
+		UserID:    input.UserID,
+		Role:      input.Role,
+		CanEdit:   input.CanEdit,
+		CreatedAt: time.Now(),
+	}
+
+	return s.coauthorRepo.Invite(ctx, coauthor)
+}
+
+// GetByPostID получает всех приглашенных соавторов поста вместе со статусами приглашений.
+// Доступно автору, принявшим приглашение соавторам и модераторам
+func (s *CoauthorService) GetByPostID(ctx context.Context, postId int, userId int) ([]models.CoauthorResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	coauthors, err := s.coauthorRepo.GetByPostID(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.UserID != userId && !isAcceptedCoauthor(coauthors, userId) {
+		user, err := s.userRepo.GetByID(ctx, userId)
+		if err != nil || (user.Role != "moderator" && user.Role != "admin") {
+			return nil, fmt.Errorf("доступ запрещен")
+		}
+	}
+
+	response := make([]models.CoauthorResponse, 0, len(coauthors))
+	for _, coauthor := range coauthors {
+		item := models.CoauthorResponse{
+			User: models.UserBrief{
+				ID:       coauthor.UserID,
+				Username: coauthor.Username,
+				Nickname: coauthor.Nickname,
+			},
+			Role:        coauthor.Role,
+			CanEdit:     coauthor.CanEdit,
+			Status:      coauthor.Status,
+			CreatedAt:   coauthor.CreatedAt,
+			RespondedAt: coauthor.RespondedAt,
+		}
+		if coauthor.Avatar != nil {
+			item.User.Avatar = *coauthor.Avatar
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
+// GetInvitations получает ожидающие ответа приглашения пользователя в соавторы
+func (s *CoauthorService) GetInvitations(ctx context.Context, userId int) ([]models.CoauthorInvitationResponse, error) {
+	invitations, err := s.coauthorRepo.GetInvitationsByUserID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.CoauthorInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		item := models.CoauthorInvitationResponse{
+			PostID:    invitation.PostID,
+			PostTitle: invitation.PostTitle,
+			MediaURL:  invitation.PostMediaPath,
+			Author: models.UserBrief{
+				ID:       invitation.AuthorID,
+				Username: invitation.AuthorUsername,
+				Nickname: invitation.AuthorNickname,
+			},
+			Role:      invitation.Role,
+			CanEdit:   invitation.CanEdit,
+			CreatedAt: invitation.CreatedAt,
+		}
+		if invitation.AuthorAvatar != nil {
+			item.Author.Avatar = *invitation.AuthorAvatar
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
+// Update изменяет роль и права соавтора (только автор поста)
+func (s *CoauthorService) Update(ctx context.Context, postId int, userId int, coauthorId int, input models.CoauthorUpdate) error {
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	if post.UserID != userId {
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	if _, err := s.coauthorRepo.Get(ctx, postId, coauthorId); err != nil {
+		return err
+	}
+
+	return s.coauthorRepo.Update(ctx, postId, coauthorId, input.Role, input.CanEdit)
+}
+
+// Accept принимает приглашение в соавторы
+func (s *CoauthorService) Accept(ctx context.Context, postId int, userId int) error {
+	return s.respond(ctx, postId, userId, "accepted")
+}
+
+// Decline отклоняет приглашение в соавторы
+func (s *CoauthorService) Decline(ctx context.Context, postId int, userId int) error {
+	return s.respond(ctx, postId, userId, "declined")
+}
+
+// Remove удаляет соавтора: автор может убрать любого соавтора, соавтор — только себя
+func (s *CoauthorService) Remove(ctx context.Context, postId int, userId int, coauthorId int) error {
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	if post.UserID != userId && coauthorId != userId {
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	if _, err := s.coauthorRepo.Get(ctx, postId, coauthorId); err != nil {
+		return err
+	}
+
+	return s.coauthorRepo.Delete(ctx, postId, coauthorId)
+}
+
+// respond сохраняет ответ пользователя на ожидающее приглашение
+func (s *CoauthorService) respond(ctx context.Context, postId int, userId int, status string) error {
+	if _, err := s.postRepo.GetByID(ctx, postId); err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	responded, err := s.coauthorRepo.Respond(ctx, postId, userId, status)
+	if err != nil {
+		return err
+	}
+	if !responded {
+		return fmt.Errorf("invitation not found")
+	}
+
+	return nil
+}
+
+// isAcceptedCoauthor проверяет, что пользователь есть среди принявших приглашение соавторов
+func isAcceptedCoauthor(coauthors []models.PostCoauthorDetails, userId int) bool {
+	for _, coauthor := range coauthors {
+		if coauthor.UserID == userId && coauthor.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CollectionService struct {
+	collectionRepo repository.Collection
+	postRepo       repository.Post
+	fileStorage    FileStorage
+}
+
+func NewCollectionService(collectionRepo repository.Collection, postRepo repository.Post, fileStorage FileStorage) *CollectionService {
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		postRepo:       postRepo,
+		fileStorage:    fileStorage,
+	}
+}
+
+// Create создает новую коллекцию пользователя
+func (s *CollectionService) Create(ctx context.Context, userId int, input models.CollectionCreate) (int, error) {
+	collection := models.Collection{
+		UserID:      userId,
+		Title:       input.Title,
+		Description: input.Description,
+		IsPublic:    input.IsPublic,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	return s.collectionRepo.Create(ctx, collection)
+}
+
+// GetByID получает коллекцию вместе со страницей ее постов.
+// Приватная коллекция видна только владельцу
+func (s *CollectionService) GetByID(ctx context.Context, id int, currentUserId int, filter models.PostFilter) (models.CollectionPageResponse, error) {
+	collection, err := s.collectionRepo.GetDetailsByID(ctx, id)
+	if err != nil {
+		return models.CollectionPageResponse{}, err
+	}
+
+	if !collection.IsPublic && collection.UserID != currentUserId {
+		return models.CollectionPageResponse{}, fmt.Errorf("collection not found")
+	}
+
+	filter.ViewerID = currentUserId
+
+	posts, total, err := s.postRepo.GetByCollectionID(ctx, id, filter)
+	if err != nil {
+		return models.CollectionPageResponse{}, fmt.Errorf("failed to get collection posts: %w", err)
+	}
+
+	// Порядок коллекции задается вручную, курсор ленты к нему неприменим
+	feed := newFeedResponse(posts, filter, total)
+	feed.NextCursor = ""
+
+	return models.CollectionPageResponse{
+		Collection: newCollectionResponse(collection),
+		Posts:      feed,
+	}, nil
+}
+
+// GetByUserID получает коллекции пользователя, приватные видны только ему самому
+func (s *CollectionService) GetByUserID(ctx context.Context, userId int, currentUserId int) ([]models.CollectionResponse, error) {
+	collections, err := s.collectionRepo.GetByUserID(ctx, userId, userId == currentUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCollectionResponses(collections), nil
+}
+
+// GetByPostID получает коллекции, в которые добавлен пост
+func (s *CollectionService) GetByPostID(ctx context.Context, postId int, currentUserId int) ([]models.CollectionResponse, error) {
+	if _, err := s.postRepo.GetByID(ctx, postId); err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	collections, err := s.collectionRepo.GetByPostID(ctx, postId, currentUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCollectionResponses(collections), nil
+}
+
+// Update обновляет коллекцию
+func (s *CollectionService) Update(ctx context.Context, id int, userId int, input models.CollectionUpdate) error {
+	collection, err := s.getOwned(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+
+	if input.Title != "" {
+		collection.Title = input.Title
+	}
+	if input.Description != nil {
+		collection.Description = *input.Description
+	}
+	if input.IsPublic != nil {
+		collection.IsPublic = *input.IsPublic
+	}
+	collection.UpdatedAt = time.Now()
+
+	return s.collectionRepo.Update(ctx, collection)
+}
+
+// UpdateCover загружает новую обложку коллекции
+func (s *CollectionService) UpdateCover(ctx context.Context, id int, userId int, coverFile *multipart.FileHeader) error {
+	collection, err := s.getOwned(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+
+	// Открываем файл
+	file, err := coverFile.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open cover file: %w", err)
+	}
+	defer file.Close()
+
+	// Генерируем уникальное имя файла
+	ext := filepath.Ext(coverFile.Filename)
+	filename := fmt.Sprintf("collection_%d_%d%s", id, time.Now().UnixNano(), ext)
+
+	// Сохраняем файл
+	coverPath, err := s.fileStorage.SaveFile(file, filename)
+	if err != nil {
+		return fmt.Errorf("failed to save cover file: %w", err)
+	}
+
+	if err := s.collectionRepo.UpdateCover(ctx, id, coverPath); err != nil {
+		return err
+	}
+
+	// Удаляем прежнюю обложку
+	s.deleteCover(collection)
+
+	return nil
+}
+
+// Delete удаляет коллекцию
+func (s *CollectionService) Delete(ctx context.Context, id int, userId int) error {
+	collection, err := s.getOwned(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.collectionRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.deleteCover(collection)
+
+	return nil
+}
+
+// AddPost добавляет опубликованный пост в коллекцию
+func (s *CollectionService) AddPost(ctx context.Context, id int, userId int, postId int) error {
+	if _, err := s.getOwned(ctx, id, userId); err != nil {
+		return err
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil || post.Status != "approved" {
+		return fmt.Errorf("post not found")
+	}
+
+	return s.collectionRepo.AddItem(ctx, id, postId)
+}
+
+// RemovePost удаляет пост из коллекции
+func (s *CollectionService) RemovePost(ctx context.Context, id int, userId int, postId int) error {
+	if _, err := s.getOwned(ctx, id, userId); err != nil {
+		return err
+	}
+
+	return s.collectionRepo.RemoveItem(ctx, id, postId)
+}
+
+// Reorder задает новый порядок постов в коллекции.
+// Список должен содержать каждый видимый владельцу пост коллекции ровно один раз,
+// остальные посты остаются в конце
+func (s *CollectionService) Reorder(ctx context.Context, id int, userId int, postIds []int) error {
+	if _, err := s.getOwned(ctx, id, userId); err != nil {
+		return err
+	}
+
+	current, err := s.collectionRepo.GetVisiblePostIDs(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+
+	if len(current) != len(postIds) {
+		return models.ErrInvalidCollectionOrder
+	}
+
+	remaining := make(map[int]bool, len(current))
+	for _, postId := range current {
+		remaining[postId] = true
+	}
+	for _, postId := range postIds {
+		if !remaining[postId] {
+			return models.ErrInvalidCollectionOrder
+		}
+		delete(remaining, postId)
+	}
+
+	return s.collectionRepo.Reorder(ctx, id, postIds)
+}
+
+// getOwned получает коллекцию и проверяет, что ей владеет пользователь
+func (s *CollectionService) getOwned(ctx context.Context, id int, userId int) (models.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	if collection.UserID != userId {
+		// Чужая приватная коллекция для пользователя не существует
+		if !collection.IsPublic {
+			return models.Collection{}, fmt.Errorf("collection not found")
+		}
+		return models.Collection{}, fmt.Errorf("доступ запрещен")
+	}
+
+	return collection, nil
+}
+
+// deleteCover удаляет загруженную обложку коллекции из хранилища
+func (s *CollectionService) deleteCover(collection models.Collection) {
+	if collection.CoverPath == nil {
+		return
+	}
+
+	if err := s.fileStorage.DeleteFile(filepath.Base(*collection.CoverPath)); err != nil {
+		// Логируем ошибку, но продолжаем выполнение
+		logrus.Warnf("Failed to delete collection cover %s: %s", *collection.CoverPath, err.Error())
+	}
+}
+
+// newCollectionResponse преобразует коллекцию со связанными данными в ответ API
+func newCollectionResponse(collection models.CollectionDetails) models.CollectionResponse {
+	response := models.CollectionResponse{
+		ID:          collection.ID,
+		Title:       collection.Title,
+		Description: collection.Description,
+		IsPublic:    collection.IsPublic,
+		ItemsCount:  collection.ItemsCount,
+		Owner: models.UserBrief{
+			ID:       collection.UserID,
+			Username: collection.OwnerUsername,
+			Nickname: collection.OwnerNickname,
+		},
+		CreatedAt: collection.CreatedAt,
+		UpdatedAt: collection.UpdatedAt,
+	}
+
+	// Без загруженной обложки показываем первую работу коллекции
+	if collection.CoverPath != nil {
+		response.CoverURL = *collection.CoverPath
+	} else if collection.FallbackCover != nil {
+		response.CoverURL = *collection.FallbackCover
+	}
+
+	if collection.OwnerAvatar != nil {
+		response.Owner.Avatar = *collection.OwnerAvatar
+	}
+
+	return response
+}
+
+// newCollectionResponses преобразует список коллекций в ответ API
+func newCollectionResponses(collections []models.CollectionDetails) []models.CollectionResponse {
+	response := make([]models.CollectionResponse, 0, len(collections))
+	for _, collection := range collections {
+		response = append(response, newCollectionResponse(collection))
+	}
+	return response
+}
@@ -2,21 +2,56 @@ package service
 
 import (
 	"context"
+	"designhub/internal/config"
 	"designhub/internal/models"
 	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
 	"fmt"
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 type CommentService struct {
-	commentRepo repository.Comment
-	userRepo    repository.User
+	commentRepo   repository.Comment
+	userRepo      repository.User
+	postRepo      repository.Post
+	coauthorRepo  repository.Coauthor
+	mentionRepo   repository.Mention
+	reactionRepo  repository.Reaction
+	blocks        blockChecker
+	access        postAccess
+	mentions      mentionSyncer
+	notifications notifier
+	events        eventPublisher
+	cfg           config.CommentsConfig
 }
 
-func NewCommentService(commentRepo repository.Comment, userRepo repository.User) *CommentService {
+func NewCommentService(
+	commentRepo repository.Comment,
+	userRepo repository.User,
+	postRepo repository.Post,
+	coauthorRepo repository.Coauthor,
+	mentionRepo repository.Mention,
+	reactionRepo repository.Reaction,
+	blockRepo repository.Block,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
+	cfg config.CommentsConfig,
+) *CommentService {
 	return &CommentService{
-		commentRepo: commentRepo,
-		userRepo:    userRepo,
+		commentRepo:   commentRepo,
+		userRepo:      userRepo,
+		postRepo:      postRepo,
+		coauthorRepo:  coauthorRepo,
+		mentionRepo:   mentionRepo,
+		reactionRepo:  reactionRepo,
+		blocks:        blockChecker{blockRepo: blockRepo},
+		access:        newPostAccess(userRepo, coauthorRepo, blockRepo),
+		mentions:      mentionSyncer{mentionRepo: mentionRepo, userRepo: userRepo, blockRepo: blockRepo, notifications: newNotifier(notificationRepo, hub)},
+		notifications: newNotifier(notificationRepo, hub),
+		events:        eventPublisher{hub: hub},
+		cfg:           cfg,
 	}
 }
 
@@ -28,6 +63,17 @@ func (s *CommentService) Create(ctx context.Context, userId int, comment models.
 		return 0, fmt.Errorf("user not found: %w", err)
 	}
 
+	// Проверяем, что пост существует
+	post, err := s.postRepo.GetByID(ctx, comment.PostID)
+	if err != nil {
+		return 0, fmt.Errorf("post not found: %w", err)
+	}
+
+	// Комментировать можно только пост, который пользователь видит
+	if err := s.access.check(ctx, post, userId); err != nil {
+		return 0, err
+	}
+
 	// Создаем комментарий
 	newComment := models.Comment{
 		UserID:    userId,
@@ -37,7 +83,95 @@ func (s *CommentService) Create(ctx context.Context, userId int, comment models.
 		UpdatedAt: time.Now(),
 	}
 
-	return s.commentRepo.Create(ctx, newComment)
+	// Ответ на комментарий. Ответ глубже допустимого уровня становится
+	// ответом на родителя, чтобы ветка не уходила вправо бесконечно
+	var parentAuthorId int
+	if comment.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return 0, fmt.Errorf("comment not found: %w", err)
+		}
+		// Скрытый по жалобам комментарий не виден в обсуждении, отвечать на него нельзя
+		if parent.HiddenAt != nil {
+			return 0, fmt.Errorf("comment not found")
+		}
+		if parent.PostID != comment.PostID {
+			return 0, models.ErrInvalidParentComment
+		}
+		parentAuthorId = parent.UserID
+
+		// Нельзя отвечать пользователю, с которым есть блокировка
+		blocked, err := s.blocks.between(ctx, userId, parentAuthorId)
+		if err != nil {
+			return 0, err
+		}
+		if blocked {
+			return 0, models.ErrUserBlocked
+		}
+
+		maxDepth := s.cfg.MaxDepth
+		if maxDepth < 1 {
+			maxDepth = 1
+		}
+
+		if parent.Depth >= maxDepth && parent.ParentID != nil {
+			newComment.ParentID = parent.ParentID
+			newComment.Depth = parent.Depth
+		} else {
+			newComment.ParentID = &parent.ID
+			newComment.Depth = parent.Depth + 1
+		}
+	}
+
+	// Привязываем комментарий к области медиа, если она указана
+	if annotation := comment.Annotation; annotation != nil {
+		if err := validateAnnotation(*annotation); err != nil {
+			return 0, err
+		}
+
+		newComment.MediaIndex = &annotation.MediaIndex
+		newComment.X = &annotation.X
+		newComment.Y = &annotation.Y
+		newComment.Width = annotation.Width
+		newComment.Height = annotation.Height
+	}
+
+	id, err := s.commentRepo.Create(ctx, newComment)
+	if err != nil {
+		return 0, err
+	}
+
+	// Комментарий уже сохранен, поэтому ошибка разбора упоминаний его не отменяет
+	if err := s.mentions.sync(ctx, comment.PostID, &id, userId, comment.Content); err != nil {
+		logrus.Warnf("Failed to save mentions for comment %d: %s", id, err.Error())
+	}
+
+	// Автор родительского комментария получает уведомление об ответе, автор поста —
+	// о новом комментарии, если ответили не ему самому
+	if parentAuthorId != 0 {
+		s.notifications.send(ctx, models.Notification{
+			UserID:    parentAuthorId,
+			ActorID:   &userId,
+			Type:      models.NotificationTypeReply,
+			PostID:    &post.ID,
+			CommentID: &id,
+			GroupKey:  notificationGroupKey(models.NotificationTypeReply, "comment", *comment.ParentID),
+		})
+	}
+	if post.UserID != parentAuthorId {
+		s.notifications.send(ctx, models.Notification{
+			UserID:    post.UserID,
+			ActorID:   &userId,
+			Type:      models.NotificationTypeComment,
+			PostID:    &post.ID,
+			CommentID: &id,
+			GroupKey:  notificationGroupKey(models.NotificationTypeComment, "post", post.ID),
+		})
+	}
+
+	s.events.postCounts(ctx, s.postRepo, post.ID)
+
+	return id, nil
 }
 
 // GetById получает комментарий по ID
@@ -54,72 +188,116 @@ func (s *CommentService) GetByID(ctx context.Context, id int) (models.CommentRes
 		return models.CommentResponse{}, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Подготавливаем данные пользователя
-	userBrief := models.UserBrief{
-		ID:       user.ID,
-		Username: user.Username,
-		Nickname: user.Nickname,
-		Avatar:   "", // По умолчанию пустая строка
+	mentions, err := s.commentMentions(ctx, []int{comment.ID})
+	if err != nil {
+		return models.CommentResponse{}, err
 	}
 
-	// Если аватар не nil, используем его значение
-	if user.Avatar != nil {
-		userBrief.Avatar = *user.Avatar
-	}
+	return newCommentResponse(comment, newUserBrief(user), mentions[comment.ID]), nil
+}
 
-	// Конвертируем в ответ
-	response := models.CommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		CreatedAt: comment.CreatedAt,
-		PostID:    comment.PostID,
-		User:      userBrief,
+// GetByPostId получает страницу комментариев верхнего уровня к посту
+func (s *CommentService) GetByPostID(ctx context.Context, postId int, filter models.CommentFilter) (models.CommentPage, error) {
+	filter.ParentID = 0
+
+	return s.getPage(ctx, postId, filter)
+}
+
+// GetReplies получает страницу прямых ответов на комментарий
+func (s *CommentService) GetReplies(ctx context.Context, id int, filter models.CommentFilter) (models.CommentPage, error) {
+	parent, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.CommentPage{}, fmt.Errorf("comment not found: %w", err)
 	}
+	// Ветка под скрытым по жалобам комментарием скрыта вместе с ним
+	if parent.HiddenAt != nil {
+		return models.CommentPage{}, fmt.Errorf("comment not found")
+	}
+
+	filter.ParentID = parent.ID
 
-	return response, nil
+	return s.getPage(ctx, parent.PostID, filter)
 }
 
-// GetByPostId получает все комментарии к посту
-func (s *CommentService) GetByPostID(ctx context.Context, postId int) ([]models.CommentResponse, error) {
-	// Получаем комментарии к посту
-	comments, err := s.commentRepo.GetByPostID(ctx, postId)
+// getPage выбирает страницу комментариев и загружает их авторов одним запросом
+func (s *CommentService) getPage(ctx context.Context, postId int, filter models.CommentFilter) (models.CommentPage, error) {
+	// Комментарии доступны тем же пользователям, что и сам пост, в том числе гостям
+	post, err := s.postRepo.GetByID(ctx, postId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get comments: %w", err)
+		return models.CommentPage{}, fmt.Errorf("post not found: %w", err)
 	}
 
-	// Конвертируем в ответ
-	response := make([]models.CommentResponse, 0, len(comments))
+	if err := s.access.check(ctx, post, filter.ViewerID); err != nil {
+		return models.CommentPage{}, err
+	}
+
+	comments, err := s.commentRepo.GetByPostID(ctx, postId, filter)
+	if err != nil {
+		return models.CommentPage{}, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	// Собираем уникальных авторов страницы
+	userIds := make([]int, 0, len(comments))
+	seen := make(map[int]bool, len(comments))
 	for _, comment := range comments {
-		// Получаем информацию о пользователе
-		user, err := s.userRepo.GetByID(ctx, comment.UserID)
+		if !seen[comment.UserID] {
+			seen[comment.UserID] = true
+			userIds = append(userIds, comment.UserID)
+		}
+	}
+
+	authors := make(map[int]models.UserBrief, len(userIds))
+	if len(userIds) > 0 {
+		users, err := s.userRepo.GetByIDs(ctx, userIds)
 		if err != nil {
-			return nil, fmt.Errorf("user not found: %w", err)
+			return models.CommentPage{}, fmt.Errorf("failed to get comment authors: %w", err)
 		}
 
-		// Подготавливаем данные пользователя
-		userBrief := models.UserBrief{
-			ID:       user.ID,
-			Username: user.Username,
-			Nickname: user.Nickname,
-			Avatar:   "", // По умолчанию пустая строка
+		for _, user := range users {
+			authors[user.ID] = newUserBrief(user)
 		}
+	}
 
-		// Если аватар не nil, используем его значение
-		if user.Avatar != nil {
-			userBrief.Avatar = *user.Avatar
+	commentIds := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		commentIds = append(commentIds, comment.ID)
+	}
+
+	mentions, err := s.commentMentions(ctx, commentIds)
+	if err != nil {
+		return models.CommentPage{}, err
+	}
+
+	// Реакции текущего пользователя на комментарии страницы
+	myReactions := make(map[int][]string)
+	if filter.ViewerID != 0 && len(commentIds) > 0 {
+		reactions, err := s.reactionRepo.GetByUserCommentIDs(ctx, filter.ViewerID, commentIds)
+		if err != nil {
+			return models.CommentPage{}, err
 		}
 
-		// Добавляем комментарий в ответ
-		response = append(response, models.CommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			CreatedAt: comment.CreatedAt,
-			PostID:    comment.PostID,
-			User:      userBrief,
-		})
+		for _, reaction := range reactions {
+			myReactions[reaction.TargetID] = append(myReactions[reaction.TargetID], reaction.Type)
+		}
 	}
 
-	return response, nil
+	// Конвертируем в ответ
+	page := models.CommentPage{Items: make([]models.CommentResponse, 0, len(comments))}
+	for _, comment := range comments {
+		response := newCommentResponse(comment, authors[comment.UserID], mentions[comment.ID])
+		if reactions, ok := myReactions[comment.ID]; ok {
+			response.MyReactions = reactions
+		}
+		page.Items = append(page.Items, response)
+	}
+
+	// Неполная страница — последняя
+	if len(comments) > 0 && len(comments) == filter.PerPage {
+		last := comments[len(comments)-1]
+		page.NextCursor = models.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
 }
 
 // Update обновляет комментарий
@@ -139,10 +317,66 @@ func (s *CommentService) Update(ctx context.Context, id int, userId int, comment
 	}
 
 	// Обновляем комментарий
-	return s.commentRepo.Update(ctx, id, commentUpdate)
+	if err := s.commentRepo.Update(ctx, id, commentUpdate); err != nil {
+		return err
+	}
+
+	// Упоминания пересчитываются от имени автора комментария, даже если правил модератор
+	if err := s.mentions.sync(ctx, comment.PostID, &id, comment.UserID, commentUpdate.Content); err != nil {
+		logrus.Warnf("Failed to save mentions for comment %d: %s", id, err.Error())
+	}
+
+	return nil
+}
+
+// commentMentions загружает упоминания для списка комментариев одним запросом
+func (s *CommentService) commentMentions(ctx context.Context, commentIds []int) (map[int][]models.MentionDetails, error) {
+	result := make(map[int][]models.MentionDetails, len(commentIds))
+	if len(commentIds) == 0 {
+		return result, nil
+	}
+
+	mentions, err := s.mentionRepo.GetByCommentIDs(ctx, commentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mention := range mentions {
+		if mention.CommentID != nil {
+			result[*mention.CommentID] = append(result[*mention.CommentID], mention)
+		}
+	}
+
+	return result, nil
+}
+
+// SetResolved отмечает замечание учтенным или снова открытым.
+// Доступно автору и соавторам поста, автору комментария и модераторам
+func (s *CommentService) SetResolved(ctx context.Context, id int, userId int, resolved bool) error {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("comment not found: %w", err)
+	}
+
+	post, err := s.postRepo.GetByID(ctx, comment.PostID)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	if post.UserID != userId && comment.UserID != userId {
+		coauthor, err := s.coauthorRepo.Get(ctx, post.ID, userId)
+		if err != nil || coauthor.Status != "accepted" {
+			user, err := s.userRepo.GetByID(ctx, userId)
+			if err != nil || (user.Role != "moderator" && user.Role != "admin") {
+				return fmt.Errorf("доступ запрещен")
+			}
+		}
+	}
+
+	return s.commentRepo.SetResolved(ctx, id, userId, resolved)
 }
 
-// Delete удаляет комментарий
+// Delete перемещает комментарий в корзину
 func (s *CommentService) Delete(ctx context.Context, id int, userId int) error {
 	// Получаем комментарий
 	comment, err := s.commentRepo.GetByID(ctx, id)
@@ -158,6 +392,98 @@ func (s *CommentService) Delete(ctx context.Context, id int, userId int) error {
 		}
 	}
 
-	// Удаляем комментарий
-	return s.commentRepo.Delete(ctx, id)
+	// Удаление чужого комментария — действие модератора, оно записывается в журнал
+	var action *models.ModerationAction
+	if comment.UserID != userId {
+		action = &models.ModerationAction{
+			ModeratorID:   &userId,
+			Action:        models.AuditActionCommentDelete,
+			TargetType:    models.AuditTargetComment,
+			TargetID:      id,
+			PreviousState: models.AuditState{"content": comment.Content, "user_id": comment.UserID, "post_id": comment.PostID},
+			NewState:      models.AuditState{"deleted": true},
+			CreatedAt:     time.Now(),
+		}
+	}
+
+	// Перемещаем комментарий в корзину
+	if err := s.commentRepo.Delete(ctx, id, userId, action); err != nil {
+		return err
+	}
+
+	s.events.postCounts(ctx, s.postRepo, comment.PostID)
+
+	return nil
+}
+
+// validateAnnotation проверяет, что отмеченная область целиком лежит внутри медиа.
+// У поста пока один медиафайл, поэтому допустим только индекс 0
+func validateAnnotation(annotation models.CommentAnnotation) error {
+	if annotation.MediaIndex != 0 {
+		return models.ErrInvalidAnnotation
+	}
+
+	// Область задается шириной и высотой одновременно, точка — без них
+	if (annotation.Width == nil) != (annotation.Height == nil) {
+		return models.ErrInvalidAnnotation
+	}
+
+	if annotation.Width != nil && (annotation.X+*annotation.Width > 1 || annotation.Y+*annotation.Height > 1) {
+		return models.ErrInvalidAnnotation
+	}
+
+	return nil
+}
+
+// newCommentResponse преобразует комментарий и данные автора в ответ API
+func newCommentResponse(comment models.Comment, author models.UserBrief, mentions []models.MentionDetails) models.CommentResponse {
+	response := models.CommentResponse{
+		ID:           comment.ID,
+		Content:      comment.Content,
+		CreatedAt:    comment.CreatedAt,
+		PostID:       comment.PostID,
+		ParentID:     comment.ParentID,
+		Depth:        comment.Depth,
+		RepliesCount: comment.RepliesCount,
+		User:         author,
+		Mentions:     newMentionBriefs(mentions),
+		Resolved:     comment.ResolvedAt != nil,
+		ResolvedAt:   comment.ResolvedAt,
+		ResolvedBy:   comment.ResolvedBy,
+		Reactions:    comment.Reactions,
+		MyReactions:  []string{},
+	}
+
+	if response.Reactions == nil {
+		response.Reactions = models.ReactionCounts{}
+	}
+
+	if comment.MediaIndex != nil && comment.X != nil && comment.Y != nil {
+		response.Annotation = &models.CommentAnnotation{
+			MediaIndex: *comment.MediaIndex,
+			X:          *comment.X,
+			Y:          *comment.Y,
+			Width:      comment.Width,
+			Height:     comment.Height,
+		}
+	}
+
+	return response
+}
+
+// newUserBrief преобразует пользователя в краткую информацию об авторе
+func newUserBrief(user models.User) models.UserBrief {
+	brief := models.UserBrief{
+		ID:       user.ID,
+		Username: user.Username,
+		Nickname: user.Nickname,
+		Avatar:   "", // По умолчанию пустая строка
+	}
+
+	// Если аватар не nil, используем его значение
+	if user.Avatar != nil {
+		brief.Avatar = *user.Avatar
+	}
+
+	return brief
 }
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func float(v float64) *float64 { return &v }
+
+func TestCreateCommentWithAnnotation(t *testing.T) {
+	f := newAccessFixture()
+	service := f.commentService()
+
+	annotation := &models.CommentAnnotation{X: 0.5, Y: 0.25, Width: float(0.5), Height: float(0.75)}
+	id, err := service.Create(context.Background(), strangerID, models.CommentCreate{
+		PostID:     postIDByStatus["approved"],
+		Content:    "тень здесь темнее",
+		Annotation: annotation,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	saved := f.comments.comments[id]
+	if saved.MediaIndex == nil || *saved.MediaIndex != 0 || *saved.X != 0.5 || *saved.Y != 0.25 || *saved.Width != 0.5 || *saved.Height != 0.75 {
+		t.Errorf("annotation was not saved as sent: %+v", saved)
+	}
+}
+
+func TestCreateCommentRejectsInvalidAnnotation(t *testing.T) {
+	invalid := map[string]models.CommentAnnotation{
+		"second media file":    {MediaIndex: 1, X: 0.1, Y: 0.1},
+		"width without height": {X: 0.1, Y: 0.1, Width: float(0.2)},
+		"height without width": {X: 0.1, Y: 0.1, Height: float(0.2)},
+		"past the right edge":  {X: 0.8, Y: 0.1, Width: float(0.3), Height: float(0.1)},
+		"past the bottom edge": {X: 0.1, Y: 0.9, Width: float(0.1), Height: float(0.2)},
+	}
+
+	for name, annotation := range invalid {
+		f := newAccessFixture()
+		_, err := f.commentService().Create(context.Background(), strangerID, models.CommentCreate{
+			PostID:     postIDByStatus["approved"],
+			Content:    "здесь",
+			Annotation: &annotation,
+		})
+
+		if !errors.Is(err, models.ErrInvalidAnnotation) {
+			t.Errorf("%s: error = %v, want ErrInvalidAnnotation", name, err)
+		}
+		if len(f.comments.comments) != 0 {
+			t.Errorf("%s: comment was stored", name)
+		}
+	}
+}
+
+func TestValidateAnnotationAcceptsPointAndEdge(t *testing.T) {
+	if err := validateAnnotation(models.CommentAnnotation{X: 1, Y: 1}); err != nil {
+		t.Errorf("point in the corner: %v", err)
+	}
+	if err := validateAnnotation(models.CommentAnnotation{X: 0.5, Y: 0.5, Width: float(0.5), Height: float(0.5)}); err != nil {
+		t.Errorf("area touching the edge: %v", err)
+	}
+}
+
+// Скрытый по жалобам комментарий закрывает и свою ветку: на него нельзя ответить,
+// нельзя получить ответы на него и поставить на него реакцию
+func TestHiddenCommentThreadIsClosed(t *testing.T) {
+	f := newAccessFixture()
+	postID := postIDByStatus["approved"]
+	hiddenAt := time.Now()
+	parentID := 1
+	f.comments.comments[parentID] = models.Comment{ID: parentID, PostID: postID, UserID: coauthorID, HiddenAt: &hiddenAt}
+	f.comments.comments[2] = models.Comment{ID: 2, PostID: postID, UserID: authorID, ParentID: &parentID, Depth: 1}
+	service := f.commentService()
+
+	_, err := service.Create(context.Background(), strangerID, models.CommentCreate{PostID: postID, ParentID: &parentID, Content: "согласен"})
+	if err == nil || !strings.Contains(err.Error(), "comment not found") {
+		t.Errorf("reply to hidden comment: error = %v, want comment not found", err)
+	}
+
+	if _, err := service.GetReplies(context.Background(), parentID, models.CommentFilter{PerPage: 20}); err == nil {
+		t.Error("replies of a hidden comment were listed")
+	}
+
+	reactions := NewReactionService(nil, f.posts, f.comments, f.blocks, config.ReactionsConfig{Types: []string{"fire"}})
+	if err := reactions.Add(context.Background(), strangerID, models.ReactionTargetComment, parentID, "fire"); err == nil {
+		t.Error("reaction on a hidden comment was accepted")
+	}
+
+	if len(f.notifications.sent) != 0 {
+		t.Errorf("got %d notifications", len(f.notifications.sent))
+	}
+}
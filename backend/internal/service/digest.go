@@ -0,0 +1,328 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/mailer"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed templates/digest.html templates/digest.txt
+var digestTemplates embed.FS
+
+type DigestService struct {
+	digestRepo  repository.Digest
+	mailer      mailer.Mailer
+	fileStorage FileStorage
+	tokens      unsubscribeTokens
+	html        *htmltemplate.Template
+	text        *texttemplate.Template
+	cfg         config.DigestConfig
+}
+
+func NewDigestService(digestRepo repository.Digest, mailer mailer.Mailer, fileStorage FileStorage, cfg config.DigestConfig) *DigestService {
+	return &DigestService{
+		digestRepo:  digestRepo,
+		mailer:      mailer,
+		fileStorage: fileStorage,
+		tokens:      unsubscribeTokens{secret: []byte(cfg.UnsubscribeSecret)},
+		html:        htmltemplate.Must(htmltemplate.ParseFS(digestTemplates, "templates/digest.html")),
+		text:        texttemplate.Must(texttemplate.ParseFS(digestTemplates, "templates/digest.txt")),
+		cfg:         cfg,
+	}
+}
+
+// digestEmail данные шаблона письма
+type digestEmail struct {
+	Name           string
+	Period         string
+	Posts          []digestEmailPost
+	Activity       []digestEmailActivity
+	SiteURL        string
+	SettingsURL    string
+	UnsubscribeURL string
+}
+
+type digestEmailPost struct {
+	Title    string
+	Author   string
+	Likes    int
+	URL      string
+	ImageURL string // Пусто для видео
+}
+
+type digestEmailActivity struct {
+	Text string
+	URL  string
+}
+
+// SendDue отправляет дайджесты всем пользователям, которым пора их получить
+func (s *DigestService) SendDue(ctx context.Context) error {
+	// PostgreSQL хранит время с точностью до микросекунд, а Release сравнивает его на равенство
+	now := time.Now().Truncate(time.Microsecond)
+
+	// Задача запускается с периодом cfg.Interval, поэтому дайджест, до срока которого
+	// осталось меньше периода, отправляется сейчас, а не на следующем проходе
+	dailyBefore := now.Add(-digestPeriod(models.DigestDaily) + s.cfg.Interval)
+	weeklyBefore := now.Add(-digestPeriod(models.DigestWeekly) + s.cfg.Interval)
+
+	// Пользователи, которым не удалось отправить письмо, возвращаются в очередь,
+	// поэтому до конца прохода их нужно исключать, иначе ClaimDue выберет их снова
+	var failed []int
+	sent := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		recipients, err := s.digestRepo.ClaimDue(ctx, now, dailyBefore, weeklyBefore, failed, s.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		batchFailed := 0
+		for _, recipient := range recipients {
+			ok, err := s.send(ctx, recipient, now)
+			if err != nil {
+				logrus.Warnf("Failed to send digest to user %d: %s", recipient.ID, err.Error())
+
+				// Контекст может быть уже отменен, а вернуть дайджест в очередь нужно в любом случае
+				if err := s.digestRepo.Release(context.WithoutCancel(ctx), recipient.ID, now, recipient.SentAt); err != nil {
+					logrus.Warnf("Failed to release digest for user %d: %s", recipient.ID, err.Error())
+				}
+				failed = append(failed, recipient.ID)
+				batchFailed++
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(recipients) < s.cfg.BatchSize {
+			break
+		}
+
+		// Не удалось отправить ни одного письма из пачки: скорее всего, недоступен почтовый сервер.
+		// Остальные пользователи дождутся следующего прохода
+		if batchFailed == len(recipients) {
+			logrus.Warnf("Email digest pass stopped after a failed batch of %d", batchFailed)
+			break
+		}
+	}
+
+	if sent > 0 {
+		logrus.Infof("Email digests sent: %d", sent)
+	}
+
+	return nil
+}
+
+// Run периодически отправляет дайджесты до отмены контекста
+func (s *DigestService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil {
+			logrus.Errorf("Email digest failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send собирает и отправляет дайджест. Пустой дайджест не отправляется, тогда возвращается false
+func (s *DigestService) send(ctx context.Context, recipient models.DigestRecipient, now time.Time) (bool, error) {
+	// В дайджест попадает не больше одного периода, даже если предыдущий был давно
+	since := now.Add(-digestPeriod(recipient.Frequency))
+	if recipient.SentAt != nil && recipient.SentAt.After(since) {
+		since = *recipient.SentAt
+	}
+
+	posts, err := s.digestRepo.GetPosts(ctx, recipient.ID, since, s.cfg.PostsLimit)
+	if err != nil {
+		return false, err
+	}
+
+	activity, err := s.digestRepo.GetActivity(ctx, recipient.ID, since, s.cfg.ActivityLimit)
+	if err != nil {
+		return false, err
+	}
+
+	if len(posts) == 0 && len(activity) == 0 {
+		return false, nil
+	}
+
+	data := s.newDigestEmail(recipient, posts, activity)
+
+	var html, text bytes.Buffer
+	if err := s.html.Execute(&html, data); err != nil {
+		return false, fmt.Errorf("failed to render digest: %w", err)
+	}
+	if err := s.text.Execute(&text, data); err != nil {
+		return false, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      recipient.Email,
+		Subject: "DesignHub: дайджест " + data.Period,
+		HTML:    html.String(),
+		Text:    text.String(),
+		Headers: map[string]string{
+			// Отписка в один клик из интерфейса почтового клиента (RFC 8058)
+			"List-Unsubscribe":      "<" + data.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// newDigestEmail готовит данные для шаблонов письма
+func (s *DigestService) newDigestEmail(recipient models.DigestRecipient, posts []models.DigestPost, activity []models.NotificationDetails) digestEmail {
+	siteURL := strings.TrimRight(s.cfg.SiteURL, "/")
+
+	data := digestEmail{
+		Name:           recipient.Nickname,
+		Period:         "за неделю",
+		SiteURL:        siteURL,
+		SettingsURL:    siteURL + "/settings/notifications",
+		UnsubscribeURL: s.cfg.UnsubscribeURL + "?token=" + url.QueryEscape(s.tokens.sign(recipient.ID)),
+	}
+	if recipient.Frequency == models.DigestDaily {
+		data.Period = "за день"
+	}
+
+	for _, post := range posts {
+		item := digestEmailPost{
+			Title:  post.Title,
+			Author: post.AuthorNickname,
+			Likes:  post.LikesCount,
+			URL:    fmt.Sprintf("%s/posts/%d", siteURL, post.ID),
+		}
+		if post.MediaType == "image" {
+			item.ImageURL = s.fileStorage.GetFileURL(post.MediaPath)
+		}
+		data.Posts = append(data.Posts, item)
+	}
+
+	for _, notification := range activity {
+		item := digestEmailActivity{
+			Text: digestActivityText(notification),
+			URL:  siteURL + "/notifications",
+		}
+		if notification.PostID != nil {
+			item.URL = fmt.Sprintf("%s/posts/%d", siteURL, *notification.PostID)
+		}
+		data.Activity = append(data.Activity, item)
+	}
+
+	return data
+}
+
+// digestActivityText описывает уведомление одной строкой
+func digestActivityText(notification models.NotificationDetails) string {
+	title := ""
+	if notification.PostTitle != nil {
+		title = *notification.PostTitle
+	}
+
+	names := make([]string, 0, len(notification.Actors))
+	for _, actor := range notification.Actors {
+		names = append(names, actor.Nickname)
+	}
+	actors := strings.Join(names, ", ")
+	if more := notification.ActorsCount - len(names); more > 0 {
+		actors += fmt.Sprintf(" и еще %d", more)
+	}
+
+	switch notification.Type {
+	case models.NotificationTypePostApproved:
+		return fmt.Sprintf("Работа «%s» опубликована", title)
+	case models.NotificationTypePostRejected:
+		return fmt.Sprintf("Работа «%s» отклонена модератором", title)
+	case models.NotificationTypeLike:
+		return fmt.Sprintf("Лайки к работе «%s»: %s", title, actors)
+	case models.NotificationTypeComment:
+		return fmt.Sprintf("Комментарии к работе «%s»: %s", title, actors)
+	case models.NotificationTypeReply:
+		return fmt.Sprintf("Ответы на ваш комментарий к работе «%s»: %s", title, actors)
+	case models.NotificationTypeMention:
+		return fmt.Sprintf("Вас упомянули в обсуждении работы «%s»: %s", title, actors)
+	case models.NotificationTypeFollow:
+		return fmt.Sprintf("Новые подписчики: %s", actors)
+	case models.NotificationTypeWarning:
+		return fmt.Sprintf("Предупреждение модератора по работе «%s»", title)
+	default:
+		return "Новое уведомление"
+	}
+}
+
+// digestPeriod возвращает период между дайджестами
+func digestPeriod(frequency string) time.Duration {
+	if frequency == models.DigestDaily {
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// unsubscribeTokens подписывает ссылки отписки от дайджеста, чтобы отписаться
+// можно было без входа в аккаунт, но только по ссылке из письма
+type unsubscribeTokens struct {
+	secret []byte
+}
+
+// sign создает токен вида <id пользователя>.<подпись>
+func (t unsubscribeTokens) sign(userId int) string {
+	id := strconv.Itoa(userId)
+	return id + "." + base64.RawURLEncoding.EncodeToString(t.mac(id))
+}
+
+// parse проверяет подпись токена и возвращает ID пользователя
+func (t unsubscribeTokens) parse(token string) (int, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, models.ErrInvalidUnsubscribeToken
+	}
+
+	userId, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, models.ErrInvalidUnsubscribeToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, t.mac(id)) {
+		return 0, models.ErrInvalidUnsubscribeToken
+	}
+
+	return userId, nil
+}
+
+func (t unsubscribeTokens) mac(id string) []byte {
+	h := hmac.New(sha256.New, t.secret)
+	h.Write([]byte("digest-unsubscribe:" + id))
+	return h.Sum(nil)
+}
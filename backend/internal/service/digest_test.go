@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/mailer"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDigestRepo выдает получателей из очереди, как ClaimDue в базе: выданный
+// получатель уходит из очереди, а после Release возвращается в нее
+type fakeDigestRepo struct {
+	repository.Digest
+	due      []models.DigestRecipient
+	claims   int
+	released []int
+}
+
+func (r *fakeDigestRepo) ClaimDue(ctx context.Context, now, dailyBefore, weeklyBefore time.Time, exclude []int, limit int) ([]models.DigestRecipient, error) {
+	r.claims++
+
+	skip := make(map[int]bool)
+	for _, id := range exclude {
+		skip[id] = true
+	}
+
+	var batch, rest []models.DigestRecipient
+	for _, recipient := range r.due {
+		if !skip[recipient.ID] && len(batch) < limit {
+			batch = append(batch, recipient)
+		} else {
+			rest = append(rest, recipient)
+		}
+	}
+	r.due = rest
+
+	return batch, nil
+}
+
+func (r *fakeDigestRepo) GetPosts(ctx context.Context, userID int, since time.Time, limit int) ([]models.DigestPost, error) {
+	return []models.DigestPost{{ID: 1, Title: "Постер", MediaType: "video"}}, nil
+}
+
+func (r *fakeDigestRepo) GetActivity(ctx context.Context, userID int, since time.Time, limit int) ([]models.NotificationDetails, error) {
+	return nil, nil
+}
+
+func (r *fakeDigestRepo) Release(ctx context.Context, userID int, claimedAt time.Time, sentAt *time.Time) error {
+	r.released = append(r.released, userID)
+	r.due = append(r.due, digestRecipients(userID)...)
+	return nil
+}
+
+// fakeMailer не доставляет письма на адреса из failFor, а при заданном err — никому
+type fakeMailer struct {
+	sent    []mailer.Message
+	err     error
+	failFor map[string]bool
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.failFor[msg.To] {
+		return errors.New("mailbox unavailable")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type fakePreferenceRepo struct {
+	repository.NotificationPreference
+	frequency map[int]string
+}
+
+func (r *fakePreferenceRepo) SetDigestFrequency(ctx context.Context, userID int, frequency string) error {
+	r.frequency[userID] = frequency
+	return nil
+}
+
+var digestConfig = config.DigestConfig{
+	Interval:          time.Minute,
+	BatchSize:         2,
+	PostsLimit:        5,
+	ActivityLimit:     5,
+	SiteURL:           "https://designhub.test",
+	UnsubscribeURL:    "https://designhub.test/api/v1/public/unsubscribe",
+	UnsubscribeSecret: "digest-secret",
+}
+
+func digestRecipients(ids ...int) []models.DigestRecipient {
+	recipients := make([]models.DigestRecipient, 0, len(ids))
+	for _, id := range ids {
+		recipients = append(recipients, models.DigestRecipient{ID: id, Email: fmt.Sprintf("user%d@designhub.test", id), Frequency: models.DigestWeekly})
+	}
+	return recipients
+}
+
+// Ссылка из письма отписывает именно получателя, а подделанная ссылка не принимается
+func TestDigestUnsubscribeLink(t *testing.T) {
+	mail := &fakeMailer{}
+	digests := NewDigestService(&fakeDigestRepo{due: digestRecipients(7)}, mail, nil, digestConfig)
+
+	if err := digests.SendDue(context.Background()); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+	if len(mail.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(mail.sent))
+	}
+
+	link, err := url.Parse(strings.Trim(mail.sent[0].Headers["List-Unsubscribe"], "<>"))
+	if err != nil {
+		t.Fatalf("bad List-Unsubscribe header: %v", err)
+	}
+	token := link.Query().Get("token")
+
+	preferences := &fakePreferenceRepo{frequency: map[int]string{}}
+	notifications := NewNotificationService(nil, preferences, nil, digestConfig)
+
+	// Подпись от пользователя 7 не подходит к другому ID
+	_, signature, _ := strings.Cut(token, ".")
+	for _, forged := range []string{"8." + signature, "7", "7.", "x." + signature} {
+		if err := notifications.Unsubscribe(context.Background(), forged); !errors.Is(err, models.ErrInvalidUnsubscribeToken) {
+			t.Errorf("Unsubscribe(%q) error = %v, want ErrInvalidUnsubscribeToken", forged, err)
+		}
+	}
+
+	// Токен, подписанный другим ключом, тоже не принимается
+	otherSecret := digestConfig
+	otherSecret.UnsubscribeSecret = "another-secret"
+	if err := NewNotificationService(nil, preferences, nil, otherSecret).CheckUnsubscribeToken(token); err == nil {
+		t.Error("token accepted with another secret")
+	}
+
+	if len(preferences.frequency) != 0 {
+		t.Fatalf("rejected tokens changed preferences: %v", preferences.frequency)
+	}
+
+	if err := notifications.CheckUnsubscribeToken(token); err != nil {
+		t.Fatalf("CheckUnsubscribeToken() error = %v", err)
+	}
+	if len(preferences.frequency) != 0 {
+		t.Fatal("checking a token must not unsubscribe")
+	}
+
+	if err := notifications.Unsubscribe(context.Background(), token); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if preferences.frequency[7] != models.DigestOff {
+		t.Errorf("digest frequency = %v, want user 7 off", preferences.frequency)
+	}
+}
+
+// Если почтовый сервер недоступен, проход завершается, а не выбирает одних и тех же
+// получателей снова и снова; все они возвращаются в очередь
+func TestSendDueStopsWhenMailerFails(t *testing.T) {
+	repo := &fakeDigestRepo{due: digestRecipients(1, 2, 3, 4, 5)}
+	digests := NewDigestService(repo, &fakeMailer{err: errors.New("connection refused")}, nil, digestConfig)
+
+	done := make(chan error, 1)
+	go func() { done <- digests.SendDue(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("SendDue() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SendDue() did not return")
+	}
+
+	if repo.claims != 1 || len(repo.released) != 2 {
+		t.Errorf("claims = %d, released = %v; want one failed batch of 2 released", repo.claims, repo.released)
+	}
+}
+
+// Получатель, которому не удалось отправить письмо, до конца прохода не выбирается снова,
+// а остальные получают дайджест
+func TestSendDueSkipsFailedRecipient(t *testing.T) {
+	repo := &fakeDigestRepo{due: digestRecipients(1, 2, 3, 4, 5)}
+	mail := &fakeMailer{failFor: map[string]bool{"user2@designhub.test": true}}
+
+	if err := NewDigestService(repo, mail, nil, digestConfig).SendDue(context.Background()); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+
+	if len(mail.sent) != 4 {
+		t.Errorf("sent %d emails, want 4", len(mail.sent))
+	}
+	if len(repo.released) != 1 || repo.released[0] != 2 {
+		t.Errorf("released = %v, want [2]", repo.released)
+	}
+	if repo.claims != 3 {
+		t.Errorf("claims = %d, want 3", repo.claims)
+	}
+}
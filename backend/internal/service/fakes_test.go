@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"strings"
+	"time"
+)
+
+// Заглушки репозиториев для тестов сервисов. Каждая встраивает интерфейс
+// и переопределяет только нужные методы: вызов остальных завершится паникой,
+// так что тест сразу покажет неожиданное обращение к базе
+
+type fakePostRepo struct {
+	repository.Post
+	posts map[int]models.Post
+}
+
+func (r *fakePostRepo) GetByID(ctx context.Context, id int) (models.Post, error) {
+	post, ok := r.posts[id]
+	if !ok {
+		return models.Post{}, sql.ErrNoRows
+	}
+	return post, nil
+}
+
+func (r *fakePostRepo) GetDetailsByID(ctx context.Context, id int, viewerID int) (models.PostDetails, error) {
+	post, err := r.GetByID(ctx, id)
+	return models.PostDetails{Post: post}, err
+}
+
+type fakeUserRepo struct {
+	repository.User
+	users map[int]models.User
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id int) (models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return models.User{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepo) GetByNicknames(ctx context.Context, nicknames []string) ([]models.User, error) {
+	var users []models.User
+	for _, user := range r.users {
+		for _, nickname := range nicknames {
+			if strings.EqualFold(user.Nickname, nickname) {
+				users = append(users, user)
+			}
+		}
+	}
+	return users, nil
+}
+
+func (r *fakeUserRepo) GetByIDs(ctx context.Context, ids []int) ([]models.User, error) {
+	var users []models.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type fakeCoauthorRepo struct {
+	repository.Coauthor
+	coauthors []models.PostCoauthor
+}
+
+func (r *fakeCoauthorRepo) Get(ctx context.Context, postID int, userID int) (models.PostCoauthor, error) {
+	for _, coauthor := range r.coauthors {
+		if coauthor.PostID == postID && coauthor.UserID == userID {
+			return coauthor, nil
+		}
+	}
+	return models.PostCoauthor{}, sql.ErrNoRows
+}
+
+// blockRepository псевдоним для встраивания: поле с именем Block закрыло бы метод Block
+type blockRepository = repository.Block
+
+// fakeBlockRepo хранит пары заблокировавший -> заблокированный и заглушивший -> заглушенный
+type fakeBlockRepo struct {
+	blockRepository
+	blocks [][2]int
+	muted  [][2]int
+	posts  *fakePostRepo
+}
+
+func (r *fakeBlockRepo) GetMutedIDs(ctx context.Context, userID int) ([]int, error) {
+	var ids []int
+	for _, mute := range r.muted {
+		if mute[0] == userID {
+			ids = append(ids, mute[1])
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeBlockRepo) IsBlockedBetween(ctx context.Context, userID int, otherID int) (bool, error) {
+	for _, block := range r.blocks {
+		if block == [2]int{userID, otherID} || block == [2]int{otherID, userID} {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeBlockRepo) GetBlockedIDs(ctx context.Context, userID int) ([]int, error) {
+	var ids []int
+	for _, block := range r.blocks {
+		if block[0] == userID {
+			ids = append(ids, block[1])
+		}
+		if block[1] == userID {
+			ids = append(ids, block[0])
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeBlockRepo) IsBlockedWithPost(ctx context.Context, userID int, postID int) (bool, error) {
+	if r.posts == nil {
+		return false, nil
+	}
+	return r.IsBlockedBetween(ctx, userID, r.posts.posts[postID].UserID)
+}
+
+type fakeLikeRepo struct {
+	repository.Like
+	likes []models.Like
+}
+
+func (r *fakeLikeRepo) IsLiked(ctx context.Context, postID int, userID int) (bool, error) {
+	for _, like := range r.likes {
+		if like.PostID == postID && like.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeLikeRepo) Create(ctx context.Context, like models.Like) (int, error) {
+	r.likes = append(r.likes, like)
+	return len(r.likes), nil
+}
+
+type fakeNotificationRepo struct {
+	repository.Notification
+	sent []models.Notification
+}
+
+func (r *fakeNotificationRepo) Create(ctx context.Context, notification models.Notification) (int, error) {
+	r.sent = append(r.sent, notification)
+	return len(r.sent), nil
+}
+
+type fakeCommentRepo struct {
+	repository.Comment
+	comments map[int]models.Comment
+}
+
+func (r *fakeCommentRepo) GetByID(ctx context.Context, id int) (models.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok {
+		return models.Comment{}, sql.ErrNoRows
+	}
+	return comment, nil
+}
+
+// GetByPostID возвращает видимые комментарии поста нужного уровня, как запрос в репозитории
+func (r *fakeCommentRepo) GetByPostID(ctx context.Context, postID int, filter models.CommentFilter) ([]models.Comment, error) {
+	var comments []models.Comment
+	for _, comment := range r.comments {
+		parentID := 0
+		if comment.ParentID != nil {
+			parentID = *comment.ParentID
+		}
+		if comment.PostID == postID && parentID == filter.ParentID && comment.HiddenAt == nil {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (r *fakeCommentRepo) Create(ctx context.Context, comment models.Comment) (int, error) {
+	if r.comments == nil {
+		r.comments = make(map[int]models.Comment)
+	}
+	comment.ID = len(r.comments) + 1000
+	r.comments[comment.ID] = comment
+	return comment.ID, nil
+}
+
+type fakeMentionRepo struct {
+	repository.Mention
+	saved []models.Mention
+}
+
+func (r *fakeMentionRepo) Replace(ctx context.Context, postID int, commentID *int, mentions []models.Mention) error {
+	r.saved = mentions
+	return nil
+}
+
+func (r *fakeMentionRepo) GetByCommentIDs(ctx context.Context, commentIDs []int) ([]models.MentionDetails, error) {
+	return nil, nil
+}
+
+func (r *fakeMentionRepo) NotifyPending(ctx context.Context, now time.Time) ([]models.Notification, error) {
+	return nil, nil
+}
+
+type fakeMessageRepo struct {
+	repository.Message
+	members  []models.ConversationMember
+	messages map[int]models.Message
+}
+
+func (r *fakeMessageRepo) GetMembers(ctx context.Context, conversationID int) ([]models.ConversationMember, error) {
+	var members []models.ConversationMember
+	for _, member := range r.members {
+		if member.ConversationID == conversationID {
+			members = append(members, member)
+		}
+	}
+	if len(members) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return members, nil
+}
+
+func (r *fakeMessageRepo) CreateMessage(ctx context.Context, message models.Message) (int, error) {
+	if r.messages == nil {
+		r.messages = make(map[int]models.Message)
+	}
+	message.ID = len(r.messages) + 1
+	r.messages[message.ID] = message
+	return message.ID, nil
+}
+
+func (r *fakeMessageRepo) GetMessage(ctx context.Context, messageID int) (models.Message, error) {
+	message, ok := r.messages[messageID]
+	if !ok {
+		return models.Message{}, sql.ErrNoRows
+	}
+	return message, nil
+}
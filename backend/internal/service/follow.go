@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"fmt"
+	"time"
+)
+
+type FollowService struct {
+	followRepo    repository.Follow
+	userRepo      repository.User
+	categoryRepo  repository.Category
+	blocks        blockChecker
+	notifications notifier
+}
+
+func NewFollowService(
+	followRepo repository.Follow,
+	userRepo repository.User,
+	categoryRepo repository.Category,
+	blockRepo repository.Block,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
+) *FollowService {
+	return &FollowService{
+		followRepo:    followRepo,
+		userRepo:      userRepo,
+		categoryRepo:  categoryRepo,
+		blocks:        blockChecker{blockRepo: blockRepo},
+		notifications: newNotifier(notificationRepo, hub),
+	}
+}
+
+// FollowUser подписывает пользователя на другого пользователя
+func (s *FollowService) FollowUser(ctx context.Context, userId int, followeeId int) error {
+	if userId == followeeId {
+		return models.ErrSelfFollow
+	}
+
+	// Проверяем, что пользователь существует
+	if _, err := s.userRepo.GetByID(ctx, followeeId); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	blocked, err := s.blocks.between(ctx, userId, followeeId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return models.ErrUserBlocked
+	}
+
+	added, err := s.followRepo.FollowUser(ctx, userId, followeeId, time.Now())
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("подписка уже существует")
+	}
+
+	// Новые подписчики собираются в одно уведомление
+	s.notifications.send(ctx, models.Notification{
+		UserID:   followeeId,
+		ActorID:  &userId,
+		Type:     models.NotificationTypeFollow,
+		GroupKey: notificationGroupKey(models.NotificationTypeFollow, "user", followeeId),
+	})
+
+	return nil
+}
+
+// UnfollowUser отменяет подписку на пользователя
+func (s *FollowService) UnfollowUser(ctx context.Context, userId int, followeeId int) error {
+	return s.followRepo.UnfollowUser(ctx, userId, followeeId)
+}
+
+// FollowCategory подписывает пользователя на категорию
+func (s *FollowService) FollowCategory(ctx context.Context, userId int, categoryId int) error {
+	// Проверяем, что категория существует
+	if _, err := s.categoryRepo.GetByID(ctx, categoryId); err != nil {
+		return fmt.Errorf("category not found: %w", err)
+	}
+
+	added, err := s.followRepo.FollowCategory(ctx, userId, categoryId, time.Now())
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("подписка уже существует")
+	}
+
+	return nil
+}
+
+// UnfollowCategory отменяет подписку на категорию
+func (s *FollowService) UnfollowCategory(ctx context.Context, userId int, categoryId int) error {
+	return s.followRepo.UnfollowCategory(ctx, userId, categoryId)
+}
+
+// GetFollowers получает страницу подписчиков пользователя
+func (s *FollowService) GetFollowers(ctx context.Context, userId int, currentUserId int, filter models.FollowFilter) (models.FollowUsersResponse, error) {
+	return s.getUsers(ctx, userId, currentUserId, filter, s.followRepo.GetFollowers)
+}
+
+// GetFollowing получает страницу пользователей, на которых подписан пользователь
+func (s *FollowService) GetFollowing(ctx context.Context, userId int, currentUserId int, filter models.FollowFilter) (models.FollowUsersResponse, error) {
+	return s.getUsers(ctx, userId, currentUserId, filter, s.followRepo.GetFollowing)
+}
+
+// GetFollowedCategories получает страницу категорий, на которые подписан пользователь
+func (s *FollowService) GetFollowedCategories(ctx context.Context, userId int, filter models.FollowFilter) (models.FollowedCategoriesResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userId); err != nil {
+		return models.FollowedCategoriesResponse{}, fmt.Errorf("user not found: %w", err)
+	}
+
+	filter = normalizeFollowFilter(filter)
+
+	categories, total, err := s.followRepo.GetFollowedCategories(ctx, userId, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return models.FollowedCategoriesResponse{}, err
+	}
+
+	items := make([]models.FollowedCategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		category.Category.IsFollowing = true
+		items = append(items, models.FollowedCategoryResponse{Category: category.Category, FollowedAt: category.FollowedAt})
+	}
+
+	return models.FollowedCategoriesResponse{
+		Categories: items,
+		Pagination: newFollowPagination(filter, total),
+	}, nil
+}
+
+// getUsers выбирает страницу подписчиков или подписок через переданный метод репозитория
+func (s *FollowService) getUsers(
+	ctx context.Context,
+	userId int,
+	currentUserId int,
+	filter models.FollowFilter,
+	fetch func(ctx context.Context, userID int, viewerID int, limit, offset int) ([]models.FollowUser, int, error),
+) (models.FollowUsersResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userId); err != nil {
+		return models.FollowUsersResponse{}, fmt.Errorf("user not found: %w", err)
+	}
+
+	filter = normalizeFollowFilter(filter)
+
+	users, total, err := fetch(ctx, userId, currentUserId, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return models.FollowUsersResponse{}, err
+	}
+
+	items := make([]models.FollowUserResponse, 0, len(users))
+	for _, user := range users {
+		brief := models.UserBrief{
+			ID:       user.UserID,
+			Username: user.Username,
+			Nickname: user.Nickname,
+		}
+		if user.Avatar != nil {
+			brief.Avatar = *user.Avatar
+		}
+
+		items = append(items, models.FollowUserResponse{
+			User:           brief,
+			FollowersCount: user.FollowersCount,
+			IsFollowing:    user.IsFollowing,
+			FollowedAt:     user.FollowedAt,
+		})
+	}
+
+	return models.FollowUsersResponse{
+		Users:      items,
+		Pagination: newFollowPagination(filter, total),
+	}, nil
+}
+
+// normalizeFollowFilter подставляет параметры пагинации по умолчанию
+func normalizeFollowFilter(filter models.FollowFilter) models.FollowFilter {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	return filter
+}
+
+// newFollowPagination формирует данные пагинации списка подписок
+func newFollowPagination(filter models.FollowFilter, total int) models.Pagination {
+	return models.Pagination{
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+		Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+	}
+}
@@ -4,30 +4,50 @@ import (
 	"context"
 	"designhub/internal/models"
 	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
 	"fmt"
 	"time"
 )
 
 type LikeService struct {
-	likeRepo repository.Like
-	postRepo repository.Post
+	likeRepo      repository.Like
+	postRepo      repository.Post
+	access        postAccess
+	notifications notifier
+	events        eventPublisher
 }
 
-func NewLikeService(likeRepo repository.Like, postRepo repository.Post) *LikeService {
+func NewLikeService(
+	likeRepo repository.Like,
+	postRepo repository.Post,
+	userRepo repository.User,
+	coauthorRepo repository.Coauthor,
+	blockRepo repository.Block,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
+) *LikeService {
 	return &LikeService{
-		likeRepo: likeRepo,
-		postRepo: postRepo,
+		likeRepo:      likeRepo,
+		postRepo:      postRepo,
+		access:        newPostAccess(userRepo, coauthorRepo, blockRepo),
+		notifications: newNotifier(notificationRepo, hub),
+		events:        eventPublisher{hub: hub},
 	}
 }
 
 // Create создает новый лайк
 func (s *LikeService) Create(ctx context.Context, userId int, like models.LikeCreate) (int, error) {
 	// Проверяем, что пост существует
-	_, err := s.postRepo.GetByID(ctx, like.PostID)
+	post, err := s.postRepo.GetByID(ctx, like.PostID)
 	if err != nil {
 		return 0, fmt.Errorf("post not found: %w", err)
 	}
 
+	// Лайкнуть можно только пост, который пользователь видит
+	if err := s.access.check(ctx, post, userId); err != nil {
+		return 0, err
+	}
+
 	// Проверяем, не лайкнул ли пользователь уже этот пост
 	isLiked, err := s.likeRepo.IsLiked(ctx, like.PostID, userId)
 	if err != nil {
@@ -44,7 +64,23 @@ func (s *LikeService) Create(ctx context.Context, userId int, like models.LikeCr
 		CreatedAt: time.Now(),
 	}
 
-	return s.likeRepo.Create(ctx, newLike)
+	id, err := s.likeRepo.Create(ctx, newLike)
+	if err != nil {
+		return 0, err
+	}
+
+	// Лайки одного поста собираются в одно уведомление, пока автор его не прочитал
+	s.notifications.send(ctx, models.Notification{
+		UserID:   post.UserID,
+		ActorID:  &userId,
+		Type:     models.NotificationTypeLike,
+		PostID:   &post.ID,
+		GroupKey: notificationGroupKey(models.NotificationTypeLike, "post", post.ID),
+	})
+
+	s.events.postCounts(ctx, s.postRepo, post.ID)
+
+	return id, nil
 }
 
 // Delete удаляет лайк
@@ -65,7 +101,13 @@ func (s *LikeService) Delete(ctx context.Context, postId int, userId int) error
 	}
 
 	// Удаляем лайк
-	return s.likeRepo.DeleteByPostIDAndUserID(ctx, postId, userId)
+	if err := s.likeRepo.DeleteByPostIDAndUserID(ctx, postId, userId); err != nil {
+		return err
+	}
+
+	s.events.postCounts(ctx, s.postRepo, postId)
+
+	return nil
 }
 
 // IsLiked проверяет, лайкнул ли пользователь пост
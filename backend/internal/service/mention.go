@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxMentionsPerText ограничивает число уведомлений, которые может вызвать один текст
+const maxMentionsPerText = 20
+
+// mentionPattern находит "@никнейм", перед которым нет буквы, цифры или точки,
+// чтобы не принимать за упоминания адреса почты
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])(@[\p{L}\p{N}_][\p{L}\p{N}_.-]*)`)
+
+// mentionToken упоминание, найденное в тексте
+type mentionToken struct {
+	Nickname string
+	Offset   int // Позиция "@" в символах Unicode
+	Length   int // Длина вместе с "@"
+}
+
+// parseMentions находит упоминания в тексте. Точки и дефисы в конце отбрасываются,
+// чтобы "@anna." в конце предложения указывало на anna
+func parseMentions(text string) []mentionToken {
+	var tokens []mentionToken
+
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		mention := strings.TrimRight(text[start:end], ".-")
+
+		nickname := mention[1:]
+		if length := utf8.RuneCountInString(nickname); length < 3 || length > 50 {
+			continue
+		}
+
+		tokens = append(tokens, mentionToken{
+			Nickname: nickname,
+			Offset:   utf8.RuneCountInString(text[:start]),
+			Length:   utf8.RuneCountInString(mention),
+		})
+	}
+
+	return tokens
+}
+
+// mentionSyncer сохраняет упоминания из текста поста или комментария и рассылает уведомления
+type mentionSyncer struct {
+	mentionRepo   repository.Mention
+	userRepo      repository.User
+	blockRepo     repository.Block
+	notifications notifier
+}
+
+// sync заменяет упоминания в описании поста (commentId = nil) или в комментарии.
+// Никнеймы не уникальны, поэтому упоминание засчитывается, только если никнейм
+// однозначно указывает на одного пользователя. Автор не упоминает сам себя
+// и пользователей, с которыми связан блокировкой
+func (m mentionSyncer) sync(ctx context.Context, postId int, commentId *int, authorId int, text string) error {
+	tokens := parseMentions(text)
+
+	nicknames := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		nicknames = append(nicknames, strings.ToLower(token.Nickname))
+	}
+
+	usersByNickname := make(map[string][]models.User)
+	if len(nicknames) > 0 {
+		users, err := m.userRepo.GetByNicknames(ctx, nicknames)
+		if err != nil {
+			return fmt.Errorf("failed to resolve mentions: %w", err)
+		}
+
+		for _, user := range users {
+			key := strings.ToLower(user.Nickname)
+			usersByNickname[key] = append(usersByNickname[key], user)
+		}
+	}
+
+	blocked := make(map[int]bool)
+	if len(nicknames) > 0 {
+		ids, err := m.blockRepo.GetBlockedIDs(ctx, authorId)
+		if err != nil {
+			return fmt.Errorf("failed to resolve mentions: %w", err)
+		}
+
+		for _, id := range ids {
+			blocked[id] = true
+		}
+	}
+
+	var mentions []models.Mention
+	mentioned := make(map[int]bool)
+	for _, token := range tokens {
+		candidates := usersByNickname[strings.ToLower(token.Nickname)]
+		if len(candidates) != 1 || candidates[0].ID == authorId || blocked[candidates[0].ID] {
+			continue
+		}
+
+		// Повторное упоминание того же пользователя отмечается в тексте, но не увеличивает лимит
+		userId := candidates[0].ID
+		if !mentioned[userId] && len(mentioned) >= maxMentionsPerText {
+			continue
+		}
+		mentioned[userId] = true
+
+		mentions = append(mentions, models.Mention{
+			PostID:          postId,
+			CommentID:       commentId,
+			AuthorID:        authorId,
+			MentionedUserID: userId,
+			Offset:          token.Offset,
+			Length:          token.Length,
+			CreatedAt:       time.Now(),
+		})
+	}
+
+	if err := m.mentionRepo.Replace(ctx, postId, commentId, mentions); err != nil {
+		return err
+	}
+
+	return m.notify(ctx)
+}
+
+// notify рассылает уведомления по упоминаниям, которые стали видны после публикации поста
+func (m mentionSyncer) notify(ctx context.Context) error {
+	notifications, err := m.mentionRepo.NotifyPending(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		m.notifications.push(ctx, notification.UserID, notification.ID)
+	}
+
+	return nil
+}
+
+// newMentionBriefs преобразует упоминания в формат ответа API
+func newMentionBriefs(mentions []models.MentionDetails) []models.MentionBrief {
+	briefs := make([]models.MentionBrief, 0, len(mentions))
+	for _, mention := range mentions {
+		briefs = append(briefs, models.MentionBrief{
+			UserID:   mention.MentionedUserID,
+			Username: mention.Username,
+			Nickname: mention.Nickname,
+			Offset:   mention.Offset,
+			Length:   mention.Length,
+		})
+	}
+
+	return briefs
+}
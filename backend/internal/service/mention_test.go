@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want []mentionToken
+	}{
+		"single":               {"hi @anna", []mentionToken{{"anna", 3, 5}}},
+		"trailing punctuation": {"thanks @anna.", []mentionToken{{"anna", 7, 5}}},
+		"rune offsets":         {"привет @мария", []mentionToken{{"мария", 7, 6}}},
+		"several":              {"@anna и @boris-", []mentionToken{{"anna", 0, 5}, {"boris", 8, 6}}},
+		"email":                {"mail@anna.com", nil},
+		"double at":            {"@@anna", nil},
+		"too short":            {"@ab", nil},
+		"too long":             {"@" + strings.Repeat("a", 51), nil},
+	}
+
+	for name, tt := range tests {
+		if got := parseMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseMentions(%q) = %+v, want %+v", name, tt.text, got, tt.want)
+		}
+	}
+}
+
+// Упоминание пользователя из блокировки не сохраняется, поэтому уведомление
+// об упоминании ему не уходит, а остальные упоминания того же текста сохраняются
+func TestCommentMentionSkipsBlockedUser(t *testing.T) {
+	f := newAccessFixture()
+	f.users.users[strangerID] = models.User{ID: strangerID, Nickname: "stranger", Role: "user"}
+	f.users.users[coauthorID] = models.User{ID: coauthorID, Nickname: "friend", Role: "user"}
+	f.blocks.blocks = [][2]int{{strangerID, moderatorID}}
+
+	mentions := &fakeMentionRepo{}
+	service := NewCommentService(f.comments, f.users, f.posts, f.coauthors, mentions, nil, f.blocks, f.notifications, nil, commentsConfig)
+
+	comment := models.CommentCreate{PostID: postIDByStatus["approved"], Content: "@stranger посмотри, @friend тоже"}
+	if _, err := service.Create(context.Background(), moderatorID, comment); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if len(mentions.saved) != 1 || mentions.saved[0].MentionedUserID != coauthorID {
+		t.Errorf("saved mentions = %+v, want only user %d", mentions.saved, coauthorID)
+	}
+}
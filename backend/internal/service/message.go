@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type MessageService struct {
+	messageRepo repository.Message
+	userRepo    repository.User
+	followRepo  repository.Follow
+	blocks      blockChecker
+	events      eventPublisher
+}
+
+func NewMessageService(messageRepo repository.Message, userRepo repository.User, followRepo repository.Follow, blockRepo repository.Block, hub pubsub.Hub) *MessageService {
+	return &MessageService{
+		messageRepo: messageRepo,
+		userRepo:    userRepo,
+		followRepo:  followRepo,
+		blocks:      blockChecker{blockRepo: blockRepo},
+		events:      eventPublisher{hub: hub},
+	}
+}
+
+// SendToUser отправляет сообщение пользователю, при необходимости создавая диалог
+func (s *MessageService) SendToUser(ctx context.Context, userId int, recipientId int, input models.MessageCreate) (models.MessageResponse, error) {
+	if recipientId == userId {
+		return models.MessageResponse{}, models.ErrSelfMessage
+	}
+
+	recipient, err := s.userRepo.GetByID(ctx, recipientId)
+	if err != nil {
+		return models.MessageResponse{}, fmt.Errorf("user not found: %w", err)
+	}
+
+	conversationId, err := s.messageRepo.FindConversation(ctx, userId, recipientId)
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	if conversationId == 0 {
+		status, err := s.admit(ctx, recipient, userId)
+		if err != nil {
+			return models.MessageResponse{}, err
+		}
+
+		conversationId, err = s.messageRepo.CreateConversation(ctx, userId, recipientId, status, time.Now())
+		if err != nil {
+			return models.MessageResponse{}, err
+		}
+	}
+
+	return s.send(ctx, userId, conversationId, input)
+}
+
+// SendToConversation отправляет сообщение в существующий диалог
+func (s *MessageService) SendToConversation(ctx context.Context, userId int, conversationId int, input models.MessageCreate) (models.MessageResponse, error) {
+	return s.send(ctx, userId, conversationId, input)
+}
+
+// send проверяет, что получатель принимает сообщения, и сохраняет сообщение
+func (s *MessageService) send(ctx context.Context, userId int, conversationId int, input models.MessageCreate) (models.MessageResponse, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return models.MessageResponse{}, models.ErrEmptyMessage
+	}
+
+	me, peer, err := s.members(ctx, conversationId, userId)
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	// Блокировка закрывает переписку в обе стороны, даже если диалог уже принят
+	blocked, err := s.blocks.between(ctx, userId, peer.UserID)
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+	if blocked {
+		return models.MessageResponse{}, models.ErrMessagesRestricted
+	}
+
+	switch peer.Status {
+	case models.ConversationStatusDeclined:
+		return models.MessageResponse{}, models.ErrMessagesRestricted
+	case models.ConversationStatusRequest:
+		// Пока запрос не принят, настройки получателя могли измениться
+		recipient, err := s.userRepo.GetByID(ctx, peer.UserID)
+		if err != nil {
+			return models.MessageResponse{}, fmt.Errorf("user not found: %w", err)
+		}
+
+		status, err := s.admit(ctx, recipient, userId)
+		if err != nil {
+			return models.MessageResponse{}, err
+		}
+
+		if status == models.ConversationStatusAccepted {
+			if err := s.messageRepo.SetStatus(ctx, conversationId, peer.UserID, status); err != nil {
+				return models.MessageResponse{}, err
+			}
+			peer.Status = status
+		}
+	}
+
+	// Ответ на запрос принимает его
+	if me.Status != models.ConversationStatusAccepted {
+		if err := s.messageRepo.SetStatus(ctx, conversationId, userId, models.ConversationStatusAccepted); err != nil {
+			return models.MessageResponse{}, err
+		}
+	}
+
+	message := models.Message{
+		ConversationID: conversationId,
+		SenderID:       userId,
+		Body:           body,
+		CreatedAt:      time.Now(),
+	}
+
+	message.ID, err = s.messageRepo.CreateMessage(ctx, message)
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	// Сообщение появляется у собеседника и в других вкладках отправителя. В событие
+	// попадают только ID, текст подписчик загрузит сам
+	ref := models.MessageRef{MessageID: message.ID, ConversationID: conversationId}
+	s.events.publish(ctx, userTopic(peer.UserID), models.StreamEventMessage, ref)
+	s.events.publish(ctx, userTopic(userId), models.StreamEventMessage, ref)
+
+	return newMessageResponse(message, peer.LastReadMessageID), nil
+}
+
+// admit определяет, куда попадет новый диалог у получателя: во входящие, если он подписан
+// на отправителя, иначе в запросы. Возвращает ошибку, если получатель не принимает сообщения
+func (s *MessageService) admit(ctx context.Context, recipient models.User, senderId int) (string, error) {
+	if recipient.DMPolicy == models.DMPolicyNobody {
+		return "", models.ErrMessagesRestricted
+	}
+
+	blocked, err := s.blocks.between(ctx, recipient.ID, senderId)
+	if err != nil {
+		return "", err
+	}
+	if blocked {
+		return "", models.ErrMessagesRestricted
+	}
+
+	follows, err := s.followRepo.IsFollowingUser(ctx, recipient.ID, senderId)
+	if err != nil {
+		return "", err
+	}
+
+	if follows {
+		return models.ConversationStatusAccepted, nil
+	}
+
+	if recipient.DMPolicy == models.DMPolicyFollowing {
+		return "", models.ErrMessagesRestricted
+	}
+
+	return models.ConversationStatusRequest, nil
+}
+
+// GetConversations получает входящие диалоги пользователя
+func (s *MessageService) GetConversations(ctx context.Context, userId int, filter models.ConversationFilter) (models.ConversationsResponse, error) {
+	return s.getConversations(ctx, userId, models.ConversationStatusAccepted, filter)
+}
+
+// GetRequests получает запросы на переписку от незнакомых пользователей
+func (s *MessageService) GetRequests(ctx context.Context, userId int, filter models.ConversationFilter) (models.ConversationsResponse, error) {
+	return s.getConversations(ctx, userId, models.ConversationStatusRequest, filter)
+}
+
+func (s *MessageService) getConversations(ctx context.Context, userId int, status string, filter models.ConversationFilter) (models.ConversationsResponse, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	conversations, total, err := s.messageRepo.GetConversations(ctx, userId, status, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return models.ConversationsResponse{}, err
+	}
+
+	items := make([]models.ConversationResponse, 0, len(conversations))
+	for _, conversation := range conversations {
+		items = append(items, newConversationResponse(conversation))
+	}
+
+	return models.ConversationsResponse{
+		Conversations: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
+
+// GetConversation получает диалог, в котором участвует пользователь
+func (s *MessageService) GetConversation(ctx context.Context, conversationId int, userId int) (models.ConversationResponse, error) {
+	conversation, err := s.messageRepo.GetConversation(ctx, conversationId, userId)
+	if err != nil {
+		return models.ConversationResponse{}, err
+	}
+
+	return newConversationResponse(conversation), nil
+}
+
+// GetMessages получает страницу сообщений диалога, новые сверху, с необязательным поиском по тексту
+func (s *MessageService) GetMessages(ctx context.Context, conversationId int, userId int, filter models.MessageFilter) (models.MessagePage, error) {
+	if filter.PerPage == 0 {
+		filter.PerPage = 50
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	me, peer, err := s.members(ctx, conversationId, userId)
+	if err != nil {
+		return models.MessagePage{}, err
+	}
+
+	messages, err := s.messageRepo.GetMessages(ctx, conversationId, filter)
+	if err != nil {
+		return models.MessagePage{}, err
+	}
+
+	page := models.MessagePage{Items: make([]models.MessageResponse, 0, len(messages))}
+	for _, message := range messages {
+		// Сообщение прочитано, если получатель дочитал диалог до него
+		readUpTo := peer.LastReadMessageID
+		if message.SenderID != userId {
+			readUpTo = me.LastReadMessageID
+		}
+		page.Items = append(page.Items, newMessageResponse(message, readUpTo))
+	}
+
+	// Неполная страница — последняя
+	if len(messages) > 0 && len(messages) == filter.PerPage {
+		page.NextCursor = models.MessageCursor{ID: messages[len(messages)-1].ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// GetEvent собирает событие о новом сообщении для участника диалога
+func (s *MessageService) GetEvent(ctx context.Context, userId int, ref models.MessageRef) (models.MessageEvent, error) {
+	me, peer, err := s.members(ctx, ref.ConversationID, userId)
+	if err != nil {
+		return models.MessageEvent{}, err
+	}
+
+	message, err := s.messageRepo.GetMessage(ctx, ref.MessageID)
+	if err != nil {
+		return models.MessageEvent{}, err
+	}
+	if message.ConversationID != ref.ConversationID {
+		return models.MessageEvent{}, fmt.Errorf("message not found")
+	}
+
+	readUpTo := peer.LastReadMessageID
+	if message.SenderID != userId {
+		readUpTo = me.LastReadMessageID
+	}
+
+	return models.MessageEvent{Message: newMessageResponse(message, readUpTo), Status: me.Status}, nil
+}
+
+// MarkRead отмечает диалог прочитанным и сообщает об этом собеседнику
+func (s *MessageService) MarkRead(ctx context.Context, conversationId int, userId int) error {
+	_, peer, err := s.members(ctx, conversationId, userId)
+	if err != nil {
+		return err
+	}
+
+	readAt := time.Now()
+	lastReadId, err := s.messageRepo.MarkRead(ctx, conversationId, userId, readAt)
+	if err != nil {
+		return err
+	}
+
+	event := models.MessagesReadEvent{
+		ConversationID:    conversationId,
+		UserID:            userId,
+		LastReadMessageID: lastReadId,
+		ReadAt:            readAt,
+	}
+	s.events.publish(ctx, userTopic(peer.UserID), models.StreamEventMessagesRead, event)
+	s.events.publish(ctx, userTopic(userId), models.StreamEventMessagesRead, event)
+
+	return nil
+}
+
+// Accept переносит запрос на переписку во входящие
+func (s *MessageService) Accept(ctx context.Context, conversationId int, userId int) error {
+	return s.setStatus(ctx, conversationId, userId, models.ConversationStatusAccepted)
+}
+
+// Decline отклоняет запрос: диалог скрывается, и собеседник больше не может писать в него
+func (s *MessageService) Decline(ctx context.Context, conversationId int, userId int) error {
+	return s.setStatus(ctx, conversationId, userId, models.ConversationStatusDeclined)
+}
+
+func (s *MessageService) setStatus(ctx context.Context, conversationId int, userId int, status string) error {
+	me, _, err := s.members(ctx, conversationId, userId)
+	if err != nil {
+		return err
+	}
+
+	// Принять или отклонить можно только запрос
+	if me.Status != models.ConversationStatusRequest {
+		return models.ErrNotMessageRequest
+	}
+
+	return s.messageRepo.SetStatus(ctx, conversationId, userId, status)
+}
+
+// GetSettings получает настройки личных сообщений
+func (s *MessageService) GetSettings(ctx context.Context, userId int) (models.MessageSettings, error) {
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return models.MessageSettings{}, fmt.Errorf("user not found: %w", err)
+	}
+
+	return models.MessageSettings{DMPolicy: user.DMPolicy}, nil
+}
+
+// UpdateSettings меняет настройки личных сообщений. Уже принятые диалоги продолжаются
+func (s *MessageService) UpdateSettings(ctx context.Context, userId int, settings models.MessageSettings) error {
+	return s.messageRepo.UpdateDMPolicy(ctx, userId, settings.DMPolicy)
+}
+
+// members возвращает состояние диалога для пользователя и его собеседника.
+// Посторонний пользователь не узнает о существовании диалога
+func (s *MessageService) members(ctx context.Context, conversationId int, userId int) (models.ConversationMember, models.ConversationMember, error) {
+	members, err := s.messageRepo.GetMembers(ctx, conversationId)
+	if err != nil {
+		return models.ConversationMember{}, models.ConversationMember{}, err
+	}
+
+	var me, peer models.ConversationMember
+	found := false
+	for _, member := range members {
+		if member.UserID == userId {
+			me = member
+			found = true
+		} else {
+			peer = member
+		}
+	}
+
+	if !found {
+		return models.ConversationMember{}, models.ConversationMember{}, fmt.Errorf("conversation not found")
+	}
+
+	return me, peer, nil
+}
+
+// newMessageResponse преобразует сообщение в ответ API. readUpTo — последнее
+// сообщение, прочитанное получателем
+func newMessageResponse(message models.Message, readUpTo int) models.MessageResponse {
+	return models.MessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		Read:           message.ID <= readUpTo,
+		CreatedAt:      message.CreatedAt,
+	}
+}
+
+// newConversationResponse преобразует диалог в ответ API
+func newConversationResponse(conversation models.ConversationDetails) models.ConversationResponse {
+	response := models.ConversationResponse{
+		ID: conversation.ID,
+		User: models.UserBrief{
+			ID:       conversation.PeerID,
+			Username: conversation.PeerUsername,
+			Nickname: conversation.PeerNickname,
+			Avatar:   conversation.PeerAvatar,
+		},
+		Status:      conversation.Status,
+		UnreadCount: conversation.UnreadCount,
+		PeerReadAt:  conversation.PeerLastReadAt,
+		CreatedAt:   conversation.CreatedAt,
+	}
+
+	if conversation.LastMessageID != nil {
+		message := models.Message{
+			ID:             *conversation.LastMessageID,
+			ConversationID: conversation.ID,
+			SenderID:       *conversation.LastMessageSenderID,
+			Body:           *conversation.LastMessageBody,
+			CreatedAt:      *conversation.LastMessageCreatedAt,
+		}
+
+		readUpTo := conversation.PeerLastReadMessageID
+		if message.SenderID == conversation.PeerID {
+			readUpTo = conversation.LastReadMessageID
+		}
+
+		last := newMessageResponse(message, readUpTo)
+		response.LastMessage = &last
+	}
+
+	return response
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/pkg/pubsub"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Через шину уходят только ID сообщения и диалога, поэтому длинное сообщение
+// не упирается в размер NOTIFY, а подписчик загружает его целиком
+func TestMessageEventCarriesOnlyIDs(t *testing.T) {
+	const conversationID = 7
+
+	messages := &fakeMessageRepo{members: []models.ConversationMember{
+		{ConversationID: conversationID, UserID: authorID, Status: models.ConversationStatusAccepted},
+		{ConversationID: conversationID, UserID: strangerID, Status: models.ConversationStatusAccepted},
+	}}
+	hub := pubsub.NewMemoryHub()
+	service := NewMessageService(messages, &fakeUserRepo{}, nil, &fakeBlockRepo{}, hub)
+
+	sub := hub.Subscribe(userTopic(strangerID))
+	defer sub.Close()
+
+	body := strings.Repeat("я", 5000)
+	sent, err := service.SendToConversation(context.Background(), authorID, conversationID, models.MessageCreate{Body: body})
+	if err != nil {
+		t.Fatalf("SendToConversation() error = %v", err)
+	}
+
+	msg := <-sub.C
+	var ref models.MessageRef
+	if err := json.Unmarshal(msg.Data, &ref); err != nil {
+		t.Fatalf("decode %s event: %v", msg.Event, err)
+	}
+	if ref != (models.MessageRef{MessageID: sent.ID, ConversationID: conversationID}) || strings.Contains(string(msg.Data), "я") {
+		t.Fatalf("event data = %s, want only message and conversation IDs", msg.Data)
+	}
+
+	event, err := service.GetEvent(context.Background(), strangerID, ref)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+	if event.Message.Body != body || event.Message.Read || event.Status != models.ConversationStatusAccepted {
+		t.Errorf("GetEvent() = read %v, status %q, body of %d runes", event.Message.Read, event.Status, len([]rune(event.Message.Body)))
+	}
+
+	// Чужое сообщение собрать нельзя
+	if _, err := service.GetEvent(context.Background(), coauthorID, ref); err == nil {
+		t.Error("GetEvent() for a non-member returned no error")
+	}
+}
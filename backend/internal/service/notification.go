@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationService struct {
+	notificationRepo repository.Notification
+	preferenceRepo   repository.NotificationPreference
+	events           eventPublisher
+	tokens           unsubscribeTokens
+}
+
+func NewNotificationService(
+	notificationRepo repository.Notification,
+	preferenceRepo repository.NotificationPreference,
+	hub pubsub.Hub,
+	cfg config.DigestConfig,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		events:           eventPublisher{hub: hub},
+		tokens:           unsubscribeTokens{secret: []byte(cfg.UnsubscribeSecret)},
+	}
+}
+
+// GetByUserID получает страницу уведомлений пользователя вместе со счетчиком непрочитанных
+func (s *NotificationService) GetByUserID(ctx context.Context, userId int, filter models.NotificationFilter) (models.NotificationsResponse, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	notifications, total, err := s.notificationRepo.GetByUserID(ctx, userId, filter)
+	if err != nil {
+		return models.NotificationsResponse{}, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userId)
+	if err != nil {
+		return models.NotificationsResponse{}, err
+	}
+
+	items := make([]models.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		items = append(items, newNotificationResponse(notification))
+	}
+
+	return models.NotificationsResponse{
+		Notifications: items,
+		UnreadCount:   unread,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
+
+// CountUnread возвращает количество непрочитанных уведомлений
+func (s *NotificationService) CountUnread(ctx context.Context, userId int) (int, error) {
+	return s.notificationRepo.CountUnread(ctx, userId)
+}
+
+// MarkRead отмечает уведомление прочитанным
+func (s *NotificationService) MarkRead(ctx context.Context, userId int, id int) error {
+	exists, err := s.notificationRepo.Exists(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("notification not found")
+	}
+
+	if _, err := s.notificationRepo.MarkRead(ctx, userId, []int{id}, time.Now()); err != nil {
+		return err
+	}
+
+	s.publishUnreadCount(ctx, userId)
+
+	return nil
+}
+
+// MarkAllRead отмечает прочитанными уведомления из списка, а при пустом списке — все.
+// Возвращает количество отмеченных уведомлений
+func (s *NotificationService) MarkAllRead(ctx context.Context, userId int, ids []int) (int64, error) {
+	marked, err := s.notificationRepo.MarkRead(ctx, userId, ids, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if marked > 0 {
+		s.publishUnreadCount(ctx, userId)
+	}
+
+	return marked, nil
+}
+
+// GetPreferences получает настройки уведомлений пользователя по всем типам
+func (s *NotificationService) GetPreferences(ctx context.Context, userId int) (models.NotificationPreferences, error) {
+	stored, err := s.preferenceRepo.Get(ctx, userId)
+	if err != nil {
+		return models.NotificationPreferences{}, err
+	}
+
+	channels := make(map[string]string, len(stored.Types))
+	for _, preference := range stored.Types {
+		channels[preference.Type] = preference.Channel
+	}
+
+	preferences := models.NotificationPreferences{
+		Types:  make([]models.NotificationPreference, 0, len(models.NotificationTypes)),
+		Digest: stored.Digest,
+	}
+	for _, notificationType := range models.NotificationTypes {
+		channel, ok := channels[notificationType]
+		if !ok {
+			channel = models.DefaultNotificationChannel
+		}
+		preferences.Types = append(preferences.Types, models.NotificationPreference{Type: notificationType, Channel: channel})
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences меняет настройки уведомлений и возвращает их итоговое состояние
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userId int, input models.NotificationPreferencesUpdate) (models.NotificationPreferences, error) {
+	for notificationType := range input.Types {
+		if !slices.Contains(models.NotificationTypes, notificationType) {
+			return models.NotificationPreferences{}, models.ErrUnknownNotificationType
+		}
+	}
+
+	if err := s.preferenceRepo.Update(ctx, userId, input.Types, input.Digest); err != nil {
+		return models.NotificationPreferences{}, err
+	}
+
+	return s.GetPreferences(ctx, userId)
+}
+
+// CheckUnsubscribeToken проверяет токен из письма, ничего не меняя
+func (s *NotificationService) CheckUnsubscribeToken(token string) error {
+	_, err := s.tokens.parse(token)
+	return err
+}
+
+// Unsubscribe отключает email-дайджест по токену из письма
+func (s *NotificationService) Unsubscribe(ctx context.Context, token string) error {
+	userId, err := s.tokens.parse(token)
+	if err != nil {
+		return err
+	}
+
+	return s.preferenceRepo.SetDigestFrequency(ctx, userId, models.DigestOff)
+}
+
+// publishUnreadCount обновляет счетчик непрочитанных в других открытых вкладках пользователя
+func (s *NotificationService) publishUnreadCount(ctx context.Context, userId int) {
+	unread, err := s.notificationRepo.CountUnread(ctx, userId)
+	if err != nil {
+		logrus.Warnf("Failed to count unread notifications for user %d: %s", userId, err.Error())
+		return
+	}
+
+	s.events.publish(ctx, userTopic(userId), models.StreamEventUnreadCount, models.UnreadCountResponse{UnreadCount: unread})
+}
+
+// notifier создает уведомления из других сервисов. Уведомление — побочный эффект
+// действия, поэтому ошибка только логируется и не отменяет само действие
+type notifier struct {
+	notificationRepo repository.Notification
+	events           eventPublisher
+}
+
+// newNotifier создает notifier, который сразу отправляет уведомления в поток получателя
+func newNotifier(notificationRepo repository.Notification, hub pubsub.Hub) notifier {
+	return notifier{notificationRepo: notificationRepo, events: eventPublisher{hub: hub}}
+}
+
+// send сохраняет уведомление. Пользователь не получает уведомлений о собственных действиях
+func (n notifier) send(ctx context.Context, notification models.Notification) {
+	if notification.ActorID != nil && *notification.ActorID == notification.UserID {
+		return
+	}
+
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	id, err := n.notificationRepo.Create(ctx, notification)
+	if err != nil {
+		logrus.Warnf("Failed to create %s notification for user %d: %s", notification.Type, notification.UserID, err.Error())
+		return
+	}
+
+	// Пользователь отключил уведомления этого типа
+	if id == 0 {
+		return
+	}
+
+	n.push(ctx, notification.UserID, id)
+}
+
+// push отправляет сохраненное уведомление в поток получателя
+func (n notifier) push(ctx context.Context, userId int, id int) {
+	if n.events.hub == nil {
+		return
+	}
+
+	details, err := n.notificationRepo.GetByID(ctx, userId, id)
+	if err != nil {
+		logrus.Warnf("Failed to load notification %d: %s", id, err.Error())
+		return
+	}
+
+	unread, err := n.notificationRepo.CountUnread(ctx, userId)
+	if err != nil {
+		logrus.Warnf("Failed to count unread notifications for user %d: %s", userId, err.Error())
+		return
+	}
+
+	n.events.publish(ctx, userTopic(userId), models.StreamEventNotification, models.NotificationEvent{
+		Notification: newNotificationResponse(details),
+		UnreadCount:  unread,
+	})
+}
+
+// notificationGroupKey формирует ключ группировки однотипных событий
+func notificationGroupKey(notificationType string, target string, id int) *string {
+	key := fmt.Sprintf("%s:%s:%d", notificationType, target, id)
+	return &key
+}
+
+// newNotificationResponse преобразует уведомление в ответ API
+func newNotificationResponse(notification models.NotificationDetails) models.NotificationResponse {
+	response := models.NotificationResponse{
+		ID:           notification.ID,
+		Type:         notification.Type,
+		Actors:       notification.Actors,
+		ActorsCount:  notification.ActorsCount,
+		CommentID:    notification.CommentID,
+		RejectReason: notification.RejectReason,
+		Read:         notification.ReadAt != nil,
+		CreatedAt:    notification.CreatedAt,
+	}
+
+	if response.Actors == nil {
+		response.Actors = []models.UserBrief{}
+	}
+
+	if notification.PostID != nil && notification.PostTitle != nil {
+		response.Post = &models.NotificationPost{ID: *notification.PostID, Title: *notification.PostTitle}
+	}
+
+	return response
+}
@@ -2,20 +2,33 @@ package service
 
 import (
 	"context"
+	"designhub/internal/config"
 	"designhub/internal/models"
 	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
 	"fmt"
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"sort"
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 type PostService struct {
-	postRepo     repository.Post
-	likeRepo     repository.Like
-	userRepo     repository.User
-	categoryRepo repository.Category
-	fileStorage  FileStorage
+	postRepo      repository.Post
+	likeRepo      repository.Like
+	userRepo      repository.User
+	categoryRepo  repository.Category
+	coauthorRepo  repository.Coauthor
+	toolRepo      repository.Tool
+	access        postAccess
+	mentions      mentionSyncer
+	notifications notifier
+	events        eventPublisher
+	fileStorage   FileStorage
+	moderationCfg config.ModerationConfig
 }
 
 func NewPostService(
@@ -23,14 +36,28 @@ func NewPostService(
 	likeRepo repository.Like,
 	userRepo repository.User,
 	categoryRepo repository.Category,
+	coauthorRepo repository.Coauthor,
+	toolRepo repository.Tool,
+	blockRepo repository.Block,
+	mentionRepo repository.Mention,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
 	fileStorage FileStorage,
+	moderationCfg config.ModerationConfig,
 ) *PostService {
 	return &PostService{
-		postRepo:     postRepo,
-		likeRepo:     likeRepo,
-		userRepo:     userRepo,
-		categoryRepo: categoryRepo,
-		fileStorage:  fileStorage,
+		postRepo:      postRepo,
+		likeRepo:      likeRepo,
+		userRepo:      userRepo,
+		categoryRepo:  categoryRepo,
+		coauthorRepo:  coauthorRepo,
+		toolRepo:      toolRepo,
+		access:        newPostAccess(userRepo, coauthorRepo, blockRepo),
+		mentions:      mentionSyncer{mentionRepo: mentionRepo, userRepo: userRepo, blockRepo: blockRepo, notifications: newNotifier(notificationRepo, hub)},
+		notifications: newNotifier(notificationRepo, hub),
+		events:        eventPublisher{hub: hub},
+		fileStorage:   fileStorage,
+		moderationCfg: moderationCfg,
 	}
 }
 
@@ -48,6 +75,27 @@ func (s *PostService) Create(ctx context.Context, userId int, postInput models.P
 		return 0, fmt.Errorf("category not found: %w", err)
 	}
 
+	// Проверяем ссылки и инструменты до сохранения файла, чтобы не оставлять его без поста
+	if err := validatePostLink(postInput.SourceURL); err != nil {
+		return 0, err
+	}
+	if err := validatePostLink(postInput.CreditURL); err != nil {
+		return 0, err
+	}
+
+	toolIDs, err := s.checkTools(ctx, postInput.ToolIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	license := postInput.License
+	if license == "" {
+		license = models.DefaultLicense
+	}
+	if _, ok := models.LookupLicense(license); !ok {
+		return 0, models.ErrUnknownLicense
+	}
+
 	// Открываем файл
 	file, err := mediaFile.Open()
 	if err != nil {
@@ -72,6 +120,18 @@ func (s *PostService) Create(ctx context.Context, userId int, postInput models.P
 		mediaType = "video"
 	}
 
+	// Черновик остается у автора, остальные посты сразу попадают на модерацию
+	status := "pending"
+	if postInput.Draft {
+		status = "draft"
+	}
+
+	// Время публикации в прошлом не имеет смысла, такой пост публикуется сразу после одобрения
+	publishAt := postInput.PublishAt
+	if publishAt != nil && !publishAt.After(time.Now()) {
+		publishAt = nil
+	}
+
 	// Создаем пост
 	post := models.Post{
 		UserID:      userId,
@@ -80,167 +140,60 @@ func (s *PostService) Create(ctx context.Context, userId int, postInput models.P
 		Description: postInput.Description,
 		MediaPath:   mediaPath,
 		MediaType:   mediaType,
-		Status:      "pending", // Все посты сначала попадают на модерацию
+		Status:      status,
+		PublishAt:   publishAt,
+		License:     license,
+		SourceURL:   &postInput.SourceURL,
+		CreditURL:   &postInput.CreditURL,
+		ToolIDs:     toolIDs,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	return s.postRepo.Create(ctx, post)
-}
-
-// GetById получает детальную информацию о посте по его ID
-func (s *PostService) GetByID(ctx context.Context, id int, currentUserId int) (models.PostResponse, error) {
-	// Получаем информацию о посте
-	post, err := s.postRepo.GetByID(ctx, id)
+	id, err := s.postRepo.Create(ctx, post)
 	if err != nil {
-		return models.PostResponse{}, fmt.Errorf("post not found: %w", err)
+		return 0, err
 	}
 
-	// Если пост не опубликован, проверяем что текущий пользователь - автор или модератор
-	if post.Status != "approved" {
-		// Если не авторизован (currentUserId = 0), то доступ запрещен
-		if currentUserId == 0 {
-			return models.PostResponse{}, fmt.Errorf("доступ запрещен")
-		}
-
-		// Если не автор и не модератор, то доступ запрещен
-		if post.UserID != currentUserId {
-			user, err := s.userRepo.GetByID(ctx, currentUserId)
-			if err != nil || (user.Role != "moderator" && user.Role != "admin") {
-				return models.PostResponse{}, fmt.Errorf("доступ запрещен")
-			}
-		}
+	// Пост уже сохранен, поэтому ошибка разбора упоминаний не отменяет публикацию
+	if err := s.mentions.sync(ctx, id, nil, userId, post.Description); err != nil {
+		logrus.Warnf("Failed to save mentions for post %d: %s", id, err.Error())
 	}
 
-	// Получаем информацию об авторе
-	author, err := s.userRepo.GetByID(ctx, post.UserID)
-	if err != nil {
-		return models.PostResponse{}, fmt.Errorf("author not found: %w", err)
+	if status == "pending" {
+		s.events.moderation(ctx, models.ModerationEvent{PostID: &id, Status: status})
 	}
 
-	// Получаем категорию
-	category, err := s.categoryRepo.GetByID(ctx, post.CategoryID)
-	if err != nil {
-		return models.PostResponse{}, fmt.Errorf("category not found: %w", err)
-	}
+	return id, nil
+}
 
-	// Получаем количество лайков
-	likesCount, err := s.likeRepo.CountByPostID(ctx, id)
+// GetById получает детальную информацию о посте по его ID
+func (s *PostService) GetByID(ctx context.Context, id int, currentUserId int) (models.PostResponse, error) {
+	// Получаем пост вместе с автором, категорией и отметкой лайка
+	post, err := s.postRepo.GetDetailsByID(ctx, id, currentUserId)
 	if err != nil {
-		return models.PostResponse{}, fmt.Errorf("failed to get likes count: %w", err)
-	}
-
-	// Проверяем лайкнул ли текущий пользователь этот пост
-	isLiked := false
-	if currentUserId != 0 {
-		isLiked, _ = s.likeRepo.IsLiked(ctx, id, currentUserId)
-	}
-
-	// Конвертируем в ответ
-	response := models.PostResponse{
-		ID:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		MediaURL:    post.MediaPath,
-		MediaType:   post.MediaType,
-		Status:      post.Status,
-		CreatedAt:   post.CreatedAt,
-		UpdatedAt:   post.UpdatedAt,
-		Author: models.UserBrief{
-			ID:       author.ID,
-			Username: author.Username,
-			Nickname: author.Nickname,
-			Avatar:   "", // По умолчанию пустая строка
-		},
-		Category: models.Category{
-			ID:   category.ID,
-			Name: category.Name,
-			Slug: category.Slug,
-		},
-		IsLiked:    isLiked,
-		LikesCount: likesCount,
+		return models.PostResponse{}, fmt.Errorf("post not found: %w", err)
 	}
 
-	// Если аватар автора не nil, используем его
-	if author.Avatar != nil {
-		response.Author.Avatar = *author.Avatar
+	if err := s.access.check(ctx, post.Post, currentUserId); err != nil {
+		return models.PostResponse{}, err
 	}
 
-	return response, nil
+	return newPostResponse(post), nil
 }
 
 // GetAll получает список всех постов
 func (s *PostService) GetAll(ctx context.Context, currentUserId int, filter models.PostFilter) (models.FeedResponse, error) {
+	filter.ViewerID = currentUserId
+	filter.HideMuted = true
+
 	// Получаем посты
 	posts, total, err := s.postRepo.GetAll(ctx, filter)
 	if err != nil {
 		return models.FeedResponse{}, fmt.Errorf("failed to get posts: %w", err)
 	}
 
-	// Преобразуем посты в ответ
-	items := make([]models.PostResponse, 0, len(posts))
-	for _, post := range posts {
-		// Проверяем лайкнул ли текущий пользователь этот пост
-		isLiked := false
-		if currentUserId != 0 {
-			isLiked, _ = s.likeRepo.IsLiked(ctx, post.ID, currentUserId)
-		}
-
-		// Получаем информацию об авторе
-		author, err := s.userRepo.GetByID(ctx, post.UserID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("author not found: %w", err)
-		}
-
-		// Получаем категорию
-		category, err := s.categoryRepo.GetByID(ctx, post.CategoryID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("category not found: %w", err)
-		}
-
-		// Добавляем пост в ответ
-		items = append(items, models.PostResponse{
-			ID:          post.ID,
-			Title:       post.Title,
-			Description: post.Description,
-			MediaURL:    post.MediaPath,
-			MediaType:   post.MediaType,
-			Status:      post.Status,
-			CreatedAt:   post.CreatedAt,
-			UpdatedAt:   post.UpdatedAt,
-			Author: models.UserBrief{
-				ID:       author.ID,
-				Username: author.Username,
-				Nickname: author.Nickname,
-				Avatar:   "", // По умолчанию пустая строка
-			},
-			Category: models.Category{
-				ID:   category.ID,
-				Name: category.Name,
-				Slug: category.Slug,
-			},
-			IsLiked:    isLiked,
-			LikesCount: post.LikesCount,
-		})
-
-		// Если аватар автора не nil, используем его
-		if author.Avatar != nil {
-			items[len(items)-1].Author.Avatar = *author.Avatar
-		}
-	}
-
-	// Формируем ответ
-	response := models.FeedResponse{
-		Items: items,
-		Pagination: models.Pagination{
-			Total:   total,
-			Page:    filter.Page,
-			PerPage: filter.PerPage,
-			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
-		},
-	}
-
-	return response, nil
+	return newFeedResponse(posts, filter, total), nil
 }
 
 // GetByUserID получает список постов пользователя
@@ -251,87 +204,26 @@ func (s *PostService) GetByUserID(ctx context.Context, currentUserId int, filter
 		return models.FeedResponse{}, fmt.Errorf("user not found: %w", err)
 	}
 
+	filter.ViewerID = currentUserId
+
 	// Получаем посты пользователя
 	posts, total, err := s.postRepo.GetByUserID(ctx, filter)
 	if err != nil {
 		return models.FeedResponse{}, fmt.Errorf("failed to get user posts: %w", err)
 	}
 
-	// Преобразуем посты в ответ
-	items := make([]models.PostResponse, 0, len(posts))
-	for _, post := range posts {
-		// Если пост не опубликован, проверяем права доступа
-		if post.Status != "approved" {
-			// Если не текущий пользователь не автор и не модератор, пропускаем пост
-			if filter.UserID != currentUserId {
-				currentUser, err := s.userRepo.GetByID(ctx, currentUserId)
-				if err != nil || (currentUser.Role != "moderator" && currentUser.Role != "admin") {
-					continue
-				}
-			}
-		}
-
-		// Проверяем лайкнул ли текущий пользователь этот пост
-		isLiked := false
-		if currentUserId != 0 {
-			isLiked, _ = s.likeRepo.IsLiked(ctx, post.ID, currentUserId)
-		}
-
-		// Получаем информацию об авторе
-		author, err := s.userRepo.GetByID(ctx, post.UserID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("author not found: %w", err)
-		}
-
-		// Получаем категорию
-		category, err := s.categoryRepo.GetByID(ctx, post.CategoryID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("category not found: %w", err)
-		}
+	// Неопубликованные посты видны только автору и модераторам
+	canSeeUnpublished := filter.UserID == currentUserId || (currentUserId != 0 && s.access.isModerator(ctx, currentUserId))
 
-		// Добавляем пост в ответ
-		items = append(items, models.PostResponse{
-			ID:          post.ID,
-			Title:       post.Title,
-			Description: post.Description,
-			MediaURL:    post.MediaPath,
-			MediaType:   post.MediaType,
-			Status:      post.Status,
-			CreatedAt:   post.CreatedAt,
-			UpdatedAt:   post.UpdatedAt,
-			Author: models.UserBrief{
-				ID:       author.ID,
-				Username: author.Username,
-				Nickname: author.Nickname,
-				Avatar:   "", // По умолчанию пустая строка
-			},
-			Category: models.Category{
-				ID:   category.ID,
-				Name: category.Name,
-				Slug: category.Slug,
-			},
-			IsLiked:    isLiked,
-			LikesCount: post.LikesCount,
-		})
-
-		// Если аватар автора не nil, используем его
-		if author.Avatar != nil {
-			items[len(items)-1].Author.Avatar = *author.Avatar
+	visible := posts[:0]
+	for _, post := range posts {
+		if post.Status != "approved" && !canSeeUnpublished {
+			continue
 		}
+		visible = append(visible, post)
 	}
 
-	// Формируем ответ
-	response := models.FeedResponse{
-		Items: items,
-		Pagination: models.Pagination{
-			Total:   total,
-			Page:    filter.Page,
-			PerPage: filter.PerPage,
-			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
-		},
-	}
-
-	return response, nil
+	return newFeedResponse(visible, filter, total), nil
 }
 
 // GetLikedByUserId получает список постов, лайкнутых пользователем
@@ -342,144 +234,76 @@ func (s *PostService) GetLikedByUserID(ctx context.Context, userId int, filter m
 		return models.FeedResponse{}, fmt.Errorf("user not found: %w", err)
 	}
 
+	// Все посты в этом списке лайкнуты пользователем, он же и смотрит ленту
+	filter.ViewerID = userId
+
 	// Получаем лайкнутые посты
 	posts, total, err := s.postRepo.GetLikedByUserID(ctx, userId, filter)
 	if err != nil {
 		return models.FeedResponse{}, fmt.Errorf("failed to get liked posts: %w", err)
 	}
 
-	// Преобразуем посты в ответ
-	items := make([]models.PostResponse, 0, len(posts))
-	for _, post := range posts {
-		// Получаем информацию об авторе
-		author, err := s.userRepo.GetByID(ctx, post.UserID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("author not found: %w", err)
-		}
-
-		// Получаем категорию
-		category, err := s.categoryRepo.GetByID(ctx, post.CategoryID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("category not found: %w", err)
-		}
-
-		// Добавляем пост в ответ
-		items = append(items, models.PostResponse{
-			ID:          post.ID,
-			Title:       post.Title,
-			Description: post.Description,
-			MediaURL:    post.MediaPath,
-			MediaType:   post.MediaType,
-			Status:      post.Status,
-			CreatedAt:   post.CreatedAt,
-			UpdatedAt:   post.UpdatedAt,
-			Author: models.UserBrief{
-				ID:       author.ID,
-				Username: author.Username,
-				Nickname: author.Nickname,
-				Avatar:   "", // По умолчанию пустая строка
-			},
-			Category: models.Category{
-				ID:   category.ID,
-				Name: category.Name,
-				Slug: category.Slug,
-			},
-			IsLiked:    true, // Все посты в этом списке лайкнуты пользователем
-			LikesCount: post.LikesCount,
-		})
+	return newFeedResponse(posts, filter, total), nil
+}
 
-		// Если аватар автора не nil, используем его
-		if author.Avatar != nil {
-			items[len(items)-1].Author.Avatar = *author.Avatar
-		}
-	}
+// GetFollowingFeed получает персональную ленту из постов авторов и категорий, на которые подписан пользователь
+func (s *PostService) GetFollowingFeed(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error) {
+	// Лента подписок всегда хронологическая
+	filter.SortBy = "date"
+	filter.SortOrder = "desc"
+	filter.ViewerID = userId
+	filter.HideMuted = true
 
-	// Формируем ответ
-	response := models.FeedResponse{
-		Items: items,
-		Pagination: models.Pagination{
-			Total:   total,
-			Page:    filter.Page,
-			PerPage: filter.PerPage,
-			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
-		},
+	posts, total, err := s.postRepo.GetFollowingFeed(ctx, userId, filter)
+	if err != nil {
+		return models.FeedResponse{}, fmt.Errorf("failed to get following feed: %w", err)
 	}
 
-	return response, nil
+	return newFeedResponse(posts, filter, total), nil
 }
 
 // GetPendingModeration получает список постов, ожидающих модерации
 func (s *PostService) GetPendingModeration(ctx context.Context, filter models.PostFilter) (models.FeedResponse, error) {
+	// Очередь модерации всегда просматривается от новых постов к старым
+	filter.SortBy = "date"
+	filter.SortOrder = "desc"
+
 	// Получаем посты со статусом "pending" напрямую из репозитория
 	posts, total, err := s.postRepo.GetPendingModeration(ctx, filter)
 	if err != nil {
 		return models.FeedResponse{}, fmt.Errorf("failed to get pending posts: %w", err)
 	}
 
-	fmt.Printf("Найдено %d постов со статусом 'pending'\n", len(posts))
-
-	// Преобразуем посты в ответ
-	items := make([]models.PostResponse, 0, len(posts))
-	for _, post := range posts {
-		fmt.Printf("Пост ID: %d, Заголовок: %s, Статус: %s\n", post.ID, post.Title, post.Status)
-
-		// Получаем информацию об авторе
-		author, err := s.userRepo.GetByID(ctx, post.UserID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("author not found: %w", err)
-		}
-
-		// Получаем категорию
-		category, err := s.categoryRepo.GetByID(ctx, post.CategoryID)
-		if err != nil {
-			return models.FeedResponse{}, fmt.Errorf("category not found: %w", err)
-		}
+	return newFeedResponse(posts, filter, total), nil
+}
 
-		// Добавляем пост в ответ
-		items = append(items, models.PostResponse{
-			ID:           post.ID,
-			Title:        post.Title,
-			Description:  post.Description,
-			MediaURL:     post.MediaPath,
-			MediaType:    post.MediaType,
-			Status:       post.Status,
-			RejectReason: post.RejectReason,
-			CreatedAt:    post.CreatedAt,
-			UpdatedAt:    post.UpdatedAt,
-			Author: models.UserBrief{
-				ID:       author.ID,
-				Username: author.Username,
-				Nickname: author.Nickname,
-				Avatar:   "", // По умолчанию пустая строка
-			},
-			Category: models.Category{
-				ID:   category.ID,
-				Name: category.Name,
-				Slug: category.Slug,
-			},
-			LikesCount: post.LikesCount,
-		})
+// GetDrafts получает черновики текущего пользователя
+func (s *PostService) GetDrafts(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error) {
+	// Черновики не участвуют в трендах, поэтому показываются от новых к старым
+	filter.SortBy = "date"
+	filter.SortOrder = "desc"
+	filter.ViewerID = userId
 
-		// Если аватар автора не nil, используем его
-		if author.Avatar != nil {
-			items[len(items)-1].Author.Avatar = *author.Avatar
-		}
+	posts, total, err := s.postRepo.GetDraftsByUserID(ctx, userId, filter)
+	if err != nil {
+		return models.FeedResponse{}, fmt.Errorf("failed to get drafts: %w", err)
 	}
 
-	fmt.Printf("Формирование ответа завершено. Отправляем %d постов.\n", len(items))
+	return newFeedResponse(posts, filter, total), nil
+}
 
-	// Формируем ответ
-	response := models.FeedResponse{
-		Items: items,
-		Pagination: models.Pagination{
-			Total:   total,
-			Page:    filter.Page,
-			PerPage: filter.PerPage,
-			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
-		},
+// GetScheduled получает еще не опубликованные посты текущего пользователя с назначенным временем публикации
+func (s *PostService) GetScheduled(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error) {
+	filter.SortBy = "date"
+	filter.SortOrder = "desc"
+	filter.ViewerID = userId
+
+	posts, total, err := s.postRepo.GetScheduledByUserID(ctx, userId, filter)
+	if err != nil {
+		return models.FeedResponse{}, fmt.Errorf("failed to get scheduled posts: %w", err)
 	}
 
-	return response, nil
+	return newFeedResponse(posts, filter, total), nil
 }
 
 // Update обновляет пост
@@ -490,14 +314,34 @@ func (s *PostService) Update(ctx context.Context, id int, userId int, postUpdate
 		return fmt.Errorf("post not found: %w", err)
 	}
 
-	// Проверяем, что пользователь - автор поста или модератор
-	if post.UserID != userId {
-		user, err := s.userRepo.GetByID(ctx, userId)
-		if err != nil || (user.Role != "moderator" && user.Role != "admin") {
+	// Проверяем, что пользователь - автор поста или модератор.
+	// Черновик может редактировать только автор
+	if post.UserID != userId && post.Status == "draft" {
+		return fmt.Errorf("post not found")
+	}
+
+	// Соавтор может редактировать пост, если его роль включает право редактирования.
+	// Подпись роли — свободный текст, поэтому право хранится отдельным флагом can_edit
+	editorIsModerator := s.access.isModerator(ctx, userId)
+	if post.UserID != userId && !editorIsModerator {
+		canEdit, err := s.coauthorRepo.CanEdit(ctx, id, userId)
+		if err != nil || !canEdit {
 			return fmt.Errorf("доступ запрещен")
 		}
 	}
 
+	// Время публикации можно менять только у еще не опубликованного поста
+	publishAt := postUpdate.PublishAt
+	if publishAt != nil {
+		if post.Status != "draft" && post.Status != "pending" && post.Status != "scheduled" {
+			return models.ErrInvalidPostStatus
+		}
+		if !publishAt.After(time.Now()) {
+			now := time.Now()
+			publishAt = &now
+		}
+	}
+
 	// Если указана категория, проверяем ее существование
 	if postUpdate.CategoryID != 0 {
 		_, err = s.categoryRepo.GetByID(ctx, postUpdate.CategoryID)
@@ -506,22 +350,153 @@ func (s *PostService) Update(ctx context.Context, id int, userId int, postUpdate
 		}
 	}
 
+	if postUpdate.License != "" {
+		if _, ok := models.LookupLicense(postUpdate.License); !ok {
+			return models.ErrUnknownLicense
+		}
+	}
+
+	if postUpdate.SourceURL != nil {
+		if err := validatePostLink(*postUpdate.SourceURL); err != nil {
+			return err
+		}
+	}
+	if postUpdate.CreditURL != nil {
+		if err := validatePostLink(*postUpdate.CreditURL); err != nil {
+			return err
+		}
+	}
+
+	// Для сравнения с правкой нужен текущий список инструментов
+	var toolIDs []int
+	if postUpdate.ToolIDs != nil {
+		toolIDs, err = s.checkTools(ctx, postUpdate.ToolIDs)
+		if err != nil {
+			return err
+		}
+		if toolIDs == nil {
+			toolIDs = []int{}
+		}
+
+		post.ToolIDs, err = s.postRepo.GetToolIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Обновляем пост
 	updatedPost := models.Post{
 		ID:          id,
 		Title:       postUpdate.Title,
 		CategoryID:  postUpdate.CategoryID,
 		Description: postUpdate.Description,
+		PublishAt:   publishAt,
+		License:     postUpdate.License,
+		SourceURL:   postUpdate.SourceURL,
+		CreditURL:   postUpdate.CreditURL,
+		ToolIDs:     toolIDs,
 		UpdatedAt:   time.Now(),
 	}
 
-	return s.postRepo.Update(ctx, updatedPost)
+	// Сохраняем правку в истории, только если что-то действительно изменилось
+	changes := postChanges(post, updatedPost)
+	if len(changes) == 0 {
+		return s.postRepo.Update(ctx, updatedPost, nil)
+	}
+
+	// Правка одобренного поста автором в значимых полях требует повторной модерации.
+	// Правки модераторов проверки не требуют
+	remoderate := false
+	if (post.Status == "approved" || post.Status == "scheduled") && !editorIsModerator {
+		for _, field := range s.moderationCfg.RemoderationFields {
+			if changes.Has(field) {
+				remoderate = true
+				break
+			}
+		}
+	}
+
+	if remoderate {
+		updatedPost.Status = "pending"
+	}
+
+	revision := &models.PostRevision{
+		PostID:         id,
+		EditorID:       userId,
+		Changes:        changes,
+		PreviousStatus: post.Status,
+		Remoderated:    remoderate,
+		CreatedAt:      updatedPost.UpdatedAt,
+	}
+
+	if err := s.postRepo.Update(ctx, updatedPost, revision); err != nil {
+		return err
+	}
+
+	// Упоминания пересчитываются по новому описанию от имени автора поста
+	if changes.Has("description") {
+		if err := s.mentions.sync(ctx, id, nil, post.UserID, updatedPost.Description); err != nil {
+			logrus.Warnf("Failed to save mentions for post %d: %s", id, err.Error())
+		}
+	}
+
+	// Пост вернулся в очередь модерации
+	if remoderate && post.Status != "pending" {
+		s.events.moderation(ctx, models.ModerationEvent{PostID: &id, Status: updatedPost.Status})
+	}
+
+	return nil
+}
+
+// GetRevisions получает историю правок поста (для автора или модератора)
+func (s *PostService) GetRevisions(ctx context.Context, id int, userId int) ([]models.PostRevisionResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	// Черновик виден только автору
+	if post.UserID != userId {
+		if post.Status == "draft" {
+			return nil, fmt.Errorf("post not found")
+		}
+		if !s.access.isModerator(ctx, userId) {
+			return nil, fmt.Errorf("доступ запрещен")
+		}
+	}
+
+	revisions, err := s.postRepo.GetRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.PostRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		item := models.PostRevisionResponse{
+			ID: revision.ID,
+			Editor: models.UserBrief{
+				ID:       revision.EditorID,
+				Username: revision.EditorUsername,
+				Nickname: revision.EditorNickname,
+			},
+			Changes:        revision.Changes,
+			PreviousStatus: revision.PreviousStatus,
+			Remoderated:    revision.Remoderated,
+			CreatedAt:      revision.CreatedAt,
+		}
+		if revision.EditorAvatar != nil {
+			item.Editor.Avatar = *revision.EditorAvatar
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
 }
 
 // UpdateStatus обновляет статус поста (для модерации)
 func (s *PostService) UpdateStatus(ctx context.Context, id int, moderatorId int, status models.PostModeration) error {
 	// Получаем информацию о посте
-	_, err := s.postRepo.GetByID(ctx, id)
+	post, err := s.postRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("post not found: %w", err)
 	}
@@ -532,11 +507,86 @@ func (s *PostService) UpdateStatus(ctx context.Context, id int, moderatorId int,
 		return fmt.Errorf("доступ запрещен")
 	}
 
-	// Обновляем статус поста
-	return s.postRepo.UpdateStatus(ctx, id, status.Status, moderatorId, status.RejectReason)
+	// Черновик еще не отправлен автором на модерацию
+	if post.Status == "draft" {
+		return models.ErrInvalidPostStatus
+	}
+
+	// Одобренный пост с будущим временем публикации ждет его в статусе scheduled
+	newStatus := status.Status
+	if newStatus == "approved" && post.PublishAt != nil && post.PublishAt.After(time.Now()) {
+		newStatus = "scheduled"
+	}
+
+	// Обновляем статус поста вместе с записью в журнале модерации
+	action := models.ModerationAction{
+		ModeratorID:   &moderatorId,
+		Action:        models.AuditActionPostStatus,
+		TargetType:    models.AuditTargetPost,
+		TargetID:      id,
+		PreviousState: models.AuditState{"status": post.Status},
+		NewState:      models.AuditState{"status": newStatus},
+		CreatedAt:     time.Now(),
+	}
+	if status.Status == "rejected" && status.RejectReason != "" {
+		action.Reason = &status.RejectReason
+	}
+	if err := s.postRepo.UpdateStatus(ctx, id, newStatus, moderatorId, status.RejectReason, &action); err != nil {
+		return err
+	}
+
+	// Сообщаем автору о решении модератора
+	notification := models.Notification{UserID: post.UserID, PostID: &post.ID, Type: models.NotificationTypePostApproved}
+	if status.Status == "rejected" {
+		notification.Type = models.NotificationTypePostRejected
+		if status.RejectReason != "" {
+			notification.RejectReason = &status.RejectReason
+		}
+	}
+	s.notifications.send(ctx, notification)
+
+	// Пост уходит из очереди у всех модераторов
+	s.events.moderation(ctx, models.ModerationEvent{PostID: &post.ID, Status: newStatus})
+
+	// Упоминания в только что опубликованном посте становятся видны
+	if newStatus == "approved" {
+		if err := s.mentions.notify(ctx); err != nil {
+			logrus.Warnf("Failed to send mention notifications for post %d: %s", id, err.Error())
+		}
+	}
+
+	return nil
 }
 
-// Delete удаляет пост
+// Submit отправляет черновик автора на модерацию
+func (s *PostService) Submit(ctx context.Context, id int, userId int) error {
+	post, err := s.postRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	// Черновик виден только автору
+	if post.UserID != userId {
+		if post.Status == "draft" {
+			return fmt.Errorf("post not found")
+		}
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	submitted, err := s.postRepo.Submit(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !submitted {
+		return models.ErrInvalidPostStatus
+	}
+
+	s.events.moderation(ctx, models.ModerationEvent{PostID: &id, Status: "pending"})
+
+	return nil
+}
+
+// Delete перемещает пост в корзину
 func (s *PostService) Delete(ctx context.Context, id int, userId int) error {
 	// Получаем информацию о посте
 	post, err := s.postRepo.GetByID(ctx, id)
@@ -552,15 +602,291 @@ func (s *PostService) Delete(ctx context.Context, id int, userId int) error {
 		}
 	}
 
-	// Удаляем медиафайл поста
-	if post.MediaPath != "" {
-		filename := filepath.Base(post.MediaPath)
-		if err := s.fileStorage.DeleteFile(filename); err != nil {
-			// Логируем ошибку, но продолжаем выполнение
-			fmt.Printf("failed to delete media file: %v\n", err)
+	// Удаление чужого поста — действие модератора, оно записывается в журнал
+	var action *models.ModerationAction
+	if post.UserID != userId {
+		action = &models.ModerationAction{
+			ModeratorID:   &userId,
+			Action:        models.AuditActionPostDelete,
+			TargetType:    models.AuditTargetPost,
+			TargetID:      id,
+			PreviousState: models.AuditState{"status": post.Status, "title": post.Title, "user_id": post.UserID},
+			NewState:      models.AuditState{"deleted": true},
+			CreatedAt:     time.Now(),
 		}
 	}
 
-	// Удаляем пост
-	return s.postRepo.Delete(ctx, id)
+	// Перемещаем пост в корзину. Медиафайл остается до окончательной очистки,
+	// чтобы пост можно было восстановить
+	return s.postRepo.Delete(ctx, id, userId, action)
+}
+
+// postAccess проверяет, виден ли пост пользователю. Правила общие для просмотра поста,
+// лайков, комментариев и подписки на события поста
+type postAccess struct {
+	userRepo     repository.User
+	coauthorRepo repository.Coauthor
+	blocks       blockChecker
+}
+
+func newPostAccess(userRepo repository.User, coauthorRepo repository.Coauthor, blockRepo repository.Block) postAccess {
+	return postAccess{
+		userRepo:     userRepo,
+		coauthorRepo: coauthorRepo,
+		blocks:       blockChecker{blockRepo: blockRepo},
+	}
+}
+
+// check возвращает ошибку, если пользователь (0 — гость) не может видеть пост.
+// Черновик виден только автору. Пост автора или соавтора, связанного с пользователем
+// блокировкой, скрыт от всех, кроме модераторов, чтобы блокировка не мешала модерации.
+// Неопубликованный пост (на модерации, отложенный, отклоненный или скрытый по жалобам)
+// виден только автору, соавторам и модераторам
+func (a postAccess) check(ctx context.Context, post models.Post, userId int) error {
+	if post.Status == "draft" && post.UserID != userId {
+		return fmt.Errorf("post not found")
+	}
+
+	blocked, err := a.blocks.post(ctx, userId, post.ID)
+	if err != nil {
+		return err
+	}
+	if blocked && !a.isModerator(ctx, userId) {
+		return fmt.Errorf("post not found")
+	}
+
+	if post.Status != "approved" && post.UserID != userId {
+		if userId == 0 || (!a.isCoauthor(ctx, post.ID, userId) && !a.isModerator(ctx, userId)) {
+			return fmt.Errorf("доступ запрещен")
+		}
+	}
+
+	return nil
+}
+
+// isCoauthor проверяет, что пользователь принял приглашение в соавторы поста
+func (a postAccess) isCoauthor(ctx context.Context, postId int, userId int) bool {
+	coauthor, err := a.coauthorRepo.Get(ctx, postId, userId)
+	return err == nil && coauthor.Status == "accepted"
+}
+
+// isModerator проверяет, что пользователь имеет роль модератора или администратора
+func (a postAccess) isModerator(ctx context.Context, userId int) bool {
+	if userId == 0 {
+		return false
+	}
+
+	user, err := a.userRepo.GetByID(ctx, userId)
+	return err == nil && (user.Role == "moderator" || user.Role == "admin")
+}
+
+// postChanges сравнивает пост с правкой и возвращает измененные поля.
+// Пустые значения в правке означают, что поле не меняется
+func postChanges(post models.Post, update models.Post) models.FieldChanges {
+	var changes models.FieldChanges
+
+	if update.Title != "" && update.Title != post.Title {
+		changes = append(changes, models.FieldChange{Field: "title", Old: post.Title, New: update.Title})
+	}
+
+	if update.Description != "" && update.Description != post.Description {
+		changes = append(changes, models.FieldChange{Field: "description", Old: post.Description, New: update.Description})
+	}
+
+	if update.CategoryID != 0 && update.CategoryID != post.CategoryID {
+		changes = append(changes, models.FieldChange{Field: "category_id", Old: post.CategoryID, New: update.CategoryID})
+	}
+
+	if update.PublishAt != nil && (post.PublishAt == nil || !update.PublishAt.Equal(*post.PublishAt)) {
+		changes = append(changes, models.FieldChange{Field: "publish_at", Old: post.PublishAt, New: update.PublishAt})
+	}
+
+	if update.License != "" && update.License != post.License {
+		changes = append(changes, models.FieldChange{Field: "license", Old: post.License, New: update.License})
+	}
+
+	if update.SourceURL != nil && *update.SourceURL != stringValue(post.SourceURL) {
+		changes = append(changes, models.FieldChange{Field: "source_url", Old: stringValue(post.SourceURL), New: *update.SourceURL})
+	}
+
+	if update.CreditURL != nil && *update.CreditURL != stringValue(post.CreditURL) {
+		changes = append(changes, models.FieldChange{Field: "credit_url", Old: stringValue(post.CreditURL), New: *update.CreditURL})
+	}
+
+	if update.ToolIDs != nil && !sameIDs(post.ToolIDs, update.ToolIDs) {
+		changes = append(changes, models.FieldChange{Field: "tools", Old: post.ToolIDs, New: update.ToolIDs})
+	}
+
+	return changes
+}
+
+// checkTools убирает повторы из списка инструментов и проверяет, что все они есть в каталоге
+func (s *PostService) checkTools(ctx context.Context, toolIDs []int) ([]int, error) {
+	if len(toolIDs) == 0 {
+		return nil, nil
+	}
+
+	unique := make([]int, 0, len(toolIDs))
+	seen := make(map[int]bool, len(toolIDs))
+	for _, id := range toolIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Ints(unique)
+
+	count, err := s.toolRepo.CountExisting(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	if count != len(unique) {
+		return nil, models.ErrUnknownTool
+	}
+
+	return unique, nil
+}
+
+// validatePostLink проверяет, что ссылка пустая или является абсолютным http(s) адресом
+func validatePostLink(link string) error {
+	if link == "" {
+		return nil
+	}
+
+	parsed, err := url.ParseRequestURI(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return models.ErrInvalidLink
+	}
+
+	return nil
+}
+
+// sameIDs сравнивает два отсортированных списка ID
+func sameIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// stringValue возвращает значение строки или пустую строку для nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// newPostResponse преобразует пост со связанными данными в ответ API
+func newPostResponse(post models.PostDetails) models.PostResponse {
+	response := models.PostResponse{
+		ID:           post.ID,
+		Title:        post.Title,
+		Description:  post.Description,
+		MediaURL:     post.MediaPath,
+		MediaType:    post.MediaType,
+		Status:       post.Status,
+		RejectReason: post.RejectReason,
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+		Author: models.UserBrief{
+			ID:       post.UserID,
+			Username: post.AuthorUsername,
+			Nickname: post.AuthorNickname,
+			Avatar:   "", // По умолчанию пустая строка
+		},
+		Category: models.Category{
+			ID:   post.CategoryID,
+			Name: post.CategoryName,
+			Slug: post.CategorySlug,
+		},
+		IsLiked:     post.IsLiked,
+		LikesCount:  post.LikesCount,
+		ViewsCount:  post.ViewsCount,
+		PublishAt:   post.PublishAt,
+		PublishedAt: post.PublishedAt,
+		Coauthors:   post.Coauthors,
+		Tools:       post.Tools,
+		SourceURL:   stringValue(post.SourceURL),
+		CreditURL:   stringValue(post.CreditURL),
+	}
+
+	if response.Coauthors == nil {
+		response.Coauthors = []models.CoauthorBrief{}
+	}
+
+	if response.Tools == nil {
+		response.Tools = []models.ToolBrief{}
+	}
+
+	response.Mentions = post.Mentions
+	if response.Mentions == nil {
+		response.Mentions = []models.MentionBrief{}
+	}
+
+	response.Reactions = post.Reactions
+	if response.Reactions == nil {
+		response.Reactions = models.ReactionCounts{}
+	}
+
+	response.MyReactions = post.MyReactions
+	if response.MyReactions == nil {
+		response.MyReactions = []string{}
+	}
+
+	// Для неизвестного кода (например, лицензии, убранной из списка) показываем хотя бы код
+	license, ok := models.LookupLicense(post.License)
+	if !ok {
+		license = models.License{Code: post.License, Title: post.License}
+	}
+	response.License = license
+
+	// Если аватар автора не nil, используем его
+	if post.AuthorAvatar != nil {
+		response.Author.Avatar = *post.AuthorAvatar
+	}
+
+	return response
+}
+
+// newFeedResponse формирует ответ ленты с информацией о пагинации
+func newFeedResponse(posts []models.PostDetails, filter models.PostFilter, total int) models.FeedResponse {
+	items := make([]models.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		items = append(items, newPostResponse(post))
+	}
+
+	return models.FeedResponse{
+		Items: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+		NextCursor: nextFeedCursor(posts, filter, total),
+	}
+}
+
+// nextFeedCursor возвращает курсор следующей страницы ленты или пустую строку,
+// если текущая страница последняя
+func nextFeedCursor(posts []models.PostDetails, filter models.PostFilter, total int) string {
+	if len(posts) == 0 || len(posts) < filter.PerPage {
+		return ""
+	}
+
+	// В режиме LIMIT/OFFSET известно общее количество, поэтому последнюю страницу определяем точно
+	if filter.Cursor == "" && filter.Page*filter.PerPage >= total {
+		return ""
+	}
+
+	return models.NewFeedCursor(posts[len(posts)-1], filter).Encode()
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"testing"
+)
+
+// Участники тестов доступа к постам
+const (
+	authorID    = 1
+	strangerID  = 2
+	coauthorID  = 3
+	moderatorID = 4
+	guestID     = 0
+)
+
+// accessFixture посты автора во всех статусах; у каждого поста есть принявший приглашение соавтор
+type accessFixture struct {
+	posts         *fakePostRepo
+	users         *fakeUserRepo
+	coauthors     *fakeCoauthorRepo
+	blocks        *fakeBlockRepo
+	notifications *fakeNotificationRepo
+	comments      *fakeCommentRepo
+}
+
+var commentsConfig = config.CommentsConfig{MaxDepth: 3}
+
+var postIDByStatus = map[string]int{
+	"draft":     10,
+	"pending":   11,
+	"scheduled": 12,
+	"approved":  13,
+	"rejected":  14,
+	"hidden":    15,
+}
+
+func newAccessFixture() *accessFixture {
+	f := &accessFixture{
+		posts: &fakePostRepo{posts: map[int]models.Post{}},
+		users: &fakeUserRepo{users: map[int]models.User{
+			authorID:    {ID: authorID, Role: "user"},
+			strangerID:  {ID: strangerID, Role: "user"},
+			coauthorID:  {ID: coauthorID, Role: "user"},
+			moderatorID: {ID: moderatorID, Role: "moderator"},
+		}},
+		coauthors:     &fakeCoauthorRepo{},
+		notifications: &fakeNotificationRepo{},
+		comments:      &fakeCommentRepo{comments: map[int]models.Comment{}},
+	}
+	f.blocks = &fakeBlockRepo{posts: f.posts}
+
+	for status, id := range postIDByStatus {
+		f.posts.posts[id] = models.Post{ID: id, UserID: authorID, Status: status}
+		f.coauthors.coauthors = append(f.coauthors.coauthors, models.PostCoauthor{PostID: id, UserID: coauthorID, Status: "accepted"})
+	}
+
+	return f
+}
+
+func (f *accessFixture) postService() *PostService {
+	return NewPostService(f.posts, nil, f.users, nil, f.coauthors, nil, f.blocks, &fakeMentionRepo{}, f.notifications, nil, nil, config.ModerationConfig{})
+}
+
+func (f *accessFixture) likeService() *LikeService {
+	return NewLikeService(&fakeLikeRepo{}, f.posts, f.users, f.coauthors, f.blocks, f.notifications, nil)
+}
+
+func (f *accessFixture) commentService() *CommentService {
+	return NewCommentService(f.comments, f.users, f.posts, f.coauthors, &fakeMentionRepo{}, nil, f.blocks, f.notifications, nil, commentsConfig)
+}
+
+func TestPostServiceGetByIDVisibility(t *testing.T) {
+	// Кто видит пост в каждом статусе; остальным пост недоступен
+	visibleTo := map[string][]int{
+		"draft":     {authorID},
+		"pending":   {authorID, coauthorID, moderatorID},
+		"scheduled": {authorID, coauthorID, moderatorID},
+		"rejected":  {authorID, coauthorID, moderatorID},
+		"hidden":    {authorID, coauthorID, moderatorID},
+		"approved":  {authorID, coauthorID, moderatorID, strangerID, guestID},
+	}
+
+	service := newAccessFixture().postService()
+
+	for status, viewers := range visibleTo {
+		allowed := make(map[int]bool)
+		for _, viewer := range viewers {
+			allowed[viewer] = true
+		}
+
+		for _, viewer := range []int{authorID, coauthorID, moderatorID, strangerID, guestID} {
+			_, err := service.GetByID(context.Background(), postIDByStatus[status], viewer)
+			if allowed[viewer] && err != nil {
+				t.Errorf("%s post: user %d should see it, got %v", status, viewer, err)
+			}
+			if !allowed[viewer] && err == nil {
+				t.Errorf("%s post: user %d should not see it", status, viewer)
+			}
+		}
+	}
+}
+
+func TestPostServiceGetByIDHidesBlockedAuthor(t *testing.T) {
+	f := newAccessFixture()
+	f.blocks.blocks = [][2]int{{authorID, strangerID}}
+	service := f.postService()
+
+	if _, err := service.GetByID(context.Background(), postIDByStatus["approved"], strangerID); err == nil {
+		t.Error("blocked user should not see the author's post")
+	}
+	if _, err := service.GetByID(context.Background(), postIDByStatus["approved"], moderatorID); err != nil {
+		t.Errorf("moderator should see the post despite blocks, got %v", err)
+	}
+}
+
+func TestLikeServiceCreateRequiresVisiblePost(t *testing.T) {
+	for _, status := range []string{"draft", "pending", "scheduled"} {
+		t.Run(status, func(t *testing.T) {
+			f := newAccessFixture()
+			like := models.LikeCreate{PostID: postIDByStatus[status]}
+
+			if _, err := f.likeService().Create(context.Background(), strangerID, like); err == nil {
+				t.Fatal("stranger liked an unpublished post")
+			}
+			if len(f.notifications.sent) != 0 {
+				t.Errorf("author got %d notifications for a rejected like", len(f.notifications.sent))
+			}
+		})
+	}
+
+	f := newAccessFixture()
+	if _, err := f.likeService().Create(context.Background(), strangerID, models.LikeCreate{PostID: postIDByStatus["approved"]}); err != nil {
+		t.Fatalf("like of a published post failed: %v", err)
+	}
+	if len(f.notifications.sent) != 1 || f.notifications.sent[0].UserID != authorID {
+		t.Errorf("author should get one like notification, got %+v", f.notifications.sent)
+	}
+
+	// Соавтор видит пост на модерации и может его лайкнуть
+	if _, err := f.likeService().Create(context.Background(), coauthorID, models.LikeCreate{PostID: postIDByStatus["pending"]}); err != nil {
+		t.Errorf("coauthor like of a pending post failed: %v", err)
+	}
+}
+
+func TestCommentServiceCreateOnDraft(t *testing.T) {
+	f := newAccessFixture()
+	comment := models.CommentCreate{PostID: postIDByStatus["draft"], Content: "nice"}
+
+	if _, err := f.commentService().Create(context.Background(), strangerID, comment); err == nil {
+		t.Fatal("stranger commented on a draft")
+	}
+	if len(f.comments.comments) != 0 || len(f.notifications.sent) != 0 {
+		t.Errorf("rejected comment left %d comments and %d notifications", len(f.comments.comments), len(f.notifications.sent))
+	}
+}
+
+func TestCommentServiceGetByPostIDForGuest(t *testing.T) {
+	service := newAccessFixture().commentService()
+	filter := models.CommentFilter{PerPage: 20}
+
+	if _, err := service.GetByPostID(context.Background(), postIDByStatus["pending"], filter); err == nil {
+		t.Error("guest listed comments of a pending post")
+	}
+	if _, err := service.GetByPostID(context.Background(), postIDByStatus["approved"], filter); err != nil {
+		t.Errorf("guest could not list comments of a published post: %v", err)
+	}
+}
+
+// Пост, скрытый по жалобам или отклоненный модератором, для остальных пользователей
+// закрыт так же, как неопубликованный: ни лайков, ни комментариев, ни их списка
+func TestHiddenAndRejectedPostsAreClosed(t *testing.T) {
+	for _, status := range []string{"hidden", "rejected"} {
+		f := newAccessFixture()
+		postID := postIDByStatus[status]
+
+		if _, err := f.likeService().Create(context.Background(), strangerID, models.LikeCreate{PostID: postID}); err == nil {
+			t.Errorf("%s post: stranger could like it", status)
+		}
+		if _, err := f.commentService().Create(context.Background(), strangerID, models.CommentCreate{PostID: postID, Content: "?"}); err == nil {
+			t.Errorf("%s post: stranger could comment on it", status)
+		}
+		for _, viewer := range []int{strangerID, guestID} {
+			if _, err := f.commentService().GetByPostID(context.Background(), postID, models.CommentFilter{PerPage: 20, ViewerID: viewer}); err == nil {
+				t.Errorf("%s post: user %d could list its comments", status, viewer)
+			}
+		}
+		if len(f.notifications.sent) != 0 {
+			t.Errorf("%s post: author got %d notifications", status, len(f.notifications.sent))
+		}
+
+		// Автор видит обсуждение своего поста, чтобы разобраться с решением модератора
+		if _, err := f.commentService().GetByPostID(context.Background(), postID, models.CommentFilter{PerPage: 20, ViewerID: authorID}); err != nil {
+			t.Errorf("%s post: author could not list comments: %v", status, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PublishingService struct {
+	postRepo repository.Post
+	mentions mentionSyncer
+	events   eventPublisher
+	cfg      config.PublishingConfig
+}
+
+func NewPublishingService(
+	postRepo repository.Post,
+	mentionRepo repository.Mention,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
+	cfg config.PublishingConfig,
+) *PublishingService {
+	return &PublishingService{
+		postRepo: postRepo,
+		mentions: mentionSyncer{mentionRepo: mentionRepo, notifications: newNotifier(notificationRepo, hub)},
+		events:   eventPublisher{hub: hub},
+		cfg:      cfg,
+	}
+}
+
+// ProcessDue отправляет на модерацию черновики и публикует одобренные посты,
+// время публикации которых наступило
+func (s *PublishingService) ProcessDue(ctx context.Context) error {
+	now := time.Now()
+
+	submitted, err := s.postRepo.SubmitDueDrafts(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to submit due drafts: %w", err)
+	}
+
+	published, err := s.postRepo.PublishDue(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+
+	// Модераторы видят новые посты в очереди сразу
+	if submitted > 0 {
+		s.events.moderation(ctx, models.ModerationEvent{Status: "pending", Count: int(submitted)})
+	}
+
+	// Упоминания в опубликованных постах становятся видны
+	if published > 0 {
+		if err := s.mentions.notify(ctx); err != nil {
+			return fmt.Errorf("failed to send mention notifications: %w", err)
+		}
+	}
+
+	if submitted > 0 || published > 0 {
+		logrus.Infof("Scheduled posts processed: %d submitted, %d published", submitted, published)
+	}
+
+	return nil
+}
+
+// Run периодически обрабатывает отложенные посты до отмены контекста
+func (s *PublishingService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessDue(ctx); err != nil {
+			logrus.Errorf("Scheduled publishing failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"time"
+)
+
+type ReactionService struct {
+	reactionRepo repository.Reaction
+	postRepo     repository.Post
+	commentRepo  repository.Comment
+	blocks       blockChecker
+	cfg          config.ReactionsConfig
+}
+
+func NewReactionService(
+	reactionRepo repository.Reaction,
+	postRepo repository.Post,
+	commentRepo repository.Comment,
+	blockRepo repository.Block,
+	cfg config.ReactionsConfig,
+) *ReactionService {
+	return &ReactionService{
+		reactionRepo: reactionRepo,
+		postRepo:     postRepo,
+		commentRepo:  commentRepo,
+		blocks:       blockChecker{blockRepo: blockRepo},
+		cfg:          cfg,
+	}
+}
+
+// GetTypes возвращает настроенный набор реакций
+func (s *ReactionService) GetTypes() []string {
+	types := make([]string, len(s.cfg.Types))
+	copy(types, s.cfg.Types)
+
+	return types
+}
+
+// Add ставит реакцию на пост или комментарий
+func (s *ReactionService) Add(ctx context.Context, userId int, target string, targetId int, reactionType string) error {
+	if err := s.checkTarget(ctx, userId, target, targetId, reactionType); err != nil {
+		return err
+	}
+
+	added, err := s.reactionRepo.Add(ctx, target, targetId, userId, reactionType, time.Now())
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("реакция уже существует")
+	}
+
+	return nil
+}
+
+// Remove снимает реакцию с поста или комментария
+func (s *ReactionService) Remove(ctx context.Context, userId int, target string, targetId int, reactionType string) error {
+	// Снять свою реакцию можно и после блокировки, поэтому блокировки не проверяются
+	if err := s.checkTarget(ctx, 0, target, targetId, reactionType); err != nil {
+		return err
+	}
+
+	return s.reactionRepo.Remove(ctx, target, targetId, userId, reactionType)
+}
+
+// GetUsers получает страницу пользователей, поставивших реакцию указанного типа
+func (s *ReactionService) GetUsers(ctx context.Context, target string, targetId int, reactionType string, filter models.ReactionUsersFilter) (models.ReactionUsersResponse, error) {
+	if err := s.checkTarget(ctx, filter.ViewerID, target, targetId, reactionType); err != nil {
+		return models.ReactionUsersResponse{}, err
+	}
+
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	users, total, err := s.reactionRepo.GetUsers(ctx, target, targetId, reactionType, filter.ViewerID, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return models.ReactionUsersResponse{}, err
+	}
+
+	items := make([]models.ReactionUserResponse, 0, len(users))
+	for _, user := range users {
+		brief := models.UserBrief{
+			ID:       user.UserID,
+			Username: user.Username,
+			Nickname: user.Nickname,
+		}
+		if user.Avatar != nil {
+			brief.Avatar = *user.Avatar
+		}
+
+		items = append(items, models.ReactionUserResponse{User: brief, CreatedAt: user.CreatedAt})
+	}
+
+	return models.ReactionUsersResponse{
+		Type:  reactionType,
+		Users: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
+
+// checkTarget проверяет тип реакции и то, что пост или комментарий опубликован.
+// Реакции на неопубликованные посты и комментарии к ним, на скрытые по жалобам
+// комментарии, а также на посты и комментарии авторов, связанных с пользователем
+// блокировкой, недоступны
+func (s *ReactionService) checkTarget(ctx context.Context, userId int, target string, targetId int, reactionType string) error {
+	if !s.isKnownType(reactionType) {
+		return models.ErrUnknownReaction
+	}
+
+	postId := targetId
+	commentAuthorId := 0
+	if target == models.ReactionTargetComment {
+		comment, err := s.commentRepo.GetByID(ctx, targetId)
+		if err != nil {
+			return fmt.Errorf("comment not found: %w", err)
+		}
+		// Скрытый по жалобам комментарий не виден в обсуждении
+		if comment.HiddenAt != nil {
+			return fmt.Errorf("comment not found")
+		}
+		postId = comment.PostID
+		commentAuthorId = comment.UserID
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+	if post.Status != "approved" {
+		return fmt.Errorf("post not found")
+	}
+
+	// Пост автора или соавтора, связанного с пользователем блокировкой, для него не существует
+	blocked, err := s.blocks.post(ctx, userId, post.ID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("post not found")
+	}
+
+	blocked, err = s.blocks.between(ctx, userId, commentAuthorId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return models.ErrUserBlocked
+	}
+
+	return nil
+}
+
+// isKnownType проверяет, входит ли реакция в настроенный набор
+func (s *ReactionService) isKnownType(reactionType string) bool {
+	for _, t := range s.cfg.Types {
+		if t == reactionType {
+			return true
+		}
+	}
+
+	return false
+}
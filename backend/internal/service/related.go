@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/cache"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Веса признаков при ранжировании похожих постов
+const (
+	relatedCoLikeWeight     = 3.0
+	relatedCategoryWeight   = 2.0
+	relatedAuthorWeight     = 1.5
+	relatedPopularityWeight = 0.5
+
+	// Сколько кандидатов отбирается из базы для ранжирования
+	relatedCandidatesLimit = 200
+)
+
+type RelatedService struct {
+	postRepo  repository.Post
+	blockRepo repository.Block
+	cfg       config.RelatedConfig
+	cache     *cache.TTLCache[int, []int]
+}
+
+func NewRelatedService(postRepo repository.Post, blockRepo repository.Block, cfg config.RelatedConfig) *RelatedService {
+	return &RelatedService{
+		postRepo:  postRepo,
+		blockRepo: blockRepo,
+		cfg:       cfg,
+		cache:     cache.NewTTLCache[int, []int](cfg.CacheTTL),
+	}
+}
+
+// Run периодически удаляет из кэша устаревшие списки до отмены контекста.
+// Без этого записи для постов, которые больше не открывают, остаются в памяти навсегда
+func (s *RelatedService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.CacheTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.cache.Cleanup()
+		}
+	}
+}
+
+// GetByPostID получает посты, похожие на указанный.
+// Ранжированный список ID кэшируется на пост, а данные постов и отметка
+// лайка текущего пользователя загружаются при каждом запросе.
+func (s *RelatedService) GetByPostID(ctx context.Context, postId int, currentUserId int) ([]models.PostResponse, error) {
+	// Исходный пост проверяется до кэша: удаленный, скрытый или снятый с публикации
+	// пост не должен отдавать похожие, пока не истек срок жизни записи
+	post, err := s.postRepo.GetByID(ctx, postId)
+	if err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	// Рекомендации строятся только для опубликованных постов
+	if post.Status != "approved" {
+		return []models.PostResponse{}, nil
+	}
+
+	ids, ok := s.cache.Get(postId)
+	if !ok {
+		candidates, err := s.postRepo.GetRelatedCandidates(ctx, post, relatedCandidatesLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get related posts: %w", err)
+		}
+
+		ids = rankRelated(post, candidates, s.cfg.Limit)
+		s.cache.Set(postId, ids)
+	}
+
+	if len(ids) == 0 {
+		return []models.PostResponse{}, nil
+	}
+
+	posts, err := s.postRepo.GetDetailsByIDs(ctx, ids, currentUserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related posts: %w", err)
+	}
+
+	byID := make(map[int]models.PostDetails, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	// Кэш общий для всех пользователей, поэтому посты авторов и соавторов, связанных
+	// с текущим пользователем блокировкой, и посты заглушенных им авторов отсеиваются
+	// уже после него, как в лентах с HideMuted
+	blocked := make(map[int]bool)
+	muted := make(map[int]bool)
+	if currentUserId != 0 {
+		blockedIds, err := s.blockRepo.GetBlockedIDs(ctx, currentUserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get related posts: %w", err)
+		}
+
+		mutedIds, err := s.blockRepo.GetMutedIDs(ctx, currentUserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get related posts: %w", err)
+		}
+
+		for _, id := range blockedIds {
+			blocked[id] = true
+		}
+		for _, id := range mutedIds {
+			muted[id] = true
+		}
+	}
+
+	// Восстанавливаем порядок ранжирования и пропускаем посты, снятые с публикации после кэширования
+	response := make([]models.PostResponse, 0, len(ids))
+	for _, id := range ids {
+		post, ok := byID[id]
+		if !ok || post.Status != "approved" || blocked[post.UserID] || muted[post.UserID] || hasBlockedCoauthor(post, blocked) {
+			continue
+		}
+		response = append(response, newPostResponse(post))
+	}
+
+	return response, nil
+}
+
+// hasBlockedCoauthor сообщает, есть ли среди соавторов поста пользователь из блокировки
+func hasBlockedCoauthor(post models.PostDetails, blocked map[int]bool) bool {
+	for _, coauthor := range post.Coauthors {
+		if blocked[coauthor.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+// rankRelated вычисляет оценку похожести кандидатов и возвращает ID лучших
+func rankRelated(post models.Post, candidates []models.RelatedCandidate, limit int) []int {
+	scores := make(map[int]float64, len(candidates))
+	for _, candidate := range candidates {
+		score := relatedCoLikeWeight*float64(candidate.CoLikes) +
+			relatedPopularityWeight*math.Log1p(float64(candidate.LikesCount))
+
+		if candidate.CategoryID == post.CategoryID {
+			score += relatedCategoryWeight
+		}
+		if candidate.UserID == post.UserID {
+			score += relatedAuthorWeight
+		}
+
+		scores[candidate.PostID] = score
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		left, right := scores[candidates[i].PostID], scores[candidates[j].PostID]
+		if left != right {
+			return left > right
+		}
+		return candidates[i].PublishedAt.After(candidates[j].PublishedAt)
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	ids := make([]int, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.PostID)
+	}
+
+	return ids
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankRelated(t *testing.T) {
+	source := models.Post{ID: 100, UserID: 10, CategoryID: 1}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	candidates := func() []models.RelatedCandidate {
+		return []models.RelatedCandidate{
+			{PostID: 4, UserID: 20, CategoryID: 2, PublishedAt: now.Add(-2 * time.Hour)},
+			{PostID: 3, UserID: 20, CategoryID: 2, LikesCount: 100, PublishedAt: now},
+			{PostID: 5, UserID: 20, CategoryID: 2, PublishedAt: now.Add(-time.Hour)},
+			{PostID: 2, UserID: 10, CategoryID: 1, PublishedAt: now},
+			{PostID: 1, UserID: 20, CategoryID: 2, CoLikes: 2, PublishedAt: now},
+		}
+	}
+
+	// Совместные лайки (6) важнее той же категории и автора (3.5), те важнее
+	// популярности (2.3), а посты без признаков идут от новых к старым
+	if got := rankRelated(source, candidates(), 10); !reflect.DeepEqual(got, []int{1, 2, 3, 5, 4}) {
+		t.Errorf("rankRelated() = %v, want [1 2 3 5 4]", got)
+	}
+
+	if got := rankRelated(source, candidates(), 3); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("rankRelated() with limit 3 = %v, want [1 2 3]", got)
+	}
+
+	if got := rankRelated(source, nil, 3); len(got) != 0 {
+		t.Errorf("rankRelated() without candidates = %v, want empty", got)
+	}
+}
+
+// relatedPostRepo отдает заранее заданных кандидатов и считает обращения к ним
+type relatedPostRepo struct {
+	*fakePostRepo
+	candidates     []models.RelatedCandidate
+	candidateCalls int
+}
+
+func (r *relatedPostRepo) GetRelatedCandidates(ctx context.Context, post models.Post, limit int) ([]models.RelatedCandidate, error) {
+	r.candidateCalls++
+	return append([]models.RelatedCandidate(nil), r.candidates...), nil
+}
+
+func (r *relatedPostRepo) GetDetailsByIDs(ctx context.Context, ids []int, viewerID int) ([]models.PostDetails, error) {
+	var details []models.PostDetails
+	for _, id := range ids {
+		if post, ok := r.posts[id]; ok {
+			details = append(details, models.PostDetails{Post: post})
+		}
+	}
+	return details, nil
+}
+
+func newRelatedFixture() (*relatedPostRepo, *fakeBlockRepo, *RelatedService) {
+	posts := &relatedPostRepo{
+		fakePostRepo: &fakePostRepo{posts: map[int]models.Post{
+			1: {ID: 1, UserID: authorID, CategoryID: 1, Status: "approved"},
+			2: {ID: 2, UserID: strangerID, CategoryID: 1, Status: "approved"},
+			3: {ID: 3, UserID: coauthorID, CategoryID: 1, Status: "approved"},
+		}},
+		candidates: []models.RelatedCandidate{
+			{PostID: 2, UserID: strangerID, CategoryID: 1, CoLikes: 2},
+			{PostID: 3, UserID: coauthorID, CategoryID: 1},
+		},
+	}
+	blocks := &fakeBlockRepo{}
+	service := NewRelatedService(posts, blocks, config.RelatedConfig{CacheTTL: time.Hour, Limit: 8})
+
+	return posts, blocks, service
+}
+
+func relatedIDs(posts []models.PostResponse) []int {
+	ids := make([]int, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+// Список похожих кэшируется, но исходный пост проверяется при каждом запросе
+func TestRelatedRechecksSourcePostOnCacheHit(t *testing.T) {
+	posts, _, service := newRelatedFixture()
+
+	related, err := service.GetByPostID(context.Background(), 1, guestID)
+	if err != nil || !reflect.DeepEqual(relatedIDs(related), []int{2, 3}) {
+		t.Fatalf("GetByPostID() = %v, %v; want [2 3]", relatedIDs(related), err)
+	}
+
+	if _, err := service.GetByPostID(context.Background(), 1, guestID); err != nil || posts.candidateCalls != 1 {
+		t.Fatalf("second request should come from cache: calls = %d, err = %v", posts.candidateCalls, err)
+	}
+
+	hidden := posts.posts[1]
+	hidden.Status = "hidden"
+	posts.posts[1] = hidden
+	if related, err := service.GetByPostID(context.Background(), 1, guestID); err != nil || len(related) != 0 {
+		t.Errorf("hidden source post: GetByPostID() = %v, %v; want empty", relatedIDs(related), err)
+	}
+
+	delete(posts.posts, 1)
+	if _, err := service.GetByPostID(context.Background(), 1, guestID); err == nil {
+		t.Error("deleted source post: GetByPostID() returned no error")
+	}
+}
+
+// Посты авторов из блокировки и заглушенных авторов отсеиваются после общего кэша
+func TestRelatedFiltersBlockedAndMutedAuthors(t *testing.T) {
+	_, blocks, service := newRelatedFixture()
+	blocks.blocks = [][2]int{{strangerID, moderatorID}}
+
+	related, err := service.GetByPostID(context.Background(), 1, moderatorID)
+	if err != nil || !reflect.DeepEqual(relatedIDs(related), []int{3}) {
+		t.Errorf("blocked author: GetByPostID() = %v, %v; want [3]", relatedIDs(related), err)
+	}
+
+	blocks.blocks = nil
+	blocks.muted = [][2]int{{moderatorID, coauthorID}}
+	related, err = service.GetByPostID(context.Background(), 1, moderatorID)
+	if err != nil || !reflect.DeepEqual(relatedIDs(related), []int{2}) {
+		t.Errorf("muted author: GetByPostID() = %v, %v; want [2]", relatedIDs(related), err)
+	}
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ReportService struct {
+	reportRepo    repository.Report
+	postRepo      repository.Post
+	commentRepo   repository.Comment
+	blocks        blockChecker
+	notifications notifier
+	events        eventPublisher
+	cfg           config.ReportsConfig
+}
+
+func NewReportService(
+	reportRepo repository.Report,
+	postRepo repository.Post,
+	commentRepo repository.Comment,
+	blockRepo repository.Block,
+	notificationRepo repository.Notification,
+	hub pubsub.Hub,
+	cfg config.ReportsConfig,
+) *ReportService {
+	return &ReportService{
+		reportRepo:    reportRepo,
+		postRepo:      postRepo,
+		commentRepo:   commentRepo,
+		blocks:        blockChecker{blockRepo: blockRepo},
+		notifications: newNotifier(notificationRepo, hub),
+		events:        eventPublisher{hub: hub},
+		cfg:           cfg,
+	}
+}
+
+// reportAuditActions записи журнала модерации для решений по жалобам
+var reportAuditActions = map[string]string{
+	models.ReportActionDismiss: models.AuditActionReportDismiss,
+	models.ReportActionHide:    models.AuditActionReportHide,
+	models.ReportActionDelete:  models.AuditActionReportDelete,
+	models.ReportActionWarn:    models.AuditActionReportWarn,
+}
+
+// reportTarget объект жалобы вместе с автором и постом, к которому он относится
+type reportTarget struct {
+	Type     string
+	ID       int
+	AuthorID int
+	PostID   int
+	Hidden   bool
+}
+
+// ReportPost сохраняет жалобу на опубликованный пост
+func (s *ReportService) ReportPost(ctx context.Context, userId int, postId int, input models.ReportCreate) error {
+	target, err := s.getTarget(ctx, models.ReportTargetPost, postId)
+	if err != nil {
+		return err
+	}
+
+	return s.create(ctx, userId, target, input)
+}
+
+// ReportComment сохраняет жалобу на комментарий к опубликованному посту
+func (s *ReportService) ReportComment(ctx context.Context, userId int, commentId int, input models.ReportCreate) error {
+	target, err := s.getTarget(ctx, models.ReportTargetComment, commentId)
+	if err != nil {
+		return err
+	}
+
+	return s.create(ctx, userId, target, input)
+}
+
+// create проверяет, что пользователь видит объект, и сохраняет жалобу.
+// Набрав AutoHideThreshold открытых жалоб, объект скрывается до решения модератора
+func (s *ReportService) create(ctx context.Context, userId int, target reportTarget, input models.ReportCreate) error {
+	if target.AuthorID == userId {
+		return models.ErrSelfReport
+	}
+
+	// Скрытые и неопубликованные объекты видны только автору, поэтому пожаловаться на них нельзя
+	post, err := s.postRepo.GetByID(ctx, target.PostID)
+	if err != nil || post.Status != "approved" || target.Hidden {
+		return fmt.Errorf("%s not found", target.Type)
+	}
+
+	// Пост автора, связанного с пользователем блокировкой, для него не существует
+	for _, authorId := range []int{post.UserID, target.AuthorID} {
+		blocked, err := s.blocks.between(ctx, userId, authorId)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return fmt.Errorf("%s not found", target.Type)
+		}
+	}
+
+	report := models.Report{
+		ReporterID: userId,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		Reason:     input.Reason,
+		CreatedAt:  time.Now(),
+	}
+	if details := strings.TrimSpace(input.Details); details != "" {
+		report.Details = &details
+	}
+
+	added, err := s.reportRepo.Create(ctx, report)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("жалоба уже существует")
+	}
+
+	count, err := s.reportRepo.CountOpen(ctx, target.Type, target.ID)
+	if err != nil {
+		return err
+	}
+
+	// Жалоба уже сохранена, поэтому ошибка скрытия ее не отменяет.
+	// Автоматическое скрытие записывается в журнал модерации без модератора
+	hidden := false
+	if s.cfg.AutoHideThreshold > 0 && count >= s.cfg.AutoHideThreshold {
+		action := &models.ModerationAction{
+			Action:        models.AuditActionAutoHide,
+			TargetType:    target.Type,
+			TargetID:      target.ID,
+			PreviousState: models.AuditState{"hidden": false},
+			NewState:      models.AuditState{"hidden": true, "reports_count": count},
+			CreatedAt:     time.Now(),
+		}
+		if hidden, err = s.setHidden(ctx, target, true, action); err != nil {
+			logrus.Warnf("Failed to hide reported %s %d: %s", target.Type, target.ID, err.Error())
+		}
+	}
+
+	s.events.report(ctx, models.ReportEvent{
+		TargetType:   target.Type,
+		TargetID:     target.ID,
+		Status:       models.ReportStatusOpen,
+		ReportsCount: count,
+		Hidden:       hidden,
+	})
+
+	return nil
+}
+
+// GetQueue получает очередь жалоб, сгруппированных по объекту
+func (s *ReportService) GetQueue(ctx context.Context, filter models.ReportFilter) (models.ReportGroupsResponse, error) {
+	if filter.Status == "" {
+		filter.Status = models.ReportStatusOpen
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	groups, total, err := s.reportRepo.GetGroups(ctx, filter)
+	if err != nil {
+		return models.ReportGroupsResponse{}, err
+	}
+
+	items := make([]models.ReportGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		item := models.ReportGroupResponse{
+			TargetType:      group.TargetType,
+			TargetID:        group.TargetID,
+			PostID:          group.PostID,
+			Hidden:          group.Hidden,
+			ReportsCount:    group.ReportsCount,
+			Reasons:         group.Reasons,
+			FirstReportedAt: group.FirstReportedAt,
+			LastReportedAt:  group.LastReportedAt,
+		}
+		if group.Preview != nil {
+			item.Preview = *group.Preview
+		}
+		if group.AuthorID != nil {
+			item.Author = &models.UserBrief{
+				ID:       *group.AuthorID,
+				Username: *group.AuthorUsername,
+				Nickname: *group.AuthorNickname,
+			}
+			if group.AuthorAvatar != nil {
+				item.Author.Avatar = *group.AuthorAvatar
+			}
+		}
+		items = append(items, item)
+	}
+
+	return models.ReportGroupsResponse{
+		Reports: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}, nil
+}
+
+// GetByTarget получает все жалобы на объект, включая закрытые
+func (s *ReportService) GetByTarget(ctx context.Context, targetType string, targetId int) ([]models.ReportResponse, error) {
+	reports, err := s.reportRepo.GetByTarget(ctx, targetType, targetId)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("reports not found")
+	}
+
+	response := make([]models.ReportResponse, 0, len(reports))
+	for _, report := range reports {
+		item := models.ReportResponse{
+			ID: report.ID,
+			Reporter: models.UserBrief{
+				ID:       report.ReporterID,
+				Username: report.ReporterUsername,
+				Nickname: report.ReporterNickname,
+			},
+			Reason:     report.Reason,
+			Details:    report.Details,
+			Status:     report.Status,
+			Resolution: report.Resolution,
+			ResolvedAt: report.ResolvedAt,
+			CreatedAt:  report.CreatedAt,
+		}
+		if report.ReporterAvatar != nil {
+			item.Reporter.Avatar = *report.ReporterAvatar
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
+// Resolve применяет решение модератора к объекту и закрывает все открытые жалобы на него
+func (s *ReportService) Resolve(ctx context.Context, targetType string, targetId int, moderatorId int, input models.ReportResolve) error {
+	count, err := s.reportRepo.CountOpen(ctx, targetType, targetId)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("reports not found")
+	}
+
+	action := models.ModerationAction{
+		ModeratorID: &moderatorId,
+		Action:      reportAuditActions[input.Action],
+		TargetType:  targetType,
+		TargetID:    targetId,
+		CreatedAt:   time.Now(),
+	}
+	if message := strings.TrimSpace(input.Message); message != "" {
+		action.Reason = &message
+	}
+
+	hidden := false
+	target, err := s.getTarget(ctx, targetType, targetId)
+	if err == nil {
+		if hidden, err = s.apply(ctx, target, moderatorId, input); err != nil {
+			return err
+		}
+		action.PreviousState = models.AuditState{"hidden": target.Hidden}
+		action.NewState = models.AuditState{"hidden": hidden}
+		if input.Action == models.ReportActionDelete {
+			action.NewState = models.AuditState{"deleted": true}
+		}
+	} else if input.Action != models.ReportActionDismiss && input.Action != models.ReportActionDelete {
+		// Объект уже удален: жалобы можно только отклонить или закрыть удалением
+		return err
+	}
+
+	// Жалобы закрываются вместе с записью решения в журнале модерации
+	if _, err := s.reportRepo.Resolve(ctx, targetType, targetId, input.Action, moderatorId, action.CreatedAt, &action); err != nil {
+		return err
+	}
+
+	s.events.report(ctx, models.ReportEvent{
+		TargetType: targetType,
+		TargetID:   targetId,
+		Status:     models.ReportStatusResolved,
+		Hidden:     hidden,
+	})
+
+	return nil
+}
+
+// apply применяет решение модератора к объекту. Возвращает, скрыт ли объект после решения
+func (s *ReportService) apply(ctx context.Context, target reportTarget, moderatorId int, input models.ReportResolve) (bool, error) {
+	switch input.Action {
+	case models.ReportActionDismiss:
+		// Объект, скрытый автоматически, возвращается в публикацию
+		_, err := s.setHidden(ctx, target, false, nil)
+		return false, err
+	case models.ReportActionHide:
+		changed, err := s.setHidden(ctx, target, true, nil)
+		return changed || target.Hidden, err
+	case models.ReportActionDelete:
+		return false, s.delete(ctx, target, moderatorId)
+	case models.ReportActionWarn:
+		notification := models.Notification{UserID: target.AuthorID, PostID: &target.PostID, Type: models.NotificationTypeWarning}
+		if target.Type == models.ReportTargetComment {
+			notification.CommentID = &target.ID
+		}
+		if message := strings.TrimSpace(input.Message); message != "" {
+			notification.RejectReason = &message
+		}
+		s.notifications.send(ctx, notification)
+	}
+
+	return target.Hidden, nil
+}
+
+// getTarget получает пост или комментарий, на который подается или рассматривается жалоба
+func (s *ReportService) getTarget(ctx context.Context, targetType string, targetId int) (reportTarget, error) {
+	if targetType == models.ReportTargetComment {
+		comment, err := s.commentRepo.GetByID(ctx, targetId)
+		if err != nil {
+			return reportTarget{}, fmt.Errorf("comment not found: %w", err)
+		}
+
+		return reportTarget{
+			Type:     targetType,
+			ID:       comment.ID,
+			AuthorID: comment.UserID,
+			PostID:   comment.PostID,
+			Hidden:   comment.HiddenAt != nil,
+		}, nil
+	}
+
+	post, err := s.postRepo.GetByID(ctx, targetId)
+	if err != nil {
+		return reportTarget{}, fmt.Errorf("post not found: %w", err)
+	}
+
+	return reportTarget{
+		Type:     models.ReportTargetPost,
+		ID:       post.ID,
+		AuthorID: post.UserID,
+		PostID:   post.ID,
+		Hidden:   post.Status == "hidden",
+	}, nil
+}
+
+// setHidden скрывает объект или возвращает его в публикацию. Запись журнала модерации,
+// если передана, сохраняется вместе с изменением. Возвращает false, если объект уже был в нужном состоянии
+func (s *ReportService) setHidden(ctx context.Context, target reportTarget, hidden bool, action *models.ModerationAction) (bool, error) {
+	if target.Type == models.ReportTargetComment {
+		changed, err := s.commentRepo.SetHidden(ctx, target.ID, hidden, action)
+		if err != nil {
+			return false, err
+		}
+		if changed {
+			s.events.postCounts(ctx, s.postRepo, target.PostID)
+		}
+
+		return changed, nil
+	}
+
+	return s.postRepo.SetHidden(ctx, target.ID, hidden, action)
+}
+
+// delete перемещает объект в корзину от имени модератора
+func (s *ReportService) delete(ctx context.Context, target reportTarget, moderatorId int) error {
+	if target.Type == models.ReportTargetComment {
+		if err := s.commentRepo.Delete(ctx, target.ID, moderatorId, nil); err != nil {
+			return err
+		}
+		s.events.postCounts(ctx, s.postRepo, target.PostID)
+
+		return nil
+	}
+
+	return s.postRepo.Delete(ctx, target.ID, moderatorId, nil)
+}
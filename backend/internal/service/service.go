@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"designhub/internal/config"
 	"designhub/internal/models"
 	"designhub/internal/repository"
+	"designhub/pkg/mailer"
+	"designhub/pkg/pubsub"
 	"io"
 	"mime/multipart"
 
@@ -19,7 +22,7 @@ type Authorization interface {
 
 // User сервис для работы с пользователями
 type User interface {
-	GetByID(ctx context.Context, id int) (models.UserResponse, error)
+	GetByID(ctx context.Context, id int, currentUserId int) (models.UserResponse, error)
 	Update(ctx context.Context, id int, user models.UserUpdate) error
 	UpdateAvatar(ctx context.Context, id int, avatar *multipart.FileHeader) error
 	Delete(ctx context.Context, id int) error
@@ -32,9 +35,14 @@ type Post interface {
 	GetAll(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
 	GetByUserID(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
 	GetLikedByUserID(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
+	GetFollowingFeed(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
 	GetPendingModeration(ctx context.Context, filter models.PostFilter) (models.FeedResponse, error)
+	GetDrafts(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
+	GetScheduled(ctx context.Context, userId int, filter models.PostFilter) (models.FeedResponse, error)
 	Update(ctx context.Context, id int, userId int, post models.PostUpdate) error
 	UpdateStatus(ctx context.Context, id int, moderatorId int, status models.PostModeration) error
+	Submit(ctx context.Context, id int, userId int) error
+	GetRevisions(ctx context.Context, id int, userId int) ([]models.PostRevisionResponse, error)
 	Delete(ctx context.Context, id int, userId int) error
 }
 
@@ -42,8 +50,10 @@ type Post interface {
 type Comment interface {
 	Create(ctx context.Context, userId int, comment models.CommentCreate) (int, error)
 	GetByID(ctx context.Context, id int) (models.CommentResponse, error)
-	GetByPostID(ctx context.Context, postId int) ([]models.CommentResponse, error)
+	GetByPostID(ctx context.Context, postId int, filter models.CommentFilter) (models.CommentPage, error)
+	GetReplies(ctx context.Context, id int, filter models.CommentFilter) (models.CommentPage, error)
 	Update(ctx context.Context, id int, userId int, comment models.CommentUpdate) error
+	SetResolved(ctx context.Context, id int, userId int, resolved bool) error
 	Delete(ctx context.Context, id int, userId int) error
 }
 
@@ -56,13 +66,166 @@ type Like interface {
 
 // Category сервис для работы с категориями
 type Category interface {
-	Create(ctx context.Context, category models.CategoryCreate) (int, error)
-	GetByID(ctx context.Context, id int) (models.Category, error)
-	GetAll(ctx context.Context) ([]models.Category, error)
-	Update(ctx context.Context, id int, category models.CategoryUpdate) error
+	Create(ctx context.Context, moderatorId int, category models.CategoryCreate) (int, error)
+	GetByID(ctx context.Context, id int, currentUserId int) (models.Category, error)
+	GetAll(ctx context.Context, currentUserId int) ([]models.Category, error)
+	Update(ctx context.Context, id int, moderatorId int, category models.CategoryUpdate) error
+	Delete(ctx context.Context, id int, moderatorId int) error
+}
+
+// Related сервис рекомендаций похожих постов
+type Related interface {
+	GetByPostID(ctx context.Context, postId int, currentUserId int) ([]models.PostResponse, error)
+	Run(ctx context.Context)
+}
+
+// Analytics сервис учета просмотров и статистики постов
+type Analytics interface {
+	RecordView(postId int, viewerId int, ip string, referrer string)
+	FlushViews(ctx context.Context) error
+	Run(ctx context.Context)
+	GetPostStats(ctx context.Context, postId int, userId int, days int) (models.PostStatsResponse, error)
+	GetCreatorDashboard(ctx context.Context, userId int, days int) (models.DashboardResponse, error)
+}
+
+// Trending сервис для расчета трендовых постов
+type Trending interface {
+	Recalculate(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+// Publishing сервис отложенной публикации постов
+type Publishing interface {
+	ProcessDue(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+// Trash сервис корзины удаленных постов и комментариев
+type Trash interface {
+	GetUserTrash(ctx context.Context, userId int, filter models.TrashFilter) (models.TrashResponse, error)
+	GetAllTrash(ctx context.Context, filter models.TrashFilter) (models.TrashResponse, error)
+	RestorePost(ctx context.Context, id int, userId int) error
+	RestoreComment(ctx context.Context, id int, userId int) error
+	PurgeExpired(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+// Collection сервис коллекций пользователей
+type Collection interface {
+	Create(ctx context.Context, userId int, collection models.CollectionCreate) (int, error)
+	GetByID(ctx context.Context, id int, currentUserId int, filter models.PostFilter) (models.CollectionPageResponse, error)
+	GetByUserID(ctx context.Context, userId int, currentUserId int) ([]models.CollectionResponse, error)
+	GetByPostID(ctx context.Context, postId int, currentUserId int) ([]models.CollectionResponse, error)
+	Update(ctx context.Context, id int, userId int, collection models.CollectionUpdate) error
+	UpdateCover(ctx context.Context, id int, userId int, cover *multipart.FileHeader) error
+	Delete(ctx context.Context, id int, userId int) error
+	AddPost(ctx context.Context, id int, userId int, postId int) error
+	RemovePost(ctx context.Context, id int, userId int, postId int) error
+	Reorder(ctx context.Context, id int, userId int, postIds []int) error
+}
+
+// Coauthor сервис соавторов постов
+type Coauthor interface {
+	Invite(ctx context.Context, postId int, userId int, input models.CoauthorInvite) error
+	GetByPostID(ctx context.Context, postId int, userId int) ([]models.CoauthorResponse, error)
+	GetInvitations(ctx context.Context, userId int) ([]models.CoauthorInvitationResponse, error)
+	Update(ctx context.Context, postId int, userId int, coauthorId int, input models.CoauthorUpdate) error
+	Accept(ctx context.Context, postId int, userId int) error
+	Decline(ctx context.Context, postId int, userId int) error
+	Remove(ctx context.Context, postId int, userId int, coauthorId int) error
+}
+
+// Tool сервис каталога инструментов
+type Tool interface {
+	Create(ctx context.Context, tool models.ToolCreate) (int, error)
+	GetByID(ctx context.Context, id int) (models.Tool, error)
+	GetAll(ctx context.Context) ([]models.Tool, error)
+	Update(ctx context.Context, id int, tool models.ToolUpdate) error
 	Delete(ctx context.Context, id int) error
 }
 
+// Reaction сервис реакций на посты и комментарии
+type Reaction interface {
+	GetTypes() []string
+	Add(ctx context.Context, userId int, target string, targetId int, reactionType string) error
+	Remove(ctx context.Context, userId int, target string, targetId int, reactionType string) error
+	GetUsers(ctx context.Context, target string, targetId int, reactionType string, filter models.ReactionUsersFilter) (models.ReactionUsersResponse, error)
+}
+
+// Follow сервис подписок на пользователей и категории
+type Follow interface {
+	FollowUser(ctx context.Context, userId int, followeeId int) error
+	UnfollowUser(ctx context.Context, userId int, followeeId int) error
+	FollowCategory(ctx context.Context, userId int, categoryId int) error
+	UnfollowCategory(ctx context.Context, userId int, categoryId int) error
+	GetFollowers(ctx context.Context, userId int, currentUserId int, filter models.FollowFilter) (models.FollowUsersResponse, error)
+	GetFollowing(ctx context.Context, userId int, currentUserId int, filter models.FollowFilter) (models.FollowUsersResponse, error)
+	GetFollowedCategories(ctx context.Context, userId int, filter models.FollowFilter) (models.FollowedCategoriesResponse, error)
+}
+
+// Notification сервис центра уведомлений
+type Notification interface {
+	GetByUserID(ctx context.Context, userId int, filter models.NotificationFilter) (models.NotificationsResponse, error)
+	CountUnread(ctx context.Context, userId int) (int, error)
+	MarkRead(ctx context.Context, userId int, id int) error
+	MarkAllRead(ctx context.Context, userId int, ids []int) (int64, error)
+	GetPreferences(ctx context.Context, userId int) (models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userId int, input models.NotificationPreferencesUpdate) (models.NotificationPreferences, error)
+	CheckUnsubscribeToken(token string) error
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+// Message сервис личных сообщений
+type Message interface {
+	SendToUser(ctx context.Context, userId int, recipientId int, input models.MessageCreate) (models.MessageResponse, error)
+	SendToConversation(ctx context.Context, userId int, conversationId int, input models.MessageCreate) (models.MessageResponse, error)
+	GetConversations(ctx context.Context, userId int, filter models.ConversationFilter) (models.ConversationsResponse, error)
+	GetRequests(ctx context.Context, userId int, filter models.ConversationFilter) (models.ConversationsResponse, error)
+	GetConversation(ctx context.Context, conversationId int, userId int) (models.ConversationResponse, error)
+	GetMessages(ctx context.Context, conversationId int, userId int, filter models.MessageFilter) (models.MessagePage, error)
+	GetEvent(ctx context.Context, userId int, ref models.MessageRef) (models.MessageEvent, error)
+	MarkRead(ctx context.Context, conversationId int, userId int) error
+	Accept(ctx context.Context, conversationId int, userId int) error
+	Decline(ctx context.Context, conversationId int, userId int) error
+	GetSettings(ctx context.Context, userId int) (models.MessageSettings, error)
+	UpdateSettings(ctx context.Context, userId int, settings models.MessageSettings) error
+}
+
+// Block сервис блокировки и заглушения пользователей
+type Block interface {
+	Block(ctx context.Context, userId int, blockedId int) error
+	Unblock(ctx context.Context, userId int, blockedId int) error
+	Mute(ctx context.Context, userId int, mutedId int) error
+	Unmute(ctx context.Context, userId int, mutedId int) error
+	GetBlocked(ctx context.Context, userId int, filter models.RestrictionFilter) (models.RestrictedUsersResponse, error)
+	GetMuted(ctx context.Context, userId int, filter models.RestrictionFilter) (models.RestrictedUsersResponse, error)
+}
+
+// Report сервис жалоб на посты и комментарии
+type Report interface {
+	ReportPost(ctx context.Context, userId int, postId int, input models.ReportCreate) error
+	ReportComment(ctx context.Context, userId int, commentId int, input models.ReportCreate) error
+	GetQueue(ctx context.Context, filter models.ReportFilter) (models.ReportGroupsResponse, error)
+	GetByTarget(ctx context.Context, targetType string, targetId int) ([]models.ReportResponse, error)
+	Resolve(ctx context.Context, targetType string, targetId int, moderatorId int, input models.ReportResolve) error
+}
+
+// Audit сервис журнала действий модераторов
+type Audit interface {
+	GetAll(ctx context.Context, filter models.AuditFilter) (models.ModerationActionsResponse, error)
+}
+
+// Digest сервис email-дайджестов
+type Digest interface {
+	SendDue(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+// Stream сервис потока событий реального времени
+type Stream interface {
+	Subscribe(ctx context.Context, userId int, postIds []int) (*pubsub.Subscription, error)
+}
+
 // Service главная структура сервисного слоя
 type Service struct {
 	Authorization
@@ -71,17 +234,51 @@ type Service struct {
 	Comment
 	Like
 	Category
+	Trending
+	Related
+	Analytics
+	Publishing
+	Trash
+	Collection
+	Coauthor
+	Tool
+	Reaction
+	Follow
+	Notification
+	Stream
+	Digest
+	Message
+	Block
+	Report
+	Audit
 }
 
 // NewService конструктор сервисного слоя
-func NewService(repos *repository.Repository, db *sqlx.DB, fileStorage FileStorage) *Service {
+func NewService(repos *repository.Repository, db *sqlx.DB, fileStorage FileStorage, hub pubsub.Hub, mailer mailer.Mailer, cfg *config.Config) *Service {
 	return &Service{
 		Authorization: NewAuthService(repos.User),
-		User:          NewUserService(repos.User, fileStorage),
-		Post:          NewPostService(repos.Post, repos.Like, repos.User, repos.Category, fileStorage),
-		Comment:       NewCommentService(repos.Comment, repos.User),
-		Like:          NewLikeService(repos.Like, repos.Post),
-		Category:      NewCategoryService(repos.Category),
+		User:          NewUserService(repos.User, repos.Follow, repos.Block, fileStorage),
+		Post:          NewPostService(repos.Post, repos.Like, repos.User, repos.Category, repos.Coauthor, repos.Tool, repos.Block, repos.Mention, repos.Notification, hub, fileStorage, cfg.Moderation),
+		Comment:       NewCommentService(repos.Comment, repos.User, repos.Post, repos.Coauthor, repos.Mention, repos.Reaction, repos.Block, repos.Notification, hub, cfg.Comments),
+		Like:          NewLikeService(repos.Like, repos.Post, repos.User, repos.Coauthor, repos.Block, repos.Notification, hub),
+		Category:      NewCategoryService(repos.Category, repos.Follow),
+		Trending:      NewTrendingService(repos.Trending, cfg.Trending),
+		Related:       NewRelatedService(repos.Post, repos.Block, cfg.Related),
+		Analytics:     NewAnalyticsService(repos.Analytics, repos.Post, repos.Comment, repos.User, cfg.Analytics),
+		Publishing:    NewPublishingService(repos.Post, repos.Mention, repos.Notification, hub, cfg.Publishing),
+		Trash:         NewTrashService(repos.Trash, repos.Post, repos.Comment, repos.User, fileStorage, cfg.Trash),
+		Collection:    NewCollectionService(repos.Collection, repos.Post, fileStorage),
+		Coauthor:      NewCoauthorService(repos.Coauthor, repos.Post, repos.User),
+		Tool:          NewToolService(repos.Tool),
+		Reaction:      NewReactionService(repos.Reaction, repos.Post, repos.Comment, repos.Block, cfg.Reactions),
+		Notification:  NewNotificationService(repos.Notification, repos.NotificationPreference, hub, cfg.Digest),
+		Stream:        NewStreamService(hub, repos.User, repos.Post, repos.Coauthor, repos.Block, cfg.Realtime),
+		Follow:        NewFollowService(repos.Follow, repos.User, repos.Category, repos.Block, repos.Notification, hub),
+		Digest:        NewDigestService(repos.Digest, mailer, fileStorage, cfg.Digest),
+		Message:       NewMessageService(repos.Message, repos.User, repos.Follow, repos.Block, hub),
+		Block:         NewBlockService(repos.Block, repos.User),
+		Report:        NewReportService(repos.Report, repos.Post, repos.Comment, repos.Block, repos.Notification, hub, cfg.Reports),
+		Audit:         NewAuditService(repos.Audit),
 	}
 }
 
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"designhub/pkg/pubsub"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// moderationTopic тема изменений очереди модерации, на нее подписываются модераторы
+const moderationTopic = "moderation"
+
+// userTopic тема личных событий пользователя
+func userTopic(userId int) string {
+	return fmt.Sprintf("user:%d", userId)
+}
+
+// postTopic тема событий поста для его зрителей
+func postTopic(postId int) string {
+	return fmt.Sprintf("post:%d", postId)
+}
+
+type StreamService struct {
+	hub      pubsub.Hub
+	userRepo repository.User
+	postRepo repository.Post
+	access   postAccess
+	cfg      config.RealtimeConfig
+}
+
+func NewStreamService(
+	hub pubsub.Hub,
+	userRepo repository.User,
+	postRepo repository.Post,
+	coauthorRepo repository.Coauthor,
+	blockRepo repository.Block,
+	cfg config.RealtimeConfig,
+) *StreamService {
+	return &StreamService{
+		hub:      hub,
+		userRepo: userRepo,
+		postRepo: postRepo,
+		access:   newPostAccess(userRepo, coauthorRepo, blockRepo),
+		cfg:      cfg,
+	}
+}
+
+// Subscribe подписывает пользователя на его личные события, счетчики просматриваемых
+// постов и, для модераторов, на изменения очереди модерации
+func (s *StreamService) Subscribe(ctx context.Context, userId int, postIds []int) (*pubsub.Subscription, error) {
+	if len(postIds) > s.cfg.MaxPosts {
+		return nil, models.ErrTooManyStreamPosts
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	isModerator := user.Role == "moderator" || user.Role == "admin"
+
+	topics := []string{userTopic(userId)}
+
+	seen := make(map[int]bool, len(postIds))
+	for _, postId := range postIds {
+		if seen[postId] {
+			continue
+		}
+		seen[postId] = true
+
+		// Подписаться можно только на события поста, который пользователь видит
+		post, err := s.postRepo.GetByID(ctx, postId)
+		if err != nil {
+			return nil, fmt.Errorf("post not found: %w", err)
+		}
+		if err := s.access.check(ctx, post, userId); err != nil {
+			return nil, err
+		}
+
+		topics = append(topics, postTopic(postId))
+	}
+
+	if isModerator {
+		topics = append(topics, moderationTopic)
+	}
+
+	return s.hub.Subscribe(topics...), nil
+}
+
+// eventPublisher публикует события для потока реального времени. Событие — побочный
+// эффект действия, поэтому ошибка публикации только логируется
+type eventPublisher struct {
+	hub pubsub.Hub
+}
+
+// publish сериализует данные и публикует событие в теме
+func (p eventPublisher) publish(ctx context.Context, topic string, event string, data interface{}) {
+	if p.hub == nil {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		logrus.Warnf("Failed to encode %s event: %s", event, err.Error())
+		return
+	}
+
+	if err := p.hub.Publish(ctx, pubsub.Message{Topic: topic, Event: event, Data: payload}); err != nil {
+		logrus.Warnf("Failed to publish %s event to %s: %s", event, topic, err.Error())
+	}
+}
+
+// postCounts отправляет зрителям поста актуальные счетчики лайков и комментариев
+func (p eventPublisher) postCounts(ctx context.Context, postRepo repository.Post, postId int) {
+	if p.hub == nil {
+		return
+	}
+
+	counts, err := postRepo.GetCounts(ctx, postId)
+	if err != nil {
+		logrus.Warnf("Failed to get counts for post %d: %s", postId, err.Error())
+		return
+	}
+
+	p.publish(ctx, postTopic(postId), models.StreamEventPostCounts, counts)
+}
+
+// moderation сообщает модераторам об изменении очереди модерации
+func (p eventPublisher) moderation(ctx context.Context, event models.ModerationEvent) {
+	p.publish(ctx, moderationTopic, models.StreamEventModeration, event)
+}
+
+// report сообщает модераторам об изменении очереди жалоб
+func (p eventPublisher) report(ctx context.Context, event models.ReportEvent) {
+	p.publish(ctx, moderationTopic, models.StreamEventReport, event)
+}
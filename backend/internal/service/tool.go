@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"time"
+)
+
+type ToolService struct {
+	toolRepo repository.Tool
+}
+
+func NewToolService(toolRepo repository.Tool) *ToolService {
+	return &ToolService{toolRepo: toolRepo}
+}
+
+// Create добавляет инструмент в каталог
+func (s *ToolService) Create(ctx context.Context, input models.ToolCreate) (int, error) {
+	slug := generateSlug(input.Name)
+
+	exists, err := s.toolRepo.SlugExistsExcept(ctx, slug, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check slug existence: %w", err)
+	}
+	if exists {
+		return 0, fmt.Errorf("инструмент с таким slug уже существует")
+	}
+
+	tool := models.Tool{
+		Name:      input.Name,
+		Slug:      slug,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	return s.toolRepo.Create(ctx, tool)
+}
+
+// GetByID получает инструмент по ID
+func (s *ToolService) GetByID(ctx context.Context, id int) (models.Tool, error) {
+	return s.toolRepo.GetByID(ctx, id)
+}
+
+// GetAll получает весь каталог инструментов
+func (s *ToolService) GetAll(ctx context.Context) ([]models.Tool, error) {
+	tools, err := s.toolRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tools == nil {
+		tools = []models.Tool{}
+	}
+
+	return tools, nil
+}
+
+// Update переименовывает инструмент
+func (s *ToolService) Update(ctx context.Context, id int, input models.ToolUpdate) error {
+	if _, err := s.toolRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	slug := generateSlug(input.Name)
+
+	exists, err := s.toolRepo.SlugExistsExcept(ctx, slug, id)
+	if err != nil {
+		return fmt.Errorf("failed to check slug existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("инструмент с таким slug уже существует")
+	}
+
+	tool := models.Tool{
+		ID:        id,
+		Name:      input.Name,
+		Slug:      slug,
+		UpdatedAt: time.Now(),
+	}
+
+	return s.toolRepo.Update(ctx, tool)
+}
+
+// Delete удаляет инструмент, если он не указан ни в одном посте
+func (s *ToolService) Delete(ctx context.Context, id int) error {
+	if _, err := s.toolRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	hasRelatedPosts, err := s.toolRepo.HasRelatedPosts(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check related posts: %w", err)
+	}
+	if hasRelatedPosts {
+		return fmt.Errorf("невозможно удалить инструмент, так как он используется в постах")
+	}
+
+	return s.toolRepo.Delete(ctx, id)
+}
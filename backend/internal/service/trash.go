@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TrashService struct {
+	trashRepo   repository.Trash
+	postRepo    repository.Post
+	commentRepo repository.Comment
+	userRepo    repository.User
+	fileStorage FileStorage
+	cfg         config.TrashConfig
+}
+
+func NewTrashService(
+	trashRepo repository.Trash,
+	postRepo repository.Post,
+	commentRepo repository.Comment,
+	userRepo repository.User,
+	fileStorage FileStorage,
+	cfg config.TrashConfig,
+) *TrashService {
+	return &TrashService{
+		trashRepo:   trashRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		userRepo:    userRepo,
+		fileStorage: fileStorage,
+		cfg:         cfg,
+	}
+}
+
+// GetUserTrash получает удаленные посты и комментарии пользователя
+func (s *TrashService) GetUserTrash(ctx context.Context, userId int, filter models.TrashFilter) (models.TrashResponse, error) {
+	filter.UserID = userId
+
+	items, total, err := s.trashRepo.GetItems(ctx, filter)
+	if err != nil {
+		return models.TrashResponse{}, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	// Автор может восстановить только то, что удалил сам, а не модератор
+	for i := range items {
+		items[i].ExpiresAt = s.expiresAt(items[i].DeletedAt)
+		items[i].CanRestore = items[i].DeletedBy != nil && *items[i].DeletedBy == userId && s.restorable(items[i].DeletedAt)
+	}
+
+	return newTrashResponse(items, filter, total), nil
+}
+
+// GetAllTrash получает всю корзину (для модераторов)
+func (s *TrashService) GetAllTrash(ctx context.Context, filter models.TrashFilter) (models.TrashResponse, error) {
+	filter.UserID = 0
+
+	items, total, err := s.trashRepo.GetItems(ctx, filter)
+	if err != nil {
+		return models.TrashResponse{}, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	for i := range items {
+		items[i].ExpiresAt = s.expiresAt(items[i].DeletedAt)
+		items[i].CanRestore = s.restorable(items[i].DeletedAt)
+	}
+
+	return newTrashResponse(items, filter, total), nil
+}
+
+// RestorePost восстанавливает пост из корзины
+func (s *TrashService) RestorePost(ctx context.Context, id int, userId int) error {
+	post, err := s.postRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("post not found: %w", err)
+	}
+
+	// Черновик виден только автору
+	if post.Status == "draft" && post.UserID != userId {
+		return fmt.Errorf("post not found")
+	}
+
+	if !s.canRestore(ctx, post.UserID, post.DeletedBy, userId) {
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	if !s.restorable(*post.DeletedAt) {
+		return models.ErrRestoreExpired
+	}
+
+	return s.postRepo.Restore(ctx, id)
+}
+
+// RestoreComment восстанавливает комментарий из корзины
+func (s *TrashService) RestoreComment(ctx context.Context, id int, userId int) error {
+	comment, err := s.commentRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("comment not found: %w", err)
+	}
+
+	if !s.canRestore(ctx, comment.UserID, comment.DeletedBy, userId) {
+		return fmt.Errorf("доступ запрещен")
+	}
+
+	if !s.restorable(*comment.DeletedAt) {
+		return models.ErrRestoreExpired
+	}
+
+	return s.commentRepo.Restore(ctx, id)
+}
+
+// PurgeExpired окончательно удаляет записи, срок хранения которых в корзине истек, вместе с медиафайлами
+func (s *TrashService) PurgeExpired(ctx context.Context) error {
+	before := time.Now().Add(-s.retention())
+
+	mediaPaths, err := s.postRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	for _, mediaPath := range mediaPaths {
+		if mediaPath == "" {
+			continue
+		}
+		if err := s.fileStorage.DeleteFile(filepath.Base(mediaPath)); err != nil {
+			// Логируем ошибку, но продолжаем очистку
+			logrus.Warnf("Failed to delete media file %s: %s", mediaPath, err.Error())
+		}
+	}
+
+	comments, err := s.commentRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if len(mediaPaths) > 0 || comments > 0 {
+		logrus.Infof("Trash purged: %d posts, %d comments", len(mediaPaths), comments)
+	}
+
+	return nil
+}
+
+// Run периодически очищает корзину до отмены контекста
+func (s *TrashService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.PurgeExpired(ctx); err != nil {
+			logrus.Errorf("Trash purge failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// canRestore проверяет право на восстановление: модератор может восстановить любую запись,
+// автор — только удаленную им самим
+func (s *TrashService) canRestore(ctx context.Context, ownerId int, deletedBy *int, userId int) bool {
+	if ownerId == userId && deletedBy != nil && *deletedBy == userId {
+		return true
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userId)
+	return err == nil && (user.Role == "moderator" || user.Role == "admin")
+}
+
+// retention возвращает срок хранения записей в корзине
+func (s *TrashService) retention() time.Duration {
+	return time.Duration(s.cfg.RetentionDays) * 24 * time.Hour
+}
+
+// expiresAt возвращает время окончательного удаления записи
+func (s *TrashService) expiresAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.retention())
+}
+
+// restorable проверяет, что срок хранения записи в корзине еще не истек
+func (s *TrashService) restorable(deletedAt time.Time) bool {
+	return time.Now().Before(s.expiresAt(deletedAt))
+}
+
+// newTrashResponse формирует ответ корзины с информацией о пагинации
+func newTrashResponse(items []models.TrashItem, filter models.TrashFilter, total int) models.TrashResponse {
+	if items == nil {
+		items = []models.TrashItem{}
+	}
+
+	return models.TrashResponse{
+		Items: items,
+		Pagination: models.Pagination{
+			Total:   total,
+			Page:    filter.Page,
+			PerPage: filter.PerPage,
+			Pages:   (total + filter.PerPage - 1) / filter.PerPage,
+		},
+	}
+}
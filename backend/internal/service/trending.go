@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"designhub/internal/config"
+	"designhub/internal/models"
+	"designhub/internal/repository"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Веса активности в оценке трендовых постов: комментарий ценнее лайка, а лайк ценнее просмотра
+const (
+	trendingLikeWeight    = 1.0
+	trendingCommentWeight = 2.0
+	trendingViewWeight    = 0.1
+)
+
+type TrendingService struct {
+	repo repository.Trending
+	cfg  config.TrendingConfig
+}
+
+func NewTrendingService(repo repository.Trending, cfg config.TrendingConfig) *TrendingService {
+	return &TrendingService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Recalculate пересчитывает оценки трендовых постов для всех периодов
+func (s *TrendingService) Recalculate(ctx context.Context) error {
+	for period, window := range models.TrendingPeriods {
+		params := models.TrendingParams{
+			Period:        period,
+			Window:        window,
+			Gravity:       s.cfg.Gravity,
+			LikeWeight:    trendingLikeWeight,
+			CommentWeight: trendingCommentWeight,
+			ViewWeight:    trendingViewWeight,
+		}
+
+		if err := s.repo.Recalculate(ctx, params); err != nil {
+			return fmt.Errorf("failed to recalculate trending for %s: %w", period, err)
+		}
+	}
+
+	return nil
+}
+
+// Run периодически пересчитывает оценки до отмены контекста
+func (s *TrendingService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Recalculate(ctx); err != nil {
+			logrus.Errorf("Trending recalculation failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -13,29 +13,51 @@ import (
 
 type UserService struct {
 	repo        repository.User
+	followRepo  repository.Follow
+	blockRepo   repository.Block
 	fileStorage FileStorage
 }
 
-func NewUserService(repo repository.User, fileStorage FileStorage) *UserService {
+func NewUserService(repo repository.User, followRepo repository.Follow, blockRepo repository.Block, fileStorage FileStorage) *UserService {
 	return &UserService{
 		repo:        repo,
+		followRepo:  followRepo,
+		blockRepo:   blockRepo,
 		fileStorage: fileStorage,
 	}
 }
 
-func (s *UserService) GetByID(ctx context.Context, id int) (models.UserResponse, error) {
+func (s *UserService) GetByID(ctx context.Context, id int, currentUserId int) (models.UserResponse, error) {
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return models.UserResponse{}, fmt.Errorf("failed to get user by id: %w", err)
 	}
 
 	response := models.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Nickname:  user.Nickname,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
+		ID:             user.ID,
+		Username:       user.Username,
+		Nickname:       user.Nickname,
+		Email:          user.Email,
+		Role:           user.Role,
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+		CreatedAt:      user.CreatedAt,
+	}
+
+	// Отмечаем, подписан ли текущий пользователь на профиль
+	if currentUserId != 0 && currentUserId != id {
+		isFollowing, err := s.followRepo.IsFollowingUser(ctx, currentUserId, id)
+		if err != nil {
+			return models.UserResponse{}, err
+		}
+		response.IsFollowing = isFollowing
+
+		isBlocked, isMuted, err := s.blockRepo.GetRelation(ctx, currentUserId, id)
+		if err != nil {
+			return models.UserResponse{}, err
+		}
+		response.IsBlocked = isBlocked
+		response.IsMuted = isMuted
 	}
 
 	// Обрабатываем nullable поля
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_user_id_created_at_id;
+DROP INDEX IF EXISTS idx_posts_status_likes_count_id;
+DROP INDEX IF EXISTS idx_posts_status_created_at_id;
@@ -0,0 +1,4 @@
+-- Индексы для keyset-пагинации лент по паре (значение сортировки, id)
+CREATE INDEX idx_posts_status_created_at_id ON posts (status, created_at DESC, id DESC);
+CREATE INDEX idx_posts_status_likes_count_id ON posts (status, likes_count DESC, id DESC);
+CREATE INDEX idx_posts_user_id_created_at_id ON posts (user_id, created_at DESC, id DESC);
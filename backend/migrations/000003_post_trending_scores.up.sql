@@ -0,0 +1,15 @@
+-- Материализованные оценки трендовых постов, пересчитываются фоновой задачей
+CREATE TABLE post_trending_scores (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    period VARCHAR(10) NOT NULL, -- 'day', 'week' или 'month'
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (period, post_id)
+);
+
+-- Индекс для выборки ленты trending с keyset-пагинацией
+CREATE INDEX idx_post_trending_scores_period_score ON post_trending_scores (period, score DESC, post_id DESC);
+
+-- Индексы для подсчета активности за период
+CREATE INDEX idx_likes_created_at ON likes (created_at);
+CREATE INDEX idx_comments_created_at ON comments (created_at);
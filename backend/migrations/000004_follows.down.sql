@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_category_id_created_at_id;
+DROP TABLE IF EXISTS category_follows;
+DROP TABLE IF EXISTS user_follows;
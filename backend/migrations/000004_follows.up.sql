@@ -0,0 +1,23 @@
+-- Подписки пользователей на других пользователей
+CREATE TABLE user_follows (
+    follower_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX idx_user_follows_followee_id ON user_follows (followee_id);
+
+-- Подписки пользователей на категории
+CREATE TABLE category_follows (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, category_id)
+);
+
+CREATE INDEX idx_category_follows_category_id ON category_follows (category_id);
+
+-- Индекс для выборки ленты подписок по категориям (по авторам используется idx_posts_user_id_created_at_id)
+CREATE INDEX idx_posts_category_id_created_at_id ON posts (category_id, created_at DESC, id DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS post_referrer_stats;
+DROP TABLE IF EXISTS post_daily_stats;
+ALTER TABLE posts DROP COLUMN IF EXISTS views_count;
@@ -0,0 +1,21 @@
+-- Счетчик просмотров поста, обновляется фоновой задачей вместе с дневной статистикой
+ALTER TABLE posts ADD COLUMN views_count INT NOT NULL DEFAULT 0;
+
+-- Просмотры постов по дням
+CREATE TABLE post_daily_stats (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day)
+);
+
+CREATE INDEX idx_post_daily_stats_day ON post_daily_stats (day);
+
+-- Источники переходов на пост по дням
+CREATE TABLE post_referrer_stats (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    referrer VARCHAR(255) NOT NULL, -- Хост источника или 'direct'
+    views INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day, referrer)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_user_id_status;
+ALTER TABLE posts DROP COLUMN IF EXISTS moderated_at;
@@ -0,0 +1,7 @@
+-- Время принятия решения модератором для расчета времени модерации
+ALTER TABLE posts ADD COLUMN moderated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Для уже промодерированных постов точное время решения неизвестно, используем время последнего обновления
+UPDATE posts SET moderated_at = updated_at WHERE status IN ('approved', 'rejected');
+
+CREATE INDEX idx_posts_user_id_status ON posts (user_id, status);
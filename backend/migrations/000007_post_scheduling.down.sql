@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_posts_status_publish_at;
+
+-- Неопубликованные черновики и отложенные посты возвращаются в очередь модерации
+UPDATE posts SET status = 'pending' WHERE status IN ('draft', 'scheduled');
+
+ALTER TABLE posts DROP COLUMN IF EXISTS publish_at;
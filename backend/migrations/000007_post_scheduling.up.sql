@@ -0,0 +1,7 @@
+-- Время публикации для отложенных постов и черновиков.
+-- Статусы поста дополняются значениями 'draft' (черновик, виден только автору)
+-- и 'scheduled' (одобрен модератором, ждет времени публикации)
+ALTER TABLE posts ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Планировщик выбирает посты со статусом draft или scheduled, время публикации которых наступило
+CREATE INDEX idx_posts_status_publish_at ON posts (status, publish_at) WHERE publish_at IS NOT NULL;
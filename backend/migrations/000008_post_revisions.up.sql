@@ -0,0 +1,12 @@
+-- История правок постов: каждая запись хранит измененные поля со старыми и новыми значениями
+CREATE TABLE post_revisions (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    editor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changes JSONB NOT NULL,
+    previous_status VARCHAR(50) NOT NULL,
+    remoderated BOOLEAN NOT NULL DEFAULT FALSE, -- Правка вернула пост на модерацию
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_post_revisions_post_id_created_at ON post_revisions (post_id, created_at DESC);
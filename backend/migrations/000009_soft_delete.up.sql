@@ -0,0 +1,10 @@
+-- Мягкое удаление постов и комментариев: запись остается в корзине до окончательной очистки
+ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE posts ADD COLUMN deleted_by INT DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN deleted_by INT DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+-- Корзина и очистка выбирают только удаленные записи
+CREATE INDEX idx_posts_deleted_at ON posts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
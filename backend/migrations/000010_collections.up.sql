@@ -0,0 +1,25 @@
+-- Коллекции (мудборды) пользователей для сохранения чужих работ по темам
+CREATE TABLE collections (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    cover_path VARCHAR(255) DEFAULT NULL, -- Загруженная обложка, иначе используется первая работа
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_collections_user_id ON collections (user_id, created_at DESC);
+
+-- Посты в коллекции с порядком, заданным владельцем
+CREATE TABLE collection_items (
+    collection_id INT NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, post_id)
+);
+
+CREATE INDEX idx_collection_items_position ON collection_items (collection_id, position);
+CREATE INDEX idx_collection_items_post_id ON collection_items (post_id);
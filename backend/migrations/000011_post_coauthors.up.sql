@@ -0,0 +1,14 @@
+-- Соавторы поста: приглашение автора с подписью роли, которое соавтор принимает или отклоняет
+CREATE TABLE post_coauthors (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL, -- Подпись роли, например "Иллюстрация" или "3D"
+    can_edit BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'accepted', 'declined'
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    PRIMARY KEY (post_id, user_id)
+);
+
+-- Лента профиля и список приглашений выбирают записи соавтора по статусу
+CREATE INDEX idx_post_coauthors_user_id_status ON post_coauthors (user_id, status);
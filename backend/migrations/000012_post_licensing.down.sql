@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS post_tools;
+DROP TABLE IF EXISTS tools;
+
+DROP INDEX IF EXISTS idx_posts_license;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS credit_url;
+ALTER TABLE posts DROP COLUMN IF EXISTS source_url;
+ALTER TABLE posts DROP COLUMN IF EXISTS license;
@@ -0,0 +1,25 @@
+-- Лицензия и ссылки на источник и авторство работы
+ALTER TABLE posts ADD COLUMN license VARCHAR(30) NOT NULL DEFAULT 'all-rights-reserved';
+ALTER TABLE posts ADD COLUMN source_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN credit_url TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_posts_license ON posts (license);
+
+-- Каталог инструментов (Figma, Blender, Photoshop...), которым управляют модераторы
+CREATE TABLE tools (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Инструменты, использованные в работе
+CREATE TABLE post_tools (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tool_id INT NOT NULL REFERENCES tools(id) ON DELETE RESTRICT,
+    PRIMARY KEY (post_id, tool_id)
+);
+
+-- Фильтр ленты по инструменту
+CREATE INDEX idx_post_tools_tool_id ON post_tools (tool_id);
@@ -0,0 +1,10 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS resolved_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS resolved_at;
+
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_annotation_check;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS height;
+ALTER TABLE comments DROP COLUMN IF EXISTS width;
+ALTER TABLE comments DROP COLUMN IF EXISTS pos_y;
+ALTER TABLE comments DROP COLUMN IF EXISTS pos_x;
+ALTER TABLE comments DROP COLUMN IF EXISTS media_index;
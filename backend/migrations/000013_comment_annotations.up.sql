@@ -0,0 +1,19 @@
+-- Привязка комментария к точке или области медиа поста в нормализованных координатах (0..1)
+ALTER TABLE comments ADD COLUMN media_index INT DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN pos_x DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN pos_y DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN width DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN height DOUBLE PRECISION DEFAULT NULL;
+
+ALTER TABLE comments ADD CONSTRAINT comments_annotation_check CHECK (
+    (media_index IS NULL AND pos_x IS NULL AND pos_y IS NULL AND width IS NULL AND height IS NULL)
+    OR (
+        media_index >= 0
+        AND pos_x BETWEEN 0 AND 1 AND pos_y BETWEEN 0 AND 1
+        AND ((width IS NULL AND height IS NULL) OR (width > 0 AND height > 0 AND pos_x + width <= 1 AND pos_y + height <= 1))
+    )
+);
+
+-- Отметка о том, что замечание учтено
+ALTER TABLE comments ADD COLUMN resolved_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE comments ADD COLUMN resolved_by INT REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_comment_replies_count ON comments;
+DROP FUNCTION IF EXISTS update_comment_replies_count();
+
+DROP INDEX IF EXISTS idx_comments_parent_id;
+DROP INDEX IF EXISTS idx_comments_post_id_top_level;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS replies_count;
+ALTER TABLE comments DROP COLUMN IF EXISTS depth;
+ALTER TABLE comments DROP COLUMN IF EXISTS parent_id;
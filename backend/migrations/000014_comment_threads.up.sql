@@ -0,0 +1,39 @@
+-- Ответы на комментарии. Удаление комментария в корзину скрывает всю ветку под ним,
+-- окончательное удаление удаляет ветку каскадом
+ALTER TABLE comments ADD COLUMN parent_id INT REFERENCES comments(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN depth INT NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN replies_count INT NOT NULL DEFAULT 0;
+
+-- Keyset-пагинация комментариев верхнего уровня и ответов по (created_at, id)
+CREATE INDEX idx_comments_post_id_top_level ON comments (post_id, created_at, id)
+WHERE parent_id IS NULL AND deleted_at IS NULL;
+
+CREATE INDEX idx_comments_parent_id ON comments (parent_id, created_at, id)
+WHERE deleted_at IS NULL;
+
+-- Счетчик учитывает только прямые ответы, которые не лежат в корзине
+CREATE OR REPLACE FUNCTION update_comment_replies_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.parent_id IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            UPDATE comments SET replies_count = replies_count + 1 WHERE id = NEW.parent_id;
+        END IF;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.parent_id IS NOT NULL AND OLD.deleted_at IS NULL THEN
+            UPDATE comments SET replies_count = replies_count - 1 WHERE id = OLD.parent_id;
+        END IF;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF NEW.parent_id IS NOT NULL AND (OLD.deleted_at IS NULL) <> (NEW.deleted_at IS NULL) THEN
+            UPDATE comments
+            SET replies_count = replies_count + CASE WHEN NEW.deleted_at IS NULL THEN 1 ELSE -1 END
+            WHERE id = NEW.parent_id;
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_comment_replies_count
+AFTER INSERT OR DELETE OR UPDATE OF deleted_at ON comments
+FOR EACH ROW
+EXECUTE FUNCTION update_comment_replies_count();
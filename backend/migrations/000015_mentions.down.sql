@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_lower_nickname;
+
+DROP TABLE IF EXISTS mentions;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,34 @@
+-- Уведомления пользователей о событиях. Пока заполняются только упоминаниями
+CREATE TABLE notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- Получатель
+    actor_id INT REFERENCES users(id) ON DELETE CASCADE, -- Пользователь, вызвавший событие
+    type VARCHAR(30) NOT NULL,
+    post_id INT REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id INT REFERENCES comments(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_notifications_user_id_created_at ON notifications (user_id, created_at DESC);
+
+-- Упоминания @никнейма в описании поста (comment_id IS NULL) или в комментарии.
+-- Позиция и длина считаются в символах Unicode от начала текста
+CREATE TABLE mentions (
+    id SERIAL PRIMARY KEY,
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id INT REFERENCES comments(id) ON DELETE CASCADE,
+    author_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentioned_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text_offset INT NOT NULL,
+    text_length INT NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- Уведомление отправляется, когда пост опубликован
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_mentions_post_id_comment_id ON mentions (post_id, comment_id);
+CREATE INDEX idx_mentions_comment_id ON mentions (comment_id) WHERE comment_id IS NOT NULL;
+CREATE INDEX idx_mentions_pending ON mentions (post_id) WHERE notified_at IS NULL;
+
+-- Поиск упоминаемых пользователей по никнейму без учета регистра
+CREATE INDEX idx_users_lower_nickname ON users (LOWER(nickname));
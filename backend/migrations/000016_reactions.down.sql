@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS update_comment_reaction_counts ON comment_reactions;
+DROP TRIGGER IF EXISTS update_post_reaction_counts ON post_reactions;
+
+DROP FUNCTION IF EXISTS update_comment_reaction_counts();
+DROP FUNCTION IF EXISTS update_post_reaction_counts();
+
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS post_reactions;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS reaction_counts;
+ALTER TABLE posts DROP COLUMN IF EXISTS reaction_counts;
@@ -0,0 +1,77 @@
+-- Счетчики реакций по типам в виде {"inspiring": 3, "clean-ui": 1}
+ALTER TABLE posts ADD COLUMN reaction_counts JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE comments ADD COLUMN reaction_counts JSONB NOT NULL DEFAULT '{}';
+
+-- Реакции на посты. Пользователь может поставить несколько разных реакций
+CREATE TABLE post_reactions (
+    post_id INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (post_id, user_id, type)
+);
+
+-- Список отреагировавших по типу реакции, новые сверху
+CREATE INDEX idx_post_reactions_post_id_type ON post_reactions (post_id, type, created_at DESC);
+
+-- Реакции на комментарии
+CREATE TABLE comment_reactions (
+    comment_id INT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (comment_id, user_id, type)
+);
+
+CREATE INDEX idx_comment_reactions_comment_id_type ON comment_reactions (comment_id, type, created_at DESC);
+
+-- Триггерные функции для счетчиков реакций. Тип с нулевым счетчиком удаляется из объекта
+CREATE OR REPLACE FUNCTION update_post_reaction_counts() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts
+        SET reaction_counts = jsonb_set(
+            reaction_counts, ARRAY[NEW.type], to_jsonb(COALESCE((reaction_counts->>NEW.type)::int, 0) + 1)
+        )
+        WHERE id = NEW.post_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE posts
+        SET reaction_counts = CASE
+            WHEN COALESCE((reaction_counts->>OLD.type)::int, 0) <= 1 THEN reaction_counts - OLD.type
+            ELSE jsonb_set(reaction_counts, ARRAY[OLD.type], to_jsonb((reaction_counts->>OLD.type)::int - 1))
+        END
+        WHERE id = OLD.post_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_post_reaction_counts
+AFTER INSERT OR DELETE ON post_reactions
+FOR EACH ROW
+EXECUTE FUNCTION update_post_reaction_counts();
+
+CREATE OR REPLACE FUNCTION update_comment_reaction_counts() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE comments
+        SET reaction_counts = jsonb_set(
+            reaction_counts, ARRAY[NEW.type], to_jsonb(COALESCE((reaction_counts->>NEW.type)::int, 0) + 1)
+        )
+        WHERE id = NEW.comment_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE comments
+        SET reaction_counts = CASE
+            WHEN COALESCE((reaction_counts->>OLD.type)::int, 0) <= 1 THEN reaction_counts - OLD.type
+            ELSE jsonb_set(reaction_counts, ARRAY[OLD.type], to_jsonb((reaction_counts->>OLD.type)::int - 1))
+        END
+        WHERE id = OLD.comment_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_comment_reaction_counts
+AFTER INSERT OR DELETE ON comment_reactions
+FOR EACH ROW
+EXECUTE FUNCTION update_comment_reaction_counts();
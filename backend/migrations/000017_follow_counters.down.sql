@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS update_category_followers_count ON category_follows;
+DROP TRIGGER IF EXISTS update_user_follows_count ON user_follows;
+
+DROP FUNCTION IF EXISTS update_category_followers_count();
+DROP FUNCTION IF EXISTS update_user_follows_count();
+
+DROP INDEX IF EXISTS idx_category_follows_user_id_created_at;
+DROP INDEX IF EXISTS idx_user_follows_followee_id_created_at;
+DROP INDEX IF EXISTS idx_user_follows_follower_id_created_at;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS followers_count;
+ALTER TABLE users DROP COLUMN IF EXISTS following_count;
+ALTER TABLE users DROP COLUMN IF EXISTS followers_count;
@@ -0,0 +1,53 @@
+-- Счетчики подписок на профилях и категориях
+ALTER TABLE users ADD COLUMN followers_count INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN following_count INT NOT NULL DEFAULT 0;
+ALTER TABLE categories ADD COLUMN followers_count INT NOT NULL DEFAULT 0;
+
+-- Заполняем счетчики для уже существующих подписок
+UPDATE users u SET
+    followers_count = (SELECT COUNT(*) FROM user_follows f WHERE f.followee_id = u.id),
+    following_count = (SELECT COUNT(*) FROM user_follows f WHERE f.follower_id = u.id);
+
+UPDATE categories c SET
+    followers_count = (SELECT COUNT(*) FROM category_follows f WHERE f.category_id = c.id);
+
+-- Списки подписок и подписчиков, новые сверху
+CREATE INDEX idx_user_follows_follower_id_created_at ON user_follows (follower_id, created_at DESC);
+CREATE INDEX idx_user_follows_followee_id_created_at ON user_follows (followee_id, created_at DESC);
+CREATE INDEX idx_category_follows_user_id_created_at ON category_follows (user_id, created_at DESC);
+
+-- Триггерная функция для обновления счетчиков подписок на пользователей
+CREATE OR REPLACE FUNCTION update_user_follows_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.followee_id;
+        UPDATE users SET following_count = following_count + 1 WHERE id = NEW.follower_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.followee_id;
+        UPDATE users SET following_count = following_count - 1 WHERE id = OLD.follower_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_user_follows_count
+AFTER INSERT OR DELETE ON user_follows
+FOR EACH ROW
+EXECUTE FUNCTION update_user_follows_count();
+
+-- Триггерная функция для обновления счетчика подписчиков категории
+CREATE OR REPLACE FUNCTION update_category_followers_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE categories SET followers_count = followers_count + 1 WHERE id = NEW.category_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE categories SET followers_count = followers_count - 1 WHERE id = OLD.category_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_category_followers_count
+AFTER INSERT OR DELETE ON category_follows
+FOR EACH ROW
+EXECUTE FUNCTION update_category_followers_count();
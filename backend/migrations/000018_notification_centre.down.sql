@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_unread;
+DROP INDEX IF EXISTS idx_notifications_unread_group;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS group_key;
+ALTER TABLE notifications DROP COLUMN IF EXISTS reject_reason;
+ALTER TABLE notifications DROP COLUMN IF EXISTS actor_ids;
@@ -0,0 +1,15 @@
+-- Все пользователи, вызвавшие событие, в порядке появления. actor_id хранит последнего из них
+ALTER TABLE notifications ADD COLUMN actor_ids INT[] NOT NULL DEFAULT '{}';
+-- Причина отклонения поста для уведомления об отклонении
+ALTER TABLE notifications ADD COLUMN reject_reason TEXT DEFAULT NULL;
+-- Ключ группировки однотипных событий, например like:post:42. NULL — событие не группируется
+ALTER TABLE notifications ADD COLUMN group_key VARCHAR(100) DEFAULT NULL;
+
+UPDATE notifications SET actor_ids = ARRAY[actor_id] WHERE actor_id IS NOT NULL;
+
+-- Новое событие присоединяется к непрочитанному уведомлению той же группы
+CREATE UNIQUE INDEX idx_notifications_unread_group ON notifications (user_id, group_key)
+    WHERE read_at IS NULL AND group_key IS NOT NULL;
+
+-- Счетчик непрочитанных
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
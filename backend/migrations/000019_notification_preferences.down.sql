@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_digest;
+
+ALTER TABLE users DROP COLUMN IF EXISTS digest_sent_at;
+ALTER TABLE users DROP COLUMN IF EXISTS digest_frequency;
+
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,15 @@
+-- Настройки уведомлений по типам событий. Строка хранится только для типов,
+-- настройка которых отличается от значения по умолчанию ('email')
+CREATE TABLE notification_preferences (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL, -- 'in_app', 'email' или 'off'
+    PRIMARY KEY (user_id, type)
+);
+
+-- Периодичность email-дайджеста: 'daily', 'weekly' или 'off', и время отправки последнего
+ALTER TABLE users ADD COLUMN digest_frequency VARCHAR(20) NOT NULL DEFAULT 'weekly';
+ALTER TABLE users ADD COLUMN digest_sent_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Фоновая задача выбирает пользователей, которым пора отправить дайджест
+CREATE INDEX idx_users_digest ON users (digest_frequency, digest_sent_at) WHERE digest_frequency <> 'off';
@@ -0,0 +1,40 @@
+-- Кто может писать пользователю в личные сообщения: 'everyone', 'following' (только те,
+-- на кого он подписан) или 'nobody'
+ALTER TABLE users ADD COLUMN dm_policy VARCHAR(20) NOT NULL DEFAULT 'everyone';
+
+-- Диалог двух пользователей. Пара хранится упорядоченной, чтобы диалог был единственным
+CREATE TABLE conversations (
+    id SERIAL PRIMARY KEY,
+    user1_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user2_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_message_id INT DEFAULT NULL,
+    last_message_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CHECK (user1_id < user2_id),
+    UNIQUE (user1_id, user2_id)
+);
+
+-- Состояние диалога для каждого участника
+CREATE TABLE conversation_members (
+    conversation_id INT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL, -- 'accepted' (входящие), 'request' (запросы) или 'declined'
+    last_read_message_id INT NOT NULL DEFAULT 0, -- Последнее прочитанное сообщение
+    last_read_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX idx_conversation_members_user_id_status ON conversation_members (user_id, status);
+
+CREATE TABLE messages (
+    id SERIAL PRIMARY KEY,
+    conversation_id INT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_messages_conversation_id_id ON messages (conversation_id, id DESC);
+
+-- Сортировка входящих по последнему сообщению
+CREATE INDEX idx_conversations_last_message_at ON conversations (last_message_at DESC, id DESC);
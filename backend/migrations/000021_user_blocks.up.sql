@@ -0,0 +1,20 @@
+-- Блокировки: заблокированный пользователь не может комментировать, упоминать,
+-- писать, подписываться и не видит посты заблокировавшего. Действует в обе стороны
+CREATE TABLE user_blocks (
+    blocker_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked_id ON user_blocks (blocked_id);
+
+-- Заглушенные пользователи: их посты и комментарии скрываются из лент заглушившего
+CREATE TABLE user_mutes (
+    muter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (muter_id, muted_id),
+    CHECK (muter_id <> muted_id)
+);
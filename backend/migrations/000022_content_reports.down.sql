@@ -0,0 +1,5 @@
+UPDATE posts SET status = 'approved' WHERE status = 'hidden';
+
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_at;
+
+DROP TABLE IF EXISTS reports;
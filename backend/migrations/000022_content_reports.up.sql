@@ -0,0 +1,23 @@
+-- Жалобы пользователей на опубликованные посты и комментарии.
+-- Один пользователь может пожаловаться на объект только один раз
+CREATE TABLE reports (
+    id SERIAL PRIMARY KEY,
+    reporter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL,
+    target_id INT NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    details TEXT DEFAULT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolution VARCHAR(20) DEFAULT NULL,
+    resolved_by INT DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (reporter_id, target_type, target_id)
+);
+
+CREATE INDEX idx_reports_target ON reports (target_type, target_id, status);
+CREATE INDEX idx_reports_status_created_at ON reports (status, created_at DESC);
+
+-- Скрытые по жалобам комментарии не показываются в обсуждении.
+-- Посты скрываются статусом hidden
+ALTER TABLE comments ADD COLUMN hidden_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderation_actions;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS moderated_by;
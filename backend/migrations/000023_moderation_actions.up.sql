@@ -0,0 +1,20 @@
+-- Модератор, принявший последнее решение по посту
+ALTER TABLE posts ADD COLUMN moderated_by INT DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+-- Журнал действий модераторов: решение, его причина и состояние объекта до и после.
+-- moderator_id не указан для автоматических действий
+CREATE TABLE moderation_actions (
+    id SERIAL PRIMARY KEY,
+    moderator_id INT DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(30) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id INT NOT NULL,
+    reason TEXT DEFAULT NULL,
+    previous_state JSONB DEFAULT NULL,
+    new_state JSONB DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_moderation_actions_created_at ON moderation_actions (created_at DESC, id DESC);
+CREATE INDEX idx_moderation_actions_moderator ON moderation_actions (moderator_id, created_at DESC);
+CREATE INDEX idx_moderation_actions_target ON moderation_actions (target_type, target_id, created_at DESC);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_posts_category_id_feed_time_id;
+DROP INDEX IF EXISTS idx_posts_user_id_feed_time_id;
+DROP INDEX IF EXISTS idx_posts_status_feed_time_id;
+
+CREATE INDEX idx_posts_status_created_at_id ON posts (status, created_at DESC, id DESC);
+CREATE INDEX idx_posts_user_id_created_at_id ON posts (user_id, created_at DESC, id DESC);
+CREATE INDEX idx_posts_category_id_created_at_id ON posts (category_id, created_at DESC, id DESC);
+
+ALTER TABLE posts DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,16 @@
+-- Время первой публикации поста. Раньше запланированный пост при публикации получал
+-- created_at = publish_at; теперь created_at не меняется, а лента сортируется по published_at.
+-- Уже опубликованным постам время публикации переносится из created_at, чтобы порядок лент
+-- и выданные курсоры не сдвинулись
+ALTER TABLE posts ADD COLUMN published_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+UPDATE posts SET published_at = created_at WHERE status IN ('approved', 'hidden');
+
+-- Индексы keyset-пагинации лент переводятся на время публикации
+DROP INDEX IF EXISTS idx_posts_status_created_at_id;
+DROP INDEX IF EXISTS idx_posts_user_id_created_at_id;
+DROP INDEX IF EXISTS idx_posts_category_id_created_at_id;
+
+CREATE INDEX idx_posts_status_feed_time_id ON posts (status, COALESCE(published_at, created_at) DESC, id DESC);
+CREATE INDEX idx_posts_user_id_feed_time_id ON posts (user_id, COALESCE(published_at, created_at) DESC, id DESC);
+CREATE INDEX idx_posts_category_id_feed_time_id ON posts (category_id, COALESCE(published_at, created_at) DESC, id DESC);
@@ -0,0 +1,37 @@
+-- Счетчик ответов не учитывает и скрытые по жалобам ответы (hidden_at),
+-- так же как ответы в корзине
+CREATE OR REPLACE FUNCTION update_comment_replies_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.parent_id IS NOT NULL AND NEW.deleted_at IS NULL AND NEW.hidden_at IS NULL THEN
+            UPDATE comments SET replies_count = replies_count + 1 WHERE id = NEW.parent_id;
+        END IF;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.parent_id IS NOT NULL AND OLD.deleted_at IS NULL AND OLD.hidden_at IS NULL THEN
+            UPDATE comments SET replies_count = replies_count - 1 WHERE id = OLD.parent_id;
+        END IF;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF NEW.parent_id IS NOT NULL
+            AND (OLD.deleted_at IS NULL AND OLD.hidden_at IS NULL) <> (NEW.deleted_at IS NULL AND NEW.hidden_at IS NULL) THEN
+            UPDATE comments
+            SET replies_count = replies_count + CASE WHEN NEW.deleted_at IS NULL AND NEW.hidden_at IS NULL THEN 1 ELSE -1 END
+            WHERE id = NEW.parent_id;
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_comment_replies_count ON comments;
+
+CREATE TRIGGER update_comment_replies_count
+AFTER INSERT OR DELETE OR UPDATE OF deleted_at, hidden_at ON comments
+FOR EACH ROW
+EXECUTE FUNCTION update_comment_replies_count();
+
+-- Пересчитываем счетчики с учетом уже скрытых ответов
+UPDATE comments c
+SET replies_count = (
+    SELECT COUNT(*) FROM comments r
+    WHERE r.parent_id = c.id AND r.deleted_at IS NULL AND r.hidden_at IS NULL
+);
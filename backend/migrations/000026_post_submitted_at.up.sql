@@ -0,0 +1,12 @@
+-- Время последней отправки поста на модерацию: при создании, отправке черновика
+-- и возврате на модерацию после правки. Время модерации считается от него, а не от created_at
+ALTER TABLE posts ADD COLUMN submitted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Для существующих постов берем время последней правки, вернувшей пост на модерацию,
+-- а если таких не было — время создания
+UPDATE posts p
+SET submitted_at = COALESCE(
+    (SELECT MAX(r.created_at) FROM post_revisions r WHERE r.post_id = p.id AND r.remoderated),
+    p.created_at
+)
+WHERE p.status <> 'draft';
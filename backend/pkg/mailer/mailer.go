@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message письмо с HTML и текстовой версией
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	Headers map[string]string // Дополнительные заголовки, например List-Unsubscribe
+}
+
+// Mailer отправляет письма. LogMailer только пишет письма в журнал и подходит
+// для разработки, SMTPMailer отправляет их через SMTP-сервер
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogMailer пишет письма в журнал вместо отправки
+type LogMailer struct{}
+
+// NewLogMailer создает новый экземпляр LogMailer
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send записывает письмо в журнал
+func (m *LogMailer) Send(_ context.Context, msg Message) error {
+	logrus.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"headers": msg.Headers,
+	}).Info(msg.Text)
+
+	return nil
+}
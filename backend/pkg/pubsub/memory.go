@@ -0,0 +1,70 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryHub шина событий в памяти процесса
+type MemoryHub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*Subscription]struct{}
+}
+
+// NewMemoryHub создает новый экземпляр MemoryHub
+func NewMemoryHub() *MemoryHub {
+	return &MemoryHub{topics: make(map[string]map[*Subscription]struct{})}
+}
+
+// Publish доставляет сообщение подписчикам темы
+func (h *MemoryHub) Publish(_ context.Context, msg Message) error {
+	h.deliver(msg)
+	return nil
+}
+
+// Subscribe подписывается на сообщения указанных тем
+func (h *MemoryHub) Subscribe(topics ...string) *Subscription {
+	ch := make(chan Message, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, topics: topics, cancel: h.unsubscribe}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, topic := range topics {
+		if h.topics[topic] == nil {
+			h.topics[topic] = make(map[*Subscription]struct{})
+		}
+		h.topics[topic][sub] = struct{}{}
+	}
+
+	return sub
+}
+
+// deliver рассылает сообщение подписчикам без блокировки: если буфер подписчика
+// заполнен (клиент не успевает читать), сообщение для него пропускается
+func (h *MemoryHub) deliver(msg Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.topics[msg.Topic] {
+		select {
+		case sub.ch <- msg:
+		default:
+		}
+	}
+}
+
+// unsubscribe удаляет подписку из всех тем и закрывает ее канал
+func (h *MemoryHub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, topic := range sub.topics {
+		delete(h.topics[topic], sub)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+
+	close(sub.ch)
+}
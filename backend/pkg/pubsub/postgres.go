@@ -0,0 +1,102 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxNotifyPayload ограничение PostgreSQL на размер payload в NOTIFY (8000 байт) с запасом
+	maxNotifyPayload = 7900
+
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// PostgresHub шина событий для нескольких экземпляров приложения.
+// Publish отправляет сообщение через pg_notify, а Run слушает канал и доставляет
+// полученные сообщения локальным подписчикам, в том числе свои собственные
+type PostgresHub struct {
+	*MemoryHub
+	db      *sqlx.DB
+	dsn     string
+	channel string
+}
+
+// NewPostgresHub создает новый экземпляр PostgresHub. Для доставки сообщений нужно запустить Run
+func NewPostgresHub(db *sqlx.DB, dsn string, channel string) *PostgresHub {
+	return &PostgresHub{
+		MemoryHub: NewMemoryHub(),
+		db:        db,
+		dsn:       dsn,
+		channel:   channel,
+	}
+}
+
+// Publish отправляет сообщение всем экземплярам приложения
+func (h *PostgresHub) Publish(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("message for topic %s is too large: %d bytes", msg.Topic, len(payload))
+	}
+
+	if _, err := h.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", h.channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+
+	return nil
+}
+
+// Run слушает канал PostgreSQL до отмены контекста. Соединение восстанавливается
+// автоматически; сообщения, отправленные во время переподключения, теряются
+func (h *PostgresHub) Run(ctx context.Context) {
+	listener := pq.NewListener(h.dsn, listenerMinReconnect, listenerMaxReconnect, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logrus.Warnf("Realtime listener event %d: %s", event, err.Error())
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(h.channel); err != nil {
+		logrus.Errorf("Failed to listen channel %s: %s", h.channel, err.Error())
+		return
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-listener.Notify:
+			// nil приходит после переподключения
+			if notification == nil {
+				continue
+			}
+
+			var msg Message
+			if err := json.Unmarshal([]byte(notification.Extra), &msg); err != nil {
+				logrus.Warnf("Failed to decode realtime message: %s", err.Error())
+				continue
+			}
+
+			h.deliver(msg)
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				logrus.Warnf("Realtime listener ping failed: %s", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// subscriptionBuffer сколько сообщений может накопиться у подписчика, прежде чем новые начнут пропускаться
+const subscriptionBuffer = 32
+
+// Message событие, опубликованное в теме
+type Message struct {
+	Topic string          `json:"topic"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Hub шина событий: публикация сообщений в темы и подписка на них.
+// Реализация в памяти работает в пределах одного процесса, PostgresHub
+// доставляет сообщения всем экземплярам приложения через LISTEN/NOTIFY
+type Hub interface {
+	Publish(ctx context.Context, msg Message) error
+	Subscribe(topics ...string) *Subscription
+}
+
+// Subscription подписка на набор тем. Сообщения читаются из C до вызова Close
+type Subscription struct {
+	C <-chan Message
+
+	ch     chan Message
+	topics []string
+	once   sync.Once
+	cancel func(*Subscription)
+}
+
+// Close отменяет подписку и закрывает канал C
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.cancel(s)
+	})
+}
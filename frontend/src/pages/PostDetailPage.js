@@ -101,7 +101,7 @@ const PostDetailPage = () => {
       // Загружаем комментарии
       const commentsResponse = await api.get(`/api/v1/public/posts/${id}/comments`);
       console.log('Полученные комментарии:', commentsResponse.data);
-      setComments(commentsResponse.data || []);
+      setComments(commentsResponse.data?.comments || []);
     } catch (err) {
       console.error('Ошибка при загрузке данных поста:', err);
       console.error('Детали ошибки:', err.message);
@@ -277,7 +277,7 @@ const PostDetailPage = () => {
         
         try {
           const commentsResponse = await api.get(`/api/v1/public/posts/${id}/comments`);
-          setComments(commentsResponse.data || []);
+          setComments(commentsResponse.data?.comments || []);
         } catch (fetchError) {
           console.error('Ошибка при получении комментариев:', fetchError);
         }